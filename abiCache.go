@@ -0,0 +1,270 @@
+package aptos
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// PackageRegistryResourceType is the resource recording the packages published at an address, and how many times each
+// was upgraded
+const PackageRegistryResourceType = "0x1::code::PackageRegistry"
+
+// AbiCacheConfig configures an [AbiCache]
+type AbiCacheConfig struct {
+	Capacity         int           // Maximum number of module ABIs kept in memory, the least recently used are evicted first
+	Dir              string        // Directory to persist ABIs in across processes, empty to only keep them in memory
+	RevalidatePeriod time.Duration // How long a cached ABI is used before its package's upgrade number is checked again
+}
+
+// DefaultAbiCacheConfig is a reasonable [AbiCacheConfig], 1000 modules in memory revalidated every minute
+func DefaultAbiCacheConfig() AbiCacheConfig {
+	return AbiCacheConfig{
+		Capacity:         1000,
+		RevalidatePeriod: time.Minute,
+	}
+}
+
+// AbiCache caches module ABIs for the ABI driven builders [NodeClient.EntryFunctionWithArgs],
+// [NodeClient.ViewWithArgs] and [NodeClient.ViewTyped].  Pass it as an option to [NewClient] or [NewNodeClient], or set
+// it later with [NodeClient.SetAbiCache].  A cache may be shared by clients of the same network.
+//
+// A cached ABI is used without any request for the RevalidatePeriod.  After that, the upgrade number of its package in
+// the [PackageRegistryResourceType] of its address is checked, and the ABI is only fetched again if the package was
+// upgraded.  Modules that aren't in a package registry are fetched again every RevalidatePeriod.
+//
+// Only requests for the latest ledger version use the cache.
+//
+//	cache, _ := NewAbiCache(AbiCacheConfig{Capacity: 100, Dir: "abis", RevalidatePeriod: 10 * time.Minute})
+//	client, _ := NewClient(MainnetConfig, cache)
+type AbiCache struct {
+	config AbiCacheConfig
+
+	mutex   sync.Mutex
+	entries map[ModuleId]*list.Element // Elements of lru, values are *abiCacheEntry
+	lru     *list.List                 // Most recently used at the front
+}
+
+// abiCacheEntry is a cached module ABI, as persisted in JSON
+type abiCacheEntry struct {
+	Package       string          `json:"package"`        // Package of the module, empty if not in a package registry
+	UpgradeNumber uint64          `json:"upgrade_number"` // Upgrade number of the package when the ABI was fetched
+	Abi           *api.MoveModule `json:"abi"`
+
+	moduleId    ModuleId
+	validatedAt time.Time // Zero for an entry loaded from disk, which is revalidated on first use
+}
+
+// NewAbiCache creates an [AbiCache], creating its Dir if it doesn't exist
+//
+// Defaults to [DefaultAbiCacheConfig] if no config is given
+func NewAbiCache(config ...AbiCacheConfig) (*AbiCache, error) {
+	cacheConfig := DefaultAbiCacheConfig()
+	if len(config) > 0 {
+		cacheConfig = config[0]
+	}
+	if cacheConfig.Capacity < 1 {
+		return nil, fmt.Errorf("abi cache capacity must be at least 1, got %d", cacheConfig.Capacity)
+	}
+	if cacheConfig.Dir != "" {
+		err := os.MkdirAll(cacheConfig.Dir, 0o700)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &AbiCache{
+		config:  cacheConfig,
+		entries: make(map[ModuleId]*list.Element),
+		lru:     list.New(),
+	}, nil
+}
+
+// Len returns the number of module ABIs in memory
+func (cache *AbiCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.lru.Len()
+}
+
+// Invalidate removes a module's ABI from the cache, in memory and on disk, so it's fetched on next use
+func (cache *AbiCache) Invalidate(address AccountAddress, moduleName string) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	moduleId := ModuleId{Address: address, Name: moduleName}
+	if element, ok := cache.entries[moduleId]; ok {
+		cache.lru.Remove(element)
+		delete(cache.entries, moduleId)
+	}
+	if cache.config.Dir == "" {
+		return nil
+	}
+	err := os.Remove(cache.path(moduleId))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// moduleAbi returns the ABI of a module at the latest ledger version, fetching it with rc if it isn't cached or its
+// package was upgraded.  Package registries are fetched into registries, to be reused for other modules of an address.
+func (cache *AbiCache) moduleAbi(ctx context.Context, rc *NodeClient, moduleId ModuleId, registries map[AccountAddress]*packageRegistry) (*api.MoveModule, error) {
+	entry, ok := cache.get(moduleId)
+	if ok && time.Since(entry.validatedAt) < cache.config.RevalidatePeriod {
+		return entry.Abi, nil
+	}
+
+	// The upgrade number is fetched before the module, so an upgrade in between is caught by the next revalidation
+	registry := registries[moduleId.Address]
+	if registry == nil {
+		var err error
+		registry, err = rc.packageRegistry(ctx, moduleId.Address)
+		if err != nil {
+			return nil, err
+		}
+		registries[moduleId.Address] = registry
+	}
+	packageName, upgradeNumber := registry.packageOf(moduleId.Name)
+	if ok && entry.Package != "" && entry.Package == packageName && entry.UpgradeNumber == upgradeNumber {
+		entry.validatedAt = time.Now()
+		_ = cache.add(&entry, false) // Nothing to persist, so it can't fail
+		return entry.Abi, nil
+	}
+
+	module, err := rc.AccountModuleCtx(ctx, moduleId.Address, moduleId.Name)
+	if err != nil {
+		return nil, err
+	}
+	if module.Abi == nil {
+		return nil, fmt.Errorf("module %s::%s has no ABI", moduleId.Address.String(), moduleId.Name)
+	}
+	return module.Abi, cache.add(&abiCacheEntry{
+		Package:       packageName,
+		UpgradeNumber: upgradeNumber,
+		Abi:           module.Abi,
+		moduleId:      moduleId,
+		validatedAt:   time.Now(),
+	}, true)
+}
+
+// get returns a copy of the entry for moduleId, loading it from disk if it isn't in memory
+func (cache *AbiCache) get(moduleId ModuleId) (abiCacheEntry, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.entries[moduleId]; ok {
+		cache.lru.MoveToFront(element)
+		return *entryOf(element), true
+	}
+	if cache.config.Dir == "" {
+		return abiCacheEntry{}, false
+	}
+
+	// A missing or unreadable file is only a cache miss
+	data, err := os.ReadFile(cache.path(moduleId))
+	if err != nil {
+		return abiCacheEntry{}, false
+	}
+	entry := &abiCacheEntry{}
+	err = json.Unmarshal(data, entry)
+	if err != nil || entry.Abi == nil {
+		return abiCacheEntry{}, false
+	}
+	entry.moduleId = moduleId
+	cache.insertLocked(entry)
+	return *entry, true
+}
+
+// add adds or replaces the entry in memory, and on disk if persist is set.  The file is written after unlocking, so
+// lookups aren't held up by the disk.
+func (cache *AbiCache) add(entry *abiCacheEntry, persist bool) error {
+	cache.mutex.Lock()
+	if element, ok := cache.entries[entry.moduleId]; ok {
+		cache.lru.Remove(element)
+		delete(cache.entries, entry.moduleId)
+	}
+	cache.insertLocked(entry)
+	cache.mutex.Unlock()
+
+	if !persist || cache.config.Dir == "" {
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(cache.path(entry.moduleId), data)
+}
+
+// insertLocked inserts a new entry as the most recently used, evicting the least recently used beyond the capacity
+func (cache *AbiCache) insertLocked(entry *abiCacheEntry) {
+	cache.entries[entry.moduleId] = cache.lru.PushFront(entry)
+	for cache.lru.Len() > cache.config.Capacity {
+		oldest := cache.lru.Back()
+		cache.lru.Remove(oldest)
+		delete(cache.entries, entryOf(oldest).moduleId)
+	}
+}
+
+// entryOf returns the entry of an element of the LRU list
+func entryOf(element *list.Element) *abiCacheEntry {
+	entry, _ := element.Value.(*abiCacheEntry)
+	return entry
+}
+
+// path is the file a module's ABI is persisted in
+func (cache *AbiCache) path(moduleId ModuleId) string {
+	return filepath.Join(cache.config.Dir, fmt.Sprintf("%s.%s.json", moduleId.Address.String(), moduleId.Name))
+}
+
+// SetAbiCache sets the [AbiCache] used by the ABI driven builders, nil disables caching
+//
+//	cache, _ := NewAbiCache()
+//	client.SetAbiCache(cache)
+func (rc *NodeClient) SetAbiCache(cache *AbiCache) {
+	rc.abiCache.Store(cache)
+}
+
+// packageRegistry is the data of a [PackageRegistryResourceType]
+type packageRegistry struct {
+	Packages []struct {
+		Name          string `json:"name"`
+		UpgradeNumber uint64 `json:"upgrade_number,string"`
+		Modules       []struct {
+			Name string `json:"name"`
+		} `json:"modules"`
+	} `json:"packages"`
+}
+
+// packageOf returns the name and upgrade number of the package containing a module.  The name is empty if the module
+// isn't in the registry.
+func (registry *packageRegistry) packageOf(moduleName string) (string, uint64) {
+	for _, pkg := range registry.Packages {
+		for _, module := range pkg.Modules {
+			if module.Name == moduleName {
+				return pkg.Name, pkg.UpgradeNumber
+			}
+		}
+	}
+	return "", 0
+}
+
+// packageRegistry fetches the [PackageRegistryResourceType] of an address, which is empty if the address has none
+func (rc *NodeClient) packageRegistry(ctx context.Context, address AccountAddress) (*packageRegistry, error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "resource", PackageRegistryResourceType)
+	resource, err := GetCtx[struct {
+		Data packageRegistry `json:"data"`
+	}](ctx, rc, au.String())
+	if errors.Is(err, ErrResourceNotFound) {
+		return &packageRegistry{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get resource api err: %w", err)
+	}
+	return &resource.Data, nil
+}
@@ -0,0 +1,162 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAbiServer serves the aptos_account and coin module ABIs and a package registry with the given upgrade number, counting
+// the requests for each
+func newAbiServer(t *testing.T, upgradeNumber *atomic.Uint64) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+	moduleRequests := &atomic.Int32{}
+	registryRequests := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/0x1/module/aptos_account":
+			moduleRequests.Add(1)
+			_, _ = w.Write([]byte(`{"bytecode":"0x00","abi":{"address":"0x1","name":"aptos_account","friends":[],"exposed_functions":[
+				{"name":"transfer","visibility":"public","is_entry":true,"is_view":false,"generic_type_params":[],"params":["&signer","address","u64"],"return":[]}
+			],"structs":[]}}`))
+		case "/accounts/0x1/module/coin":
+			moduleRequests.Add(1)
+			_, _ = w.Write([]byte(`{"bytecode":"0x00","abi":{"address":"0x1","name":"coin","friends":[],"exposed_functions":[],"structs":[]}}`))
+		case "/accounts/0x1/resource/" + PackageRegistryResourceType:
+			registryRequests.Add(1)
+			_, _ = fmt.Fprintf(w, `{"type":%q,"data":{"packages":[
+				{"name":"AptosFramework","upgrade_number":"%d","modules":[{"name":"aptos_account"},{"name":"coin"}]}
+			]}}`, PackageRegistryResourceType, upgradeNumber.Load())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found","error_code":"resource_not_found"}`))
+		}
+	}))
+	return server, moduleRequests, registryRequests
+}
+
+func TestAbiCache(t *testing.T) {
+	t.Parallel()
+	upgradeNumber := &atomic.Uint64{}
+	server, moduleRequests, registryRequests := newAbiServer(t, upgradeNumber)
+	defer server.Close()
+
+	cache, err := NewAbiCache(AbiCacheConfig{Capacity: 10, RevalidatePeriod: time.Hour})
+	require.NoError(t, err)
+	client, err := NewNodeClient(server.URL, 4, cache)
+	require.NoError(t, err)
+
+	for range 3 {
+		_, err = client.EntryFunctionWithArgs(AccountOne, "aptos_account", "transfer", []any{}, []any{AccountTwo, uint64(1)})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), moduleRequests.Load())
+	assert.Equal(t, int32(1), registryRequests.Load())
+	assert.Equal(t, 1, cache.Len())
+
+	// Requests at a ledger version skip the cache
+	_, err = client.ViewTyped(&ViewPayload{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: "missing"}, 5)
+	require.Error(t, err)
+	assert.Equal(t, int32(2), moduleRequests.Load())
+
+	// Once stale, the ABI is only fetched again after an upgrade
+	cache.config.RevalidatePeriod = 0
+	_, err = client.EntryFunctionWithArgs(AccountOne, "aptos_account", "transfer", []any{}, []any{AccountTwo, uint64(1)})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), moduleRequests.Load())
+	assert.Equal(t, int32(2), registryRequests.Load())
+
+	upgradeNumber.Store(1)
+	_, err = client.EntryFunctionWithArgs(AccountOne, "aptos_account", "transfer", []any{}, []any{AccountTwo, uint64(1)})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), moduleRequests.Load())
+	assert.Equal(t, int32(3), registryRequests.Load())
+
+	require.NoError(t, cache.Invalidate(AccountOne, "aptos_account"))
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestAbiCache_RegistryFetchedOncePerPass(t *testing.T) {
+	t.Parallel()
+	upgradeNumber := &atomic.Uint64{}
+	server, moduleRequests, registryRequests := newAbiServer(t, upgradeNumber)
+	defer server.Close()
+
+	cache, err := NewAbiCache(AbiCacheConfig{Capacity: 10})
+	require.NoError(t, err)
+	client, err := NewNodeClient(server.URL, 4, cache)
+	require.NoError(t, err)
+
+	// Every use revalidates, but modules of the same address share a registry lookup within a pass
+	for pass := range 2 {
+		abis := client.moduleAbis(context.Background())
+		for _, name := range []string{"aptos_account", "coin"} {
+			abi, err := abis(ModuleId{Address: AccountOne, Name: name})
+			require.NoError(t, err)
+			assert.Equal(t, name, abi.Name)
+		}
+		assert.Equal(t, int32(2), moduleRequests.Load())
+		assert.Equal(t, int32(pass+1), registryRequests.Load())
+	}
+
+	// The cache can be swapped while a pass is using it
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		client.SetAbiCache(nil)
+	}()
+	_, err = client.moduleAbis(context.Background())(ModuleId{Address: AccountOne, Name: "coin"})
+	require.NoError(t, err)
+	wg.Wait()
+}
+
+func TestAbiCache_Persisted(t *testing.T) {
+	t.Parallel()
+	upgradeNumber := &atomic.Uint64{}
+	server, moduleRequests, registryRequests := newAbiServer(t, upgradeNumber)
+	defer server.Close()
+	dir := t.TempDir()
+
+	cache, err := NewAbiCache(AbiCacheConfig{Capacity: 10, Dir: dir, RevalidatePeriod: time.Hour})
+	require.NoError(t, err)
+	client, err := NewNodeClient(server.URL, 4, cache)
+	require.NoError(t, err)
+	expected, err := client.EntryFunctionWithArgs(AccountOne, "aptos_account", "transfer", []any{}, []any{AccountTwo, uint64(1)})
+	require.NoError(t, err)
+
+	// A new cache over the same directory only checks the upgrade number
+	cache, err = NewAbiCache(AbiCacheConfig{Capacity: 10, Dir: dir, RevalidatePeriod: time.Hour})
+	require.NoError(t, err)
+	client.SetAbiCache(cache)
+	entryFunction, err := client.EntryFunctionWithArgs(AccountOne, "aptos_account", "transfer", []any{}, []any{AccountTwo, uint64(1)})
+	require.NoError(t, err)
+	assert.Equal(t, expected, entryFunction)
+	assert.Equal(t, int32(1), moduleRequests.Load())
+	assert.Equal(t, int32(2), registryRequests.Load())
+
+	_, err = NewAbiCache(AbiCacheConfig{})
+	require.Error(t, err)
+}
+
+func TestAbiCache_Evicts(t *testing.T) {
+	t.Parallel()
+	cache, err := NewAbiCache(AbiCacheConfig{Capacity: 2})
+	require.NoError(t, err)
+	for _, name := range []string{"a", "b", "a", "c"} {
+		require.NoError(t, cache.add(&abiCacheEntry{moduleId: ModuleId{Address: AccountOne, Name: name}}, true))
+	}
+	assert.Equal(t, 2, cache.Len())
+	_, ok := cache.get(ModuleId{Address: AccountOne, Name: "b"})
+	assert.False(t, ok)
+	_, ok = cache.get(ModuleId{Address: AccountOne, Name: "a"})
+	assert.True(t, ok)
+}
@@ -0,0 +1,25 @@
+package aptos
+
+import (
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// AccountModuleRecord DeserializeSequence[AccountModuleRecord](bcs) approximates the Rust side BTreeMap<ModuleId,Vec<u8>>
+// They should BCS the same with a prefix Uleb128 length followed by (ModuleId,[]byte) pairs.
+type AccountModuleRecord struct {
+	// Account::Module
+	Id ModuleId
+
+	// Compiled bytecode of the module
+	Bytecode []byte
+}
+
+func (amr *AccountModuleRecord) MarshalBCS(ser *bcs.Serializer) {
+	amr.Id.MarshalBCS(ser)
+	ser.WriteBytes(amr.Bytecode)
+}
+
+func (amr *AccountModuleRecord) UnmarshalBCS(des *bcs.Deserializer) {
+	amr.Id.UnmarshalBCS(des)
+	amr.Bytecode = des.ReadBytes()
+}
@@ -0,0 +1,209 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrSequenceNumbersInFlight is returned by [AccountSequenceNumber.Next] when the in-flight transactions don't commit
+// within MaxWait, [AccountSequenceNumber.Resync] discards them
+var ErrSequenceNumbersInFlight = errors.New("sequence numbers still in flight")
+
+// AccountSequenceNumberConfig configures an [AccountSequenceNumber], zero values use the defaults
+type AccountSequenceNumberConfig struct {
+	MaxInFlight uint64        // Most uncommitted sequence numbers handed out at once, defaults to 100
+	PollPeriod  time.Duration // How often the chain is checked while at MaxInFlight, defaults to 100ms
+	MaxWait     time.Duration // How long Next waits for in-flight transactions to commit, defaults to 30s
+}
+
+// DefaultAccountSequenceNumberConfig is the [AccountSequenceNumberConfig] used by [NewAccountSequenceNumber]
+func DefaultAccountSequenceNumberConfig() AccountSequenceNumberConfig {
+	return AccountSequenceNumberConfig{
+		MaxInFlight: 100,
+		PollPeriod:  100 * time.Millisecond,
+		MaxWait:     30 * time.Second,
+	}
+}
+
+// AccountSequenceNumber hands out sequence numbers for a single sender locally, so transactions can be built without
+// looking up the account each time.  It is safe for concurrent use by many goroutines.
+//
+// At most MaxInFlight numbers are handed out ahead of the on-chain sequence number, after which [AccountSequenceNumber.Next]
+// waits for transactions to commit.  If they don't commit within MaxWait, it returns [ErrSequenceNumbersInFlight], and
+// [AccountSequenceNumber.Resync] hands numbers out again from the on-chain sequence number if they were lost e.g.
+// expired.  The lock isn't held while looking up or waiting on the chain.
+//
+// Pass submission errors to [AccountSequenceNumber.HandleError], so rejected transactions resync it with the chain.
+//
+//	sequenceNumbers := NewAccountSequenceNumber(client, sender.AccountAddress())
+//	seqNum, err := sequenceNumbers.Next()
+//	rawTxn, err := client.BuildTransaction(sender.AccountAddress(), payload, SequenceNumber(seqNum))
+//	...
+//	_, err = client.SubmitTransaction(signedTxn)
+//	sequenceNumbers.HandleError(err)
+type AccountSequenceNumber struct {
+	client  AptosRpcClient
+	address AccountAddress
+	config  AccountSequenceNumberConfig
+
+	mutex       sync.Mutex
+	initialized bool
+	current     uint64 // Next sequence number to hand out
+	onChain     uint64 // Last known on-chain sequence number
+}
+
+// NewAccountSequenceNumber creates an [AccountSequenceNumber] for address, the on-chain sequence number is looked up
+// on first use
+func NewAccountSequenceNumber(client AptosRpcClient, address AccountAddress, config ...AccountSequenceNumberConfig) *AccountSequenceNumber {
+	cfg := DefaultAccountSequenceNumberConfig()
+	if len(config) > 0 {
+		if config[0].MaxInFlight != 0 {
+			cfg.MaxInFlight = config[0].MaxInFlight
+		}
+		if config[0].PollPeriod != 0 {
+			cfg.PollPeriod = config[0].PollPeriod
+		}
+		if config[0].MaxWait != 0 {
+			cfg.MaxWait = config[0].MaxWait
+		}
+	}
+	return &AccountSequenceNumber{
+		client:  client,
+		address: address,
+		config:  cfg,
+	}
+}
+
+// Address is the sender the sequence numbers are for
+func (asn *AccountSequenceNumber) Address() AccountAddress {
+	return asn.address
+}
+
+// Next hands out the next sequence number, waiting while MaxInFlight numbers are uncommitted
+func (asn *AccountSequenceNumber) Next() (uint64, error) {
+	return asn.NextCtx(context.Background())
+}
+
+// NextCtx is [AccountSequenceNumber.Next] with its requests and waiting bound to ctx.  If the in-flight transactions
+// don't commit within MaxWait, it returns [ErrSequenceNumbersInFlight].
+func (asn *AccountSequenceNumber) NextCtx(ctx context.Context) (uint64, error) {
+	var deadline time.Time
+	asn.mutex.Lock()
+	for {
+		if !asn.initialized {
+			asn.mutex.Unlock()
+			onChain, err := asn.onChainSequenceNumber(ctx)
+			if err != nil {
+				return 0, err
+			}
+			asn.mutex.Lock()
+			// Another caller may have initialized it and handed out numbers meanwhile
+			if !asn.initialized {
+				asn.observeLocked(onChain)
+				asn.initialized = true
+			}
+			continue
+		}
+		if asn.current >= asn.onChain && asn.current-asn.onChain < asn.config.MaxInFlight {
+			seqNum := asn.current
+			asn.current++
+			asn.mutex.Unlock()
+			return seqNum, nil
+		}
+		asn.mutex.Unlock()
+
+		// Too many are in flight, so poll the chain without holding the lock until some commit
+		if deadline.IsZero() {
+			deadline = time.Now().Add(asn.config.MaxWait)
+		} else if time.Now().After(deadline) {
+			return 0, fmt.Errorf("%w: account %s after %s", ErrSequenceNumbersInFlight, asn.address.String(), asn.config.MaxWait)
+		} else {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(asn.config.PollPeriod):
+			}
+		}
+		onChain, err := asn.onChainSequenceNumber(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		asn.mutex.Lock()
+		asn.observeLocked(onChain)
+	}
+}
+
+// Set sets the next sequence number to hand out e.g. after submitting a transaction with it elsewhere
+func (asn *AccountSequenceNumber) Set(sequenceNumber uint64) {
+	asn.mutex.Lock()
+	defer asn.mutex.Unlock()
+	asn.current = sequenceNumber
+	asn.initialized = true
+}
+
+// release returns sequenceNumber if it was the last handed out, so a transaction that failed to build leaves no gap
+func (asn *AccountSequenceNumber) release(sequenceNumber uint64) {
+	asn.mutex.Lock()
+	defer asn.mutex.Unlock()
+	if asn.current == sequenceNumber+1 {
+		asn.current = sequenceNumber
+	}
+}
+
+// Resync discards the handed out sequence numbers, and continues from the on-chain sequence number
+func (asn *AccountSequenceNumber) Resync() error {
+	return asn.ResyncCtx(context.Background())
+}
+
+// ResyncCtx is [AccountSequenceNumber.Resync] with its requests bound to ctx
+func (asn *AccountSequenceNumber) ResyncCtx(ctx context.Context) error {
+	onChain, err := asn.onChainSequenceNumber(ctx)
+	if err != nil {
+		return err
+	}
+	asn.mutex.Lock()
+	defer asn.mutex.Unlock()
+	asn.onChain = max(asn.onChain, onChain)
+	asn.current = asn.onChain
+	asn.initialized = true
+	return nil
+}
+
+// HandleError resyncs with the chain if err shows a transaction using a handed out sequence number was rejected for
+// its sequence number e.g. [ErrSequenceNumberTooOld], [ErrSequenceNumberTooNew], or expired with
+// [ErrTransactionExpired].  It returns true if it resynced, other errors including nil are ignored.
+func (asn *AccountSequenceNumber) HandleError(err error) bool {
+	return asn.HandleErrorCtx(context.Background(), err)
+}
+
+// HandleErrorCtx is [AccountSequenceNumber.HandleError] with its requests bound to ctx
+func (asn *AccountSequenceNumber) HandleErrorCtx(ctx context.Context, err error) bool {
+	if !errors.Is(err, ErrSequenceNumberTooOld) &&
+		!errors.Is(err, ErrSequenceNumberTooNew) &&
+		!errors.Is(err, ErrTransactionExpired) {
+		return false
+	}
+	return asn.ResyncCtx(ctx) == nil
+}
+
+// observeLocked records an on-chain sequence number, which never goes backwards, even if looked up out of order
+func (asn *AccountSequenceNumber) observeLocked(onChain uint64) {
+	asn.onChain = max(asn.onChain, onChain)
+	if asn.current < asn.onChain {
+		// Transactions were committed that weren't built with these numbers
+		asn.current = asn.onChain
+	}
+}
+
+// onChainSequenceNumber looks up the sequence number of the account
+func (asn *AccountSequenceNumber) onChainSequenceNumber(ctx context.Context) (uint64, error) {
+	account, err := asn.client.AccountCtx(ctx, asn.address)
+	if err != nil {
+		return 0, err
+	}
+	return account.SequenceNumber()
+}
@@ -0,0 +1,175 @@
+package aptos
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSequenceNumberServer serves an account whose on-chain sequence number can be changed
+func newSequenceNumberServer(t *testing.T) (*httptest.Server, *atomic.Uint64) {
+	t.Helper()
+	onChain := &atomic.Uint64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/0x1", r.URL.Path)
+		_, _ = fmt.Fprintf(w, `{"sequence_number":"%d","authentication_key":"0x0000000000000000000000000000000000000000000000000000000000000001"}`, onChain.Load())
+	}))
+	return server, onChain
+}
+
+func TestAccountSequenceNumber_Concurrent(t *testing.T) {
+	t.Parallel()
+	server, onChain := newSequenceNumberServer(t)
+	defer server.Close()
+	onChain.Store(10)
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	sequenceNumbers := NewAccountSequenceNumber(client, AccountOne)
+
+	var mutex sync.Mutex
+	seen := map[uint64]bool{}
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seqNum, err := sequenceNumbers.Next()
+			assert.NoError(t, err)
+			mutex.Lock()
+			defer mutex.Unlock()
+			seen[seqNum] = true
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, seen, 50)
+	for seqNum := uint64(10); seqNum < 60; seqNum++ {
+		assert.True(t, seen[seqNum])
+	}
+}
+
+func TestAccountSequenceNumber_MaxInFlight(t *testing.T) {
+	t.Parallel()
+	server, onChain := newSequenceNumberServer(t)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	sequenceNumbers := NewAccountSequenceNumber(client, AccountOne, AccountSequenceNumberConfig{
+		MaxInFlight: 2,
+		PollPeriod:  time.Millisecond,
+		MaxWait:     time.Hour,
+	})
+
+	for expected := range uint64(2) {
+		seqNum, err := sequenceNumbers.Next()
+		require.NoError(t, err)
+		assert.Equal(t, expected, seqNum)
+	}
+
+	// The next waits on a commit
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		onChain.Store(1)
+	}()
+	seqNum, err := sequenceNumbers.Next()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), seqNum)
+	assert.Equal(t, uint64(1), onChain.Load())
+}
+
+func TestAccountSequenceNumber_MaxWait(t *testing.T) {
+	t.Parallel()
+	server, onChain := newSequenceNumberServer(t)
+	defer server.Close()
+	onChain.Store(5)
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	sequenceNumbers := NewAccountSequenceNumber(client, AccountOne, AccountSequenceNumberConfig{
+		MaxInFlight: 1,
+		PollPeriod:  time.Millisecond,
+		MaxWait:     5 * time.Millisecond,
+	})
+
+	seqNum, err := sequenceNumbers.Next()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), seqNum)
+
+	// The first transaction never commits e.g. it expired, so it's handed out again only after a resync
+	_, err = sequenceNumbers.Next()
+	require.ErrorIs(t, err, ErrSequenceNumbersInFlight)
+	require.NoError(t, sequenceNumbers.Resync())
+	seqNum, err = sequenceNumbers.Next()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), seqNum)
+}
+
+func TestAccountSequenceNumber_WaitsUnlocked(t *testing.T) {
+	t.Parallel()
+	server, _ := newSequenceNumberServer(t)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	sequenceNumbers := NewAccountSequenceNumber(client, AccountOne, AccountSequenceNumberConfig{
+		MaxInFlight: 1,
+		PollPeriod:  time.Millisecond,
+		MaxWait:     time.Hour,
+	})
+	seqNum, err := sequenceNumbers.Next()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), seqNum)
+
+	waited := make(chan uint64)
+	go func() {
+		seqNum, err := sequenceNumbers.Next()
+		assert.NoError(t, err)
+		waited <- seqNum
+	}()
+
+	// Resyncing while the other waits doesn't block on it, and lets it hand the number out again
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, sequenceNumbers.Resync())
+	select {
+	case seqNum = <-waited:
+		assert.Equal(t, uint64(0), seqNum)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "Next didn't return after the resync")
+	}
+}
+
+func TestAccountSequenceNumber_HandleError(t *testing.T) {
+	t.Parallel()
+	server, onChain := newSequenceNumberServer(t)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	sequenceNumbers := NewAccountSequenceNumber(client, AccountOne)
+	sequenceNumbers.Set(20)
+	onChain.Store(3)
+
+	assert.False(t, sequenceNumbers.HandleError(nil))
+	seqNum, err := sequenceNumbers.Next()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), seqNum)
+
+	tooNew := &HttpError{ApiError: &api.Error{
+		ErrorCode:   api.ErrorCodeVmError,
+		VmErrorCode: api.VmErrorCodeSequenceNumberTooNew,
+	}}
+	assert.True(t, sequenceNumbers.HandleError(fmt.Errorf("submit transaction api err: %w", tooNew)))
+	seqNum, err = sequenceNumbers.Next()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), seqNum)
+}
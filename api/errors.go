@@ -1,8 +1,57 @@
 package api
 
+import "fmt"
+
 // Error is an error from the REST API
 type Error struct {
 	Message     string `json:"message"`       // Message is the error message
 	ErrorCode   string `json:"error_code"`    // ErrorCode is the string name of the error
 	VmErrorCode uint64 `json:"vm_error_code"` // VmErrorCode is the number of the failure, optional 0 if not set
 }
+
+// Error codes returned by the REST API in [Error.ErrorCode]
+const (
+	ErrorCodeAccountNotFound          = "account_not_found"
+	ErrorCodeResourceNotFound         = "resource_not_found"
+	ErrorCodeModuleNotFound           = "module_not_found"
+	ErrorCodeStructFieldNotFound      = "struct_field_not_found"
+	ErrorCodeVersionNotFound          = "version_not_found"
+	ErrorCodeTransactionNotFound      = "transaction_not_found"
+	ErrorCodeTableItemNotFound        = "table_item_not_found"
+	ErrorCodeBlockNotFound            = "block_not_found"
+	ErrorCodeStateValueNotFound       = "state_value_not_found"
+	ErrorCodeVersionPruned            = "version_pruned"
+	ErrorCodeBlockPruned              = "block_pruned"
+	ErrorCodeInvalidInput             = "invalid_input"
+	ErrorCodeInvalidTransactionUpdate = "invalid_transaction_update"
+	ErrorCodeSequenceNumberTooOld     = "sequence_number_too_old"
+	ErrorCodeVmError                  = "vm_error"
+	ErrorCodeRejectedByFilter         = "rejected_by_filter"
+	ErrorCodeHealthCheckFailed        = "health_check_failed"
+	ErrorCodeMempoolIsFull            = "mempool_is_full"
+	ErrorCodeInternalError            = "internal_error"
+	ErrorCodeWebFrameworkError        = "web_framework_error"
+	ErrorCodeBcsNotSupported          = "bcs_not_supported"
+	ErrorCodeApiDisabled              = "api_disabled"
+)
+
+// VM status codes returned in [Error.VmErrorCode] for transaction validation failures
+const (
+	VmErrorCodeInvalidSignature                     = uint64(1)
+	VmErrorCodeInvalidAuthKey                       = uint64(2)
+	VmErrorCodeSequenceNumberTooOld                 = uint64(3)
+	VmErrorCodeSequenceNumberTooNew                 = uint64(4)
+	VmErrorCodeInsufficientBalanceForTransactionFee = uint64(5)
+	VmErrorCodeTransactionExpired                   = uint64(6)
+)
+
+// Error returns a string representation of the Error
+//
+// Implements:
+//   - [error]
+func (e *Error) Error() string {
+	if e.VmErrorCode != 0 {
+		return fmt.Sprintf("%s (vm_error_code %d): %s", e.ErrorCode, e.VmErrorCode, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.ErrorCode, e.Message)
+}
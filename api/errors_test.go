@@ -45,3 +45,12 @@ func Test_ErrorWithVm(t *testing.T) {
 	assert.Equal(t, errorCode, data.ErrorCode)
 	assert.Equal(t, vmErrorCode, data.VmErrorCode)
 }
+
+func Test_ErrorString(t *testing.T) {
+	t.Parallel()
+	err := &Error{Message: "Account not found", ErrorCode: ErrorCodeAccountNotFound}
+	assert.Equal(t, "account_not_found: Account not found", err.Error())
+
+	err = &Error{Message: "Invalid transaction", ErrorCode: ErrorCodeVmError, VmErrorCode: VmErrorCodeSequenceNumberTooOld}
+	assert.Equal(t, "vm_error (vm_error_code 3): Invalid transaction", err.Error())
+}
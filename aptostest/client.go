@@ -0,0 +1,727 @@
+package aptostest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/hasura/go-graphql-client"
+)
+
+// ErrUnsupported is returned for the requests, and the transactions, that the fake doesn't implement
+var ErrUnsupported = errors.New("not supported by aptostest")
+
+// accountResourceType is the type of the resource every account has, the fake serves it from the account's state
+const accountResourceType = "0x1::account::Account"
+
+// Config configures a [Client], zero values use the defaults
+type Config struct {
+	ChainId      uint8  // ChainId of the fake network, defaults to 4, the chain ID of a localnet
+	GasUnitPrice uint64 // GasUnitPrice given by gas estimates and used to build transactions, defaults to 100
+	GasUsed      uint64 // GasUsed by every transaction in gas units, defaults to 10
+}
+
+// DefaultConfig is the [Config] used by [NewClient]
+func DefaultConfig() Config {
+	return Config{
+		ChainId:      4,
+		GasUnitPrice: aptos.DefaultGasUnitPrice,
+		GasUsed:      10,
+	}
+}
+
+// ViewFunc computes the return values of a view function, as the node returns them in JSON e.g. "100" for a u64
+type ViewFunc func(payload *aptos.ViewPayload) ([]any, error)
+
+// account is the on-chain state of an account
+type account struct {
+	sequenceNumber uint64
+	authKey        crypto.AuthenticationKey
+	balance        uint64            // APT balance in octas
+	replayNonces   map[uint64]uint64 // Expiration of each replay nonce used by orderless transactions, in seconds
+}
+
+// Client is an in-memory [aptos.AptosClient], see the package documentation.  It's safe for concurrent use.
+//
+// Nothing blocks, so the contexts given to the Ctx methods are unused, and transactions are committed by the time
+// they're submitted.  No history is kept, so reads at a ledger version other than the latest fail.
+type Client struct {
+	config Config
+
+	mutex        sync.RWMutex
+	accounts     map[aptos.AccountAddress]*account
+	resources    map[aptos.AccountAddress]map[string]map[string]any // Resources set by the test, by address and type
+	transactions []*api.CommittedTransaction                        // Committed transactions by version
+	hashes       map[string]uint64                                  // Version of each committed transaction by hash
+	views        map[string]ViewFunc                                // View functions by address::module::function
+	timestamp    uint64                                             // Timestamp of the latest transaction in microseconds
+	mints        uint64                                             // Number of mint transactions, see [Client.mint]
+}
+
+var _ aptos.AptosClient = (*Client)(nil)
+
+// NewClient creates a [Client], with an empty ledger holding only the genesis transaction.  The 0x1::coin::balance
+// view function returns APT balances, other view functions must be set with [Client.SetView].
+func NewClient(config ...Config) *Client {
+	cfg := DefaultConfig()
+	if len(config) > 0 {
+		if config[0].ChainId != 0 {
+			cfg.ChainId = config[0].ChainId
+		}
+		if config[0].GasUnitPrice != 0 {
+			cfg.GasUnitPrice = config[0].GasUnitPrice
+		}
+		if config[0].GasUsed != 0 {
+			cfg.GasUsed = config[0].GasUsed
+		}
+	}
+	genesisHash := "0x" + strings.Repeat("0", 64)
+	c := &Client{
+		config:    cfg,
+		accounts:  make(map[aptos.AccountAddress]*account),
+		resources: make(map[aptos.AccountAddress]map[string]map[string]any),
+		transactions: []*api.CommittedTransaction{{
+			Type: api.TransactionVariantGenesis,
+			Inner: &api.GenesisTransaction{
+				Hash:     genesisHash,
+				Success:  true,
+				VmStatus: "Executed successfully",
+				Changes:  []*api.WriteSetChange{},
+				Events:   []*api.Event{},
+			},
+		}},
+		hashes:    map[string]uint64{genesisHash: 0},
+		views:     make(map[string]ViewFunc),
+		timestamp: uint64(time.Now().UnixMicro()), //nolint:gosec // The clock is after 1970
+	}
+	c.views["0x1::coin::balance"] = c.coinBalance
+	return c
+}
+
+// region Test setup
+
+// Fund mints amount octas of APT to address, creating the account if it doesn't exist.  New accounts have their
+// address as authentication key, like accounts created by transferring to them.
+func (c *Client) Fund(address aptos.AccountAddress, amount uint64) error {
+	return c.FundCtx(context.Background(), address, amount)
+}
+
+// FundCtx is [Client.Fund]
+func (c *Client) FundCtx(_ context.Context, address aptos.AccountAddress, amount uint64) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	acct := c.createAccount(address)
+	if acct.balance > math.MaxUint64-amount {
+		return fmt.Errorf("balance of %s would overflow", address.String())
+	}
+	acct.balance += amount
+	return nil
+}
+
+// SetResource sets the resource of type resourceType at address, as returned by [Client.AccountResource].  It
+// doesn't create an account, so it can set the resources of objects.
+func (c *Client) SetResource(address aptos.AccountAddress, resourceType string, data map[string]any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.resources[address] == nil {
+		c.resources[address] = make(map[string]map[string]any)
+	}
+	c.resources[address][normalizeType(resourceType)] = data
+}
+
+// SetView sets the implementation of a view function, given as address::module::function e.g. 0xcafe::pool::reserves,
+// replacing any previous one.  Panics if function isn't of that form.
+func (c *Client) SetView(function string, view ViewFunc) {
+	key := normalizeFunction(function)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.views[key] = view
+}
+
+// SetViewResult sets a view function to always return values, see [Client.SetView]
+func (c *Client) SetViewResult(function string, values ...any) {
+	c.SetView(function, func(*aptos.ViewPayload) ([]any, error) {
+		return values, nil
+	})
+}
+
+// endregion
+
+// region Node info
+
+// SetTimeout does nothing, as nothing blocks
+func (c *Client) SetTimeout(time.Duration) {}
+
+// SetHeader does nothing, as no requests are made
+func (c *Client) SetHeader(string, string) {}
+
+// RemoveHeader does nothing, as no requests are made
+func (c *Client) RemoveHeader(string) {}
+
+// Info returns the state of the fake ledger.  Each transaction is in a block of its own.
+func (c *Client) Info() (aptos.NodeInfo, error) {
+	return c.InfoCtx(context.Background())
+}
+
+// InfoCtx is [Client.Info]
+func (c *Client) InfoCtx(_ context.Context) (aptos.NodeInfo, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	ledgerVersion := strconv.FormatUint(c.ledgerVersion(), 10)
+	return aptos.NodeInfo{
+		ChainId:                c.config.ChainId,
+		EpochStr:               "1",
+		LedgerTimestampStr:     strconv.FormatUint(c.timestamp, 10),
+		LedgerVersionStr:       ledgerVersion,
+		OldestLedgerVersionStr: "0",
+		NodeRole:               "full_node",
+		BlockHeightStr:         ledgerVersion,
+		OldestBlockHeightStr:   "0",
+	}, nil
+}
+
+// GetChainId returns the configured chain ID
+func (c *Client) GetChainId() (uint8, error) {
+	return c.GetChainIdCtx(context.Background())
+}
+
+// GetChainIdCtx is [Client.GetChainId]
+func (c *Client) GetChainIdCtx(_ context.Context) (uint8, error) {
+	return c.config.ChainId, nil
+}
+
+// EstimateGasPrice returns the configured gas unit price for every priority
+func (c *Client) EstimateGasPrice() (aptos.EstimateGasInfo, error) {
+	return c.EstimateGasPriceCtx(context.Background())
+}
+
+// EstimateGasPriceCtx is [Client.EstimateGasPrice]
+func (c *Client) EstimateGasPriceCtx(_ context.Context) (aptos.EstimateGasInfo, error) {
+	return aptos.EstimateGasInfo{
+		DeprioritizedGasEstimate: c.config.GasUnitPrice,
+		GasEstimate:              c.config.GasUnitPrice,
+		PrioritizedGasEstimate:   c.config.GasUnitPrice,
+	}, nil
+}
+
+// NodeAPIHealthCheck always succeeds
+func (c *Client) NodeAPIHealthCheck(durationSecs ...uint64) (api.HealthCheckResponse, error) {
+	return c.NodeAPIHealthCheckCtx(context.Background(), durationSecs...)
+}
+
+// NodeAPIHealthCheckCtx is [Client.NodeAPIHealthCheck]
+func (c *Client) NodeAPIHealthCheckCtx(_ context.Context, _ ...uint64) (api.HealthCheckResponse, error) {
+	return api.HealthCheckResponse{Message: "aptos-node:ok"}, nil
+}
+
+// endregion
+
+// region Accounts
+
+// Account returns the sequence number and authentication key of an account
+func (c *Client) Account(address aptos.AccountAddress, ledgerVersion ...uint64) (aptos.AccountInfo, error) {
+	return c.AccountCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountCtx is [Client.Account]
+func (c *Client) AccountCtx(ctx context.Context, address aptos.AccountAddress, ledgerVersion ...uint64) (aptos.AccountInfo, error) {
+	info, _, err := c.AccountWithResponseInfoCtx(ctx, address, ledgerVersion...)
+	return info, err
+}
+
+// AccountWithResponseInfo is [Client.Account], additionally returning the state of the ledger
+func (c *Client) AccountWithResponseInfo(address aptos.AccountAddress, ledgerVersion ...uint64) (aptos.AccountInfo, *aptos.ResponseInfo, error) {
+	return c.AccountWithResponseInfoCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountWithResponseInfoCtx is [Client.AccountWithResponseInfo]
+func (c *Client) AccountWithResponseInfoCtx(_ context.Context, address aptos.AccountAddress, ledgerVersion ...uint64) (aptos.AccountInfo, *aptos.ResponseInfo, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	err := c.checkVersion(ledgerVersion)
+	if err != nil {
+		return aptos.AccountInfo{}, nil, err
+	}
+	acct, ok := c.accounts[address]
+	if !ok {
+		return aptos.AccountInfo{}, nil, fmt.Errorf("account %s: %w", address.String(), aptos.ErrAccountNotFound)
+	}
+	return aptos.AccountInfo{
+		SequenceNumberStr:    strconv.FormatUint(acct.sequenceNumber, 10),
+		AuthenticationKeyHex: acct.authKey.ToHex(),
+	}, c.responseInfo(), nil
+}
+
+// AccountAPTBalance returns the APT balance of an account in octas, 0 if it doesn't exist
+func (c *Client) AccountAPTBalance(address aptos.AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	return c.AccountAPTBalanceCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountAPTBalanceCtx is [Client.AccountAPTBalance]
+func (c *Client) AccountAPTBalanceCtx(ctx context.Context, address aptos.AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	balance, _, err := c.AccountAPTBalanceWithResponseInfoCtx(ctx, address, ledgerVersion...)
+	return balance, err
+}
+
+// AccountAPTBalanceWithResponseInfo is [Client.AccountAPTBalance], additionally returning the state of the ledger
+func (c *Client) AccountAPTBalanceWithResponseInfo(address aptos.AccountAddress, ledgerVersion ...uint64) (uint64, *aptos.ResponseInfo, error) {
+	return c.AccountAPTBalanceWithResponseInfoCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountAPTBalanceWithResponseInfoCtx is [Client.AccountAPTBalanceWithResponseInfo]
+func (c *Client) AccountAPTBalanceWithResponseInfoCtx(_ context.Context, address aptos.AccountAddress, ledgerVersion ...uint64) (uint64, *aptos.ResponseInfo, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	err := c.checkVersion(ledgerVersion)
+	if err != nil {
+		return 0, nil, err
+	}
+	balance := uint64(0)
+	if acct, ok := c.accounts[address]; ok {
+		balance = acct.balance
+	}
+	return balance, c.responseInfo(), nil
+}
+
+// AccountResource returns a resource set with [Client.SetResource], or the 0x1::account::Account resource of an
+// account
+func (c *Client) AccountResource(address aptos.AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, error) {
+	return c.AccountResourceCtx(context.Background(), address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceCtx is [Client.AccountResource]
+func (c *Client) AccountResourceCtx(ctx context.Context, address aptos.AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, error) {
+	resource, _, err := c.AccountResourceWithResponseInfoCtx(ctx, address, resourceType, ledgerVersion...)
+	return resource, err
+}
+
+// AccountResourceWithResponseInfo is [Client.AccountResource], additionally returning the state of the ledger
+func (c *Client) AccountResourceWithResponseInfo(address aptos.AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, *aptos.ResponseInfo, error) {
+	return c.AccountResourceWithResponseInfoCtx(context.Background(), address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceWithResponseInfoCtx is [Client.AccountResourceWithResponseInfo]
+func (c *Client) AccountResourceWithResponseInfoCtx(_ context.Context, address aptos.AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, *aptos.ResponseInfo, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	err := c.checkVersion(ledgerVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	resourceType = normalizeType(resourceType)
+	for _, resource := range c.accountResources(address) {
+		if resource.Type == resourceType {
+			return map[string]any{"type": resource.Type, "data": resource.Data}, c.responseInfo(), nil
+		}
+	}
+	return nil, nil, fmt.Errorf("resource %s at %s: %w", resourceType, address.String(), aptos.ErrResourceNotFound)
+}
+
+// AccountResources returns all resources at address, sorted by type
+func (c *Client) AccountResources(address aptos.AccountAddress, ledgerVersion ...uint64) ([]aptos.AccountResourceInfo, error) {
+	return c.AccountResourcesCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesCtx is [Client.AccountResources]
+func (c *Client) AccountResourcesCtx(_ context.Context, address aptos.AccountAddress, ledgerVersion ...uint64) ([]aptos.AccountResourceInfo, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	err := c.checkVersion(ledgerVersion)
+	if err != nil {
+		return nil, err
+	}
+	return c.accountResources(address), nil
+}
+
+// AccountResourcesIter iterates over [Client.AccountResources]
+func (c *Client) AccountResourcesIter(address aptos.AccountAddress, ledgerVersion ...uint64) iter.Seq2[aptos.AccountResourceInfo, error] {
+	return c.AccountResourcesIterCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesIterCtx is [Client.AccountResourcesIter]
+func (c *Client) AccountResourcesIterCtx(ctx context.Context, address aptos.AccountAddress, ledgerVersion ...uint64) iter.Seq2[aptos.AccountResourceInfo, error] {
+	return func(yield func(aptos.AccountResourceInfo, error) bool) {
+		resources, err := c.AccountResourcesCtx(ctx, address, ledgerVersion...)
+		if err != nil {
+			yield(aptos.AccountResourceInfo{}, err)
+			return
+		}
+		for _, resource := range resources {
+			if !yield(resource, nil) {
+				return
+			}
+		}
+	}
+}
+
+// AccountResourcesBCS is unsupported
+func (c *Client) AccountResourcesBCS(address aptos.AccountAddress, ledgerVersion ...uint64) ([]aptos.AccountResourceRecord, error) {
+	return c.AccountResourcesBCSCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesBCSCtx is unsupported
+func (c *Client) AccountResourcesBCSCtx(context.Context, aptos.AccountAddress, ...uint64) ([]aptos.AccountResourceRecord, error) {
+	return nil, unsupported("AccountResourcesBCS")
+}
+
+// accountResources returns the resources at address sorted by type, the ones set by the test taking precedence
+func (c *Client) accountResources(address aptos.AccountAddress) []aptos.AccountResourceInfo {
+	resources := maps.Clone(c.resources[address])
+	if resources == nil {
+		resources = make(map[string]map[string]any)
+	}
+	if acct, ok := c.accounts[address]; ok {
+		if _, ok := resources[accountResourceType]; !ok {
+			resources[accountResourceType] = map[string]any{
+				"sequence_number":    strconv.FormatUint(acct.sequenceNumber, 10),
+				"authentication_key": acct.authKey.ToHex(),
+			}
+		}
+	}
+	infos := make([]aptos.AccountResourceInfo, 0, len(resources))
+	for _, resourceType := range slices.Sorted(maps.Keys(resources)) {
+		infos = append(infos, aptos.AccountResourceInfo{Type: resourceType, Data: resources[resourceType]})
+	}
+	return infos
+}
+
+// createAccount returns the account at address, creating it with the address as authentication key if needed
+func (c *Client) createAccount(address aptos.AccountAddress) *account {
+	acct, ok := c.accounts[address]
+	if !ok {
+		acct = &account{authKey: *address.AuthKey()}
+		c.accounts[address] = acct
+	}
+	return acct
+}
+
+// endregion
+
+// region Unsupported state
+
+// GetTableItem is unsupported
+func (c *Client) GetTableItem(handle string, keyType string, valueType string, key any, ledgerVersion ...uint64) (any, error) {
+	return c.GetTableItemCtx(context.Background(), handle, keyType, valueType, key, ledgerVersion...)
+}
+
+// GetTableItemCtx is unsupported
+func (c *Client) GetTableItemCtx(context.Context, string, string, string, any, ...uint64) (any, error) {
+	return nil, unsupported("GetTableItem")
+}
+
+// GetRawTableItem is unsupported
+func (c *Client) GetRawTableItem(handle string, key []byte, ledgerVersion ...uint64) ([]byte, error) {
+	return c.GetRawTableItemCtx(context.Background(), handle, key, ledgerVersion...)
+}
+
+// GetRawTableItemCtx is unsupported
+func (c *Client) GetRawTableItemCtx(context.Context, string, []byte, ...uint64) ([]byte, error) {
+	return nil, unsupported("GetRawTableItem")
+}
+
+// AccountModule is unsupported
+func (c *Client) AccountModule(address aptos.AccountAddress, moduleName string, ledgerVersion ...uint64) (*api.MoveBytecode, error) {
+	return c.AccountModuleCtx(context.Background(), address, moduleName, ledgerVersion...)
+}
+
+// AccountModuleCtx is unsupported
+func (c *Client) AccountModuleCtx(context.Context, aptos.AccountAddress, string, ...uint64) (*api.MoveBytecode, error) {
+	return nil, unsupported("AccountModule")
+}
+
+// AccountModules is unsupported
+func (c *Client) AccountModules(address aptos.AccountAddress, ledgerVersion ...uint64) ([]api.MoveBytecode, error) {
+	return c.AccountModulesCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountModulesCtx is unsupported
+func (c *Client) AccountModulesCtx(context.Context, aptos.AccountAddress, ...uint64) ([]api.MoveBytecode, error) {
+	return nil, unsupported("AccountModules")
+}
+
+// AccountModulesBCS is unsupported
+func (c *Client) AccountModulesBCS(address aptos.AccountAddress, ledgerVersion ...uint64) ([]aptos.AccountModuleRecord, error) {
+	return c.AccountModulesBCSCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountModulesBCSCtx is unsupported
+func (c *Client) AccountModulesBCSCtx(context.Context, aptos.AccountAddress, ...uint64) ([]aptos.AccountModuleRecord, error) {
+	return nil, unsupported("AccountModulesBCS")
+}
+
+// AccountModulesIter is unsupported
+func (c *Client) AccountModulesIter(address aptos.AccountAddress, ledgerVersion ...uint64) iter.Seq2[*api.MoveBytecode, error] {
+	return c.AccountModulesIterCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountModulesIterCtx is unsupported
+func (c *Client) AccountModulesIterCtx(context.Context, aptos.AccountAddress, ...uint64) iter.Seq2[*api.MoveBytecode, error] {
+	return failing[*api.MoveBytecode](unsupported("AccountModulesIter"))
+}
+
+// EntryFunctionWithArgs is unsupported, as it needs the module's ABI
+func (c *Client) EntryFunctionWithArgs(moduleAddress aptos.AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, options ...any) (*aptos.EntryFunction, error) {
+	return c.EntryFunctionWithArgsCtx(context.Background(), moduleAddress, moduleName, functionName, typeArgs, args, options...)
+}
+
+// EntryFunctionWithArgsCtx is unsupported, as it needs the module's ABI
+func (c *Client) EntryFunctionWithArgsCtx(context.Context, aptos.AccountAddress, string, string, []any, []any, ...any) (*aptos.EntryFunction, error) {
+	return nil, unsupported("EntryFunctionWithArgs")
+}
+
+// BlockByHeight is unsupported
+func (c *Client) BlockByHeight(blockHeight uint64, withTransactions bool) (*api.Block, error) {
+	return c.BlockByHeightCtx(context.Background(), blockHeight, withTransactions)
+}
+
+// BlockByHeightCtx is unsupported
+func (c *Client) BlockByHeightCtx(context.Context, uint64, bool) (*api.Block, error) {
+	return nil, unsupported("BlockByHeight")
+}
+
+// BlockByVersion is unsupported
+func (c *Client) BlockByVersion(ledgerVersion uint64, withTransactions bool) (*api.Block, error) {
+	return c.BlockByVersionCtx(context.Background(), ledgerVersion, withTransactions)
+}
+
+// BlockByVersionCtx is unsupported
+func (c *Client) BlockByVersionCtx(context.Context, uint64, bool) (*api.Block, error) {
+	return nil, unsupported("BlockByVersion")
+}
+
+// endregion
+
+// region View functions
+
+// View calls a view function set with [Client.SetView]
+func (c *Client) View(payload *aptos.ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	return c.ViewCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewCtx is [Client.View]
+func (c *Client) ViewCtx(ctx context.Context, payload *aptos.ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	values, _, err := c.ViewWithResponseInfoCtx(ctx, payload, ledgerVersion...)
+	return values, err
+}
+
+// ViewWithResponseInfo is [Client.View], additionally returning the state of the ledger
+func (c *Client) ViewWithResponseInfo(payload *aptos.ViewPayload, ledgerVersion ...uint64) ([]any, *aptos.ResponseInfo, error) {
+	return c.ViewWithResponseInfoCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewWithResponseInfoCtx is [Client.ViewWithResponseInfo]
+func (c *Client) ViewWithResponseInfoCtx(_ context.Context, payload *aptos.ViewPayload, ledgerVersion ...uint64) ([]any, *aptos.ResponseInfo, error) {
+	function := payload.Module.Address.String() + "::" + payload.Module.Name + "::" + payload.Function
+	c.mutex.RLock()
+	err := c.checkVersion(ledgerVersion)
+	view, ok := c.views[function]
+	responseInfo := c.responseInfo()
+	c.mutex.RUnlock()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, fmt.Errorf("view function %s isn't set: %w", function, ErrUnsupported)
+	}
+
+	// The view may read the ledger, so it's called without holding the lock
+	values, err := view(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return values, responseInfo, nil
+}
+
+// ViewWithResponse calls a view function set with [Client.SetView], and unmarshals its return values into response
+// through JSON, like the node's response
+func (c *Client) ViewWithResponse(response any, payload *aptos.ViewPayload, ledgerVersion ...uint64) error {
+	return c.ViewWithResponseCtx(context.Background(), response, payload, ledgerVersion...)
+}
+
+// ViewWithResponseCtx is [Client.ViewWithResponse]
+func (c *Client) ViewWithResponseCtx(ctx context.Context, response any, payload *aptos.ViewPayload, ledgerVersion ...uint64) error {
+	values, err := c.ViewCtx(ctx, payload, ledgerVersion...)
+	if err != nil {
+		return err
+	}
+	blob, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(blob, &[]any{response})
+}
+
+// ViewRaw is unsupported, as views return JSON values
+func (c *Client) ViewRaw(payload *aptos.ViewPayload, ledgerVersion ...uint64) ([][]byte, error) {
+	return c.ViewRawCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewRawCtx is unsupported, as views return JSON values
+func (c *Client) ViewRawCtx(context.Context, *aptos.ViewPayload, ...uint64) ([][]byte, error) {
+	return nil, unsupported("ViewRaw")
+}
+
+// ViewTyped is unsupported, as it needs the module's ABI
+func (c *Client) ViewTyped(payload *aptos.ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	return c.ViewTypedCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewTypedCtx is unsupported, as it needs the module's ABI
+func (c *Client) ViewTypedCtx(context.Context, *aptos.ViewPayload, ...uint64) ([]any, error) {
+	return nil, unsupported("ViewTyped")
+}
+
+// ViewWithArgs is unsupported, as it needs the module's ABI
+func (c *Client) ViewWithArgs(moduleAddress aptos.AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, ledgerVersion ...uint64) ([]any, error) {
+	return c.ViewWithArgsCtx(context.Background(), moduleAddress, moduleName, functionName, typeArgs, args, ledgerVersion...)
+}
+
+// ViewWithArgsCtx is unsupported, as it needs the module's ABI
+func (c *Client) ViewWithArgsCtx(context.Context, aptos.AccountAddress, string, string, []any, []any, ...uint64) ([]any, error) {
+	return nil, unsupported("ViewWithArgs")
+}
+
+// coinBalance is the 0x1::coin::balance view function, for APT only
+func (c *Client) coinBalance(payload *aptos.ViewPayload) ([]any, error) {
+	if len(payload.ArgTypes) != 1 || payload.ArgTypes[0].String() != aptos.AptosCoinTypeTag.String() {
+		return nil, fmt.Errorf("0x1::coin::balance of coins other than APT: %w", ErrUnsupported)
+	}
+	if len(payload.Args) != 1 {
+		return nil, fmt.Errorf("0x1::coin::balance takes 1 argument, got %d: %w", len(payload.Args), aptos.ErrInvalidInput)
+	}
+	var address aptos.AccountAddress
+	err := bcs.Deserialize(&address, payload.Args[0])
+	if err != nil {
+		return nil, fmt.Errorf("bad address argument to 0x1::coin::balance: %w", aptos.ErrInvalidInput)
+	}
+	balance, err := c.AccountAPTBalance(address)
+	if err != nil {
+		return nil, err
+	}
+	return []any{strconv.FormatUint(balance, 10)}, nil
+}
+
+// endregion
+
+// region Indexer
+
+// QueryIndexer is unsupported
+func (c *Client) QueryIndexer(query any, variables map[string]any, options ...graphql.Option) error {
+	return c.QueryIndexerCtx(context.Background(), query, variables, options...)
+}
+
+// QueryIndexerCtx is unsupported
+func (c *Client) QueryIndexerCtx(context.Context, any, map[string]any, ...graphql.Option) error {
+	return unsupported("QueryIndexer")
+}
+
+// GetProcessorStatus returns the latest ledger version, as the fake indexer is always up to date
+func (c *Client) GetProcessorStatus(processorName string) (uint64, error) {
+	return c.GetProcessorStatusCtx(context.Background(), processorName)
+}
+
+// GetProcessorStatusCtx is [Client.GetProcessorStatus]
+func (c *Client) GetProcessorStatusCtx(context.Context, string) (uint64, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.ledgerVersion(), nil
+}
+
+// GetCoinBalances returns the APT balance of an account, if it exists
+func (c *Client) GetCoinBalances(address aptos.AccountAddress) ([]aptos.CoinBalance, error) {
+	return c.GetCoinBalancesCtx(context.Background(), address)
+}
+
+// GetCoinBalancesCtx is [Client.GetCoinBalances]
+func (c *Client) GetCoinBalancesCtx(_ context.Context, address aptos.AccountAddress) ([]aptos.CoinBalance, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	acct, ok := c.accounts[address]
+	if !ok {
+		return []aptos.CoinBalance{}, nil
+	}
+	return []aptos.CoinBalance{{CoinType: aptos.AptosCoinTypeTag.String(), Amount: acct.balance}}, nil
+}
+
+// endregion
+
+// ledgerVersion is the version of the latest transaction
+func (c *Client) ledgerVersion() uint64 {
+	return uint64(len(c.transactions) - 1)
+}
+
+// responseInfo is the state of the ledger, as a node reports it with every response
+func (c *Client) responseInfo() *aptos.ResponseInfo {
+	return &aptos.ResponseInfo{
+		ChainId:         c.config.ChainId,
+		LedgerVersion:   c.ledgerVersion(),
+		LedgerTimestamp: c.timestamp,
+		Epoch:           1,
+		BlockHeight:     c.ledgerVersion(),
+	}
+}
+
+// checkVersion fails reads at a ledger version other than the latest, as no history is kept
+func (c *Client) checkVersion(ledgerVersion []uint64) error {
+	if len(ledgerVersion) == 0 {
+		return nil
+	}
+	latest := c.ledgerVersion()
+	switch {
+	case ledgerVersion[0] > latest:
+		return fmt.Errorf("ledger version %d is after %d: %w", ledgerVersion[0], latest, aptos.ErrVersionNotFound)
+	case ledgerVersion[0] < latest:
+		return fmt.Errorf("reading at past ledger version %d: %w", ledgerVersion[0], ErrUnsupported)
+	}
+	return nil
+}
+
+// unsupported is the error of an unsupported method
+func unsupported(method string) error {
+	return fmt.Errorf("%s: %w", method, ErrUnsupported)
+}
+
+// failing is an iterator yielding only err
+func failing[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
+
+// normalizeType formats a Move type like the node does e.g. with short addresses, leaving it as is if it doesn't parse
+func normalizeType(moveType string) string {
+	typeTag, err := aptos.ParseTypeTag(moveType)
+	if err != nil {
+		return moveType
+	}
+	return typeTag.String()
+}
+
+// normalizeFunction formats a function given as address::module::function with a short address, panics if it's not of
+// that form
+func normalizeFunction(function string) string {
+	parts := strings.Split(function, "::")
+	if len(parts) != 3 {
+		panic(fmt.Sprintf("function %s isn't of the form address::module::function", function))
+	}
+	var address aptos.AccountAddress
+	err := address.ParseStringRelaxed(parts[0])
+	if err != nil {
+		panic(fmt.Sprintf("function %s has a bad address: %v", function, err))
+	}
+	return address.String() + "::" + parts[1] + "::" + parts[2]
+}
@@ -0,0 +1,380 @@
+package aptostest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fee = 10 * aptos.DefaultGasUnitPrice
+
+func newFundedAccount(t *testing.T, client *Client, amount uint64) *aptos.Account {
+	t.Helper()
+	account, err := aptos.NewEd25519Account()
+	require.NoError(t, err)
+	require.NoError(t, client.Fund(account.Address, amount))
+	return account
+}
+
+func transferPayload(t *testing.T, receiver aptos.AccountAddress, amount uint64) aptos.TransactionPayload {
+	t.Helper()
+	payload, err := aptos.CoinTransferPayload(nil, receiver, amount)
+	require.NoError(t, err)
+	return aptos.TransactionPayload{Payload: payload}
+}
+
+func TestClient_Transfer(t *testing.T) {
+	t.Parallel()
+	client := NewClient()
+	sender := newFundedAccount(t, client, 100_000_000)
+	receiver, err := aptos.NewEd25519Account()
+	require.NoError(t, err)
+
+	submitted, err := client.BuildSignAndSubmitTransaction(sender, transferPayload(t, receiver.Address, 1_000))
+	require.NoError(t, err)
+	txn, err := client.WaitForTransaction(submitted.Hash)
+	require.NoError(t, err)
+	assert.True(t, txn.Success)
+	assert.Equal(t, uint64(1), txn.Version)
+	assert.Equal(t, uint64(0), txn.SequenceNumber)
+	assert.Equal(t, "0x1::aptos_account::transfer", txn.Payload.Inner.(*api.TransactionPayloadEntryFunction).Function)
+	eventTypes := make([]string, len(txn.Events))
+	for i, event := range txn.Events {
+		eventTypes[i] = event.Type
+	}
+	assert.Equal(t, []string{"0x1::coin::CoinWithdraw", "0x1::coin::CoinDeposit", "0x1::transaction_fee::FeeStatement"}, eventTypes)
+
+	balance, err := client.AccountAPTBalance(sender.Address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100_000_000-1_000-fee), balance)
+	balance, err = client.AccountAPTBalance(receiver.Address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1_000), balance)
+	info, err := client.Account(sender.Address)
+	require.NoError(t, err)
+	sequenceNumber, err := info.SequenceNumber()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), sequenceNumber)
+
+	// The transaction is in the ledger, and the view of the sender's transactions
+	byVersion, err := client.TransactionByVersion(1)
+	require.NoError(t, err)
+	assert.Equal(t, submitted.Hash, byVersion.Hash())
+	sent, err := client.AccountTransactions(sender.Address, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	assert.Equal(t, submitted.Hash, sent[0].Hash())
+	all, err := client.Transactions(nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestClient_Rejections(t *testing.T) {
+	t.Parallel()
+	client := NewClient()
+	sender := newFundedAccount(t, client, 100_000_000)
+	other := newFundedAccount(t, client, 100_000_000)
+	payload := transferPayload(t, other.Address, 1_000)
+
+	// Signed by the wrong key, or changed after signing
+	rawTxn, err := client.BuildTransaction(sender.Address, payload)
+	require.NoError(t, err)
+	signedTxn, err := rawTxn.SignedTransaction(other)
+	require.NoError(t, err)
+	_, err = client.SubmitTransaction(signedTxn)
+	require.ErrorContains(t, err, "INVALID_AUTH_KEY")
+	signedTxn, err = rawTxn.SignedTransaction(sender)
+	require.NoError(t, err)
+	rawTxn.MaxGasAmount++
+	_, err = client.SubmitTransaction(signedTxn)
+	require.ErrorIs(t, err, aptos.ErrInvalidSignature)
+
+	// Sequence numbers
+	rawTxn, err = client.BuildTransaction(sender.Address, payload, aptos.SequenceNumber(1))
+	require.NoError(t, err)
+	signedTxn, err = rawTxn.SignedTransaction(sender)
+	require.NoError(t, err)
+	_, err = client.SubmitTransaction(signedTxn)
+	require.ErrorIs(t, err, aptos.ErrSequenceNumberTooNew)
+	_, err = client.BuildSignAndSubmitTransaction(sender, payload)
+	require.NoError(t, err)
+	rawTxn, err = client.BuildTransaction(sender.Address, payload, aptos.SequenceNumber(0))
+	require.NoError(t, err)
+	signedTxn, err = rawTxn.SignedTransaction(sender)
+	require.NoError(t, err)
+	_, err = client.SubmitTransaction(signedTxn)
+	require.ErrorIs(t, err, aptos.ErrSequenceNumberTooOld)
+
+	// Gas the sender can't afford, and accounts that don't exist
+	_, err = client.BuildSignAndSubmitTransaction(sender, payload, aptos.MaxGasAmount(10_000_000))
+	require.ErrorIs(t, err, aptos.ErrInsufficientBalance)
+	stranger, err := aptos.NewEd25519Account()
+	require.NoError(t, err)
+	_, err = client.BuildSignAndSubmitTransaction(stranger, payload)
+	require.ErrorIs(t, err, aptos.ErrAccountNotFound)
+	_, err = client.BuildSignAndSubmitTransaction(stranger, payload, aptos.SequenceNumber(0))
+	require.ErrorIs(t, err, aptos.ErrAccountNotFound)
+
+	// Rejections are reported as a node reports them in a batch
+	response, err := client.BatchSubmitTransaction([]*aptos.SignedTransaction{signedTxn})
+	require.NoError(t, err)
+	require.Len(t, response.TransactionFailures, 1)
+	assert.Equal(t, api.ErrorCodeVmError, response.TransactionFailures[0].Error.ErrorCode)
+	assert.Equal(t, api.VmErrorCodeSequenceNumberTooOld, response.TransactionFailures[0].Error.VmErrorCode)
+
+	// Payloads other than transfers
+	_, err = client.BuildSignAndSubmitTransaction(sender, aptos.TransactionPayload{Payload: &aptos.EntryFunction{
+		Module:   aptos.ModuleId{Address: aptos.AccountOne, Name: "code"},
+		Function: "publish_package_txn",
+		ArgTypes: []aptos.TypeTag{},
+		Args:     [][]byte{},
+	}})
+	require.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestClient_FailedTransfer(t *testing.T) {
+	t.Parallel()
+	client := NewClient()
+	sender := newFundedAccount(t, client, 5*fee)
+	receiver := newFundedAccount(t, client, 0)
+
+	submitted, err := client.BuildSignAndSubmitTransaction(sender, transferPayload(t, receiver.Address, 5*fee), aptos.MaxGasAmount(20))
+	require.NoError(t, err)
+	txn, err := client.WaitForTransaction(submitted.Hash)
+	require.NoError(t, err)
+	assert.False(t, txn.Success)
+	assert.Contains(t, txn.VmStatus, "EINSUFFICIENT_BALANCE")
+
+	// Gas is still charged, and the sequence number bumped
+	balance, err := client.AccountAPTBalance(sender.Address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4*fee), balance)
+	balance, err = client.AccountAPTBalance(receiver.Address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), balance)
+	info, err := client.Account(sender.Address)
+	require.NoError(t, err)
+	sequenceNumber, err := info.SequenceNumber()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), sequenceNumber)
+}
+
+func TestClient_FeePayer(t *testing.T) {
+	t.Parallel()
+	client := NewClient()
+	sponsor := newFundedAccount(t, client, 100_000_000)
+	sender, err := aptos.NewEd25519Account()
+	require.NoError(t, err)
+	receiver := newFundedAccount(t, client, 0)
+
+	// The sender doesn't exist yet, the sponsor creates it
+	rawTxn, err := client.BuildTransactionMultiAgent(sender.Address, transferPayload(t, receiver.Address, 0),
+		aptos.FeePayer(&aptos.AccountZero), aptos.SequenceNumber(0))
+	require.NoError(t, err)
+	simulated, err := client.SimulateTransactionMultiAgent(rawTxn, sender, aptos.FeePayer(&aptos.AccountZero))
+	require.NoError(t, err)
+	assert.True(t, simulated[0].Success)
+
+	rawTxn.SetFeePayer(sponsor.Address)
+	senderAuth, err := rawTxn.Sign(sender)
+	require.NoError(t, err)
+	sponsorAuth, err := rawTxn.Sign(sponsor)
+	require.NoError(t, err)
+	signedTxn, ok := rawTxn.ToFeePayerSignedTransaction(senderAuth, sponsorAuth, []crypto.AccountAuthenticator{})
+	require.True(t, ok)
+	_, err = client.SubmitTransaction(signedTxn)
+	require.NoError(t, err)
+
+	balance, err := client.AccountAPTBalance(sponsor.Address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100_000_000-fee), balance)
+	info, err := client.Account(sender.Address)
+	require.NoError(t, err)
+	sequenceNumber, err := info.SequenceNumber()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), sequenceNumber)
+
+	// The fee payer signature covers the fee payer's address
+	rawTxn, err = client.BuildTransactionMultiAgent(sender.Address, transferPayload(t, receiver.Address, 0),
+		aptos.FeePayer(&aptos.AccountZero))
+	require.NoError(t, err)
+	senderAuth, err = rawTxn.Sign(sender)
+	require.NoError(t, err)
+	rawTxn.SetFeePayer(sponsor.Address)
+	sponsorAuth, err = rawTxn.Sign(sponsor)
+	require.NoError(t, err)
+	signedTxn, ok = rawTxn.ToFeePayerSignedTransaction(senderAuth, sponsorAuth, []crypto.AccountAuthenticator{})
+	require.True(t, ok)
+	_, err = client.SubmitTransaction(signedTxn)
+	require.ErrorIs(t, err, aptos.ErrInvalidSignature)
+}
+
+func TestClient_Orderless(t *testing.T) {
+	t.Parallel()
+	client := NewClient()
+	sender := newFundedAccount(t, client, 100_000_000)
+
+	// Any number of orderless transactions, without using the sequence number
+	for range 3 {
+		submitted, err := client.BuildSignAndSubmitTransaction(sender, transferPayload(t, aptos.AccountTwo, 1), aptos.Orderless(true))
+		require.NoError(t, err)
+		require.NotNil(t, submitted.ReplayProtectionNonce)
+		txn, err := client.WaitForTransaction(submitted.Hash)
+		require.NoError(t, err)
+		assert.True(t, txn.Success)
+		assert.Equal(t, submitted.ReplayProtectionNonce, txn.ReplayProtectionNonce)
+	}
+	balance, err := client.AccountAPTBalance(aptos.AccountTwo)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), balance)
+	info, err := client.Account(sender.Address)
+	require.NoError(t, err)
+	sequenceNumber, err := info.SequenceNumber()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), sequenceNumber)
+
+	// A nonce can't be reused until its transaction expires
+	rawTxn, err := client.BuildTransaction(sender.Address, transferPayload(t, aptos.AccountTwo, 1), aptos.ReplayNonce(7))
+	require.NoError(t, err)
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	require.NoError(t, err)
+	_, err = client.SubmitTransaction(signedTxn)
+	require.NoError(t, err)
+	rawTxn, err = client.BuildTransaction(sender.Address, transferPayload(t, aptos.AccountTwo, 2), aptos.ReplayNonce(7))
+	require.NoError(t, err)
+	signedTxn, err = rawTxn.SignedTransaction(sender)
+	require.NoError(t, err)
+	_, err = client.SubmitTransaction(signedTxn)
+	require.ErrorContains(t, err, "NONCE_ALREADY_USED")
+
+	// Nor expire too far in the future
+	_, err = client.BuildTransaction(sender.Address, transferPayload(t, aptos.AccountTwo, 1), aptos.Orderless(true), aptos.ExpirationSeconds(300))
+	require.Error(t, err)
+	rawTxn, err = client.BuildTransaction(sender.Address, transferPayload(t, aptos.AccountTwo, 1), aptos.Orderless(true))
+	require.NoError(t, err)
+	rawTxn.ExpirationTimestampSeconds += 300
+	signedTxn, err = rawTxn.SignedTransaction(sender)
+	require.NoError(t, err)
+	_, err = client.SubmitTransaction(signedTxn)
+	require.ErrorContains(t, err, "TRANSACTION_EXPIRATION_TOO_FAR_IN_FUTURE")
+}
+
+func TestClient_BuildSignAndSubmitTransactions(t *testing.T) {
+	t.Parallel()
+	client := NewClient()
+	sender := newFundedAccount(t, client, 100_000_000)
+
+	const count = 5
+	payloads := make(chan aptos.TransactionBuildPayload, count)
+	responses := make(chan aptos.TransactionSubmissionResponse, count)
+	for id := range uint64(count) {
+		payloads <- aptos.TransactionBuildPayload{Id: id, Type: aptos.TransactionSubmissionTypeSingle, Inner: transferPayload(t, aptos.AccountTwo, 1)}
+	}
+	close(payloads)
+	client.BuildSignAndSubmitTransactions(sender, payloads, responses)
+	ids := map[uint64]bool{}
+	for response := range responses {
+		require.NoError(t, response.Err)
+		ids[response.Id] = true
+	}
+	assert.Len(t, ids, count)
+	balance, err := client.AccountAPTBalance(aptos.AccountTwo)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(count), balance)
+
+	// Nothing is built once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	payloads = make(chan aptos.TransactionBuildPayload, 1)
+	responses = make(chan aptos.TransactionSubmissionResponse, 1)
+	payloads <- aptos.TransactionBuildPayload{Type: aptos.TransactionSubmissionTypeSingle, Inner: transferPayload(t, aptos.AccountTwo, 1)}
+	close(payloads)
+	client.BuildSignAndSubmitTransactionsCtx(ctx, sender, payloads, responses)
+	response := <-responses
+	require.ErrorIs(t, response.Err, context.Canceled)
+}
+
+func TestClient_SimulateMaxGas(t *testing.T) {
+	t.Parallel()
+	client := NewClient(Config{GasUsed: 100})
+	sender := newFundedAccount(t, client, 100_000_000)
+
+	rawTxn, err := client.BuildTransaction(sender.Address, transferPayload(t, aptos.AccountTwo, 1), aptos.SimulateMaxGas{Signer: sender})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(150), rawTxn.MaxGasAmount)
+	assert.Equal(t, uint8(DefaultConfig().ChainId), rawTxn.ChainId)
+
+	_, err = client.BuildTransaction(sender.Address, transferPayload(t, aptos.AccountTwo, 1), aptos.FeePayer(&aptos.AccountZero))
+	require.Error(t, err)
+
+	withData, err := client.BuildTransactionMultiAgent(sender.Address, transferPayload(t, aptos.AccountTwo, 1), aptos.FeePayer(&aptos.AccountZero), aptos.SimulateMaxGas{Signer: sender})
+	require.NoError(t, err)
+	inner, ok := withData.Inner.(*aptos.MultiAgentWithFeePayerRawTransactionWithData)
+	require.True(t, ok)
+	assert.Equal(t, uint64(150), inner.RawTxn.MaxGasAmount)
+}
+
+func TestClient_ResourcesAndViews(t *testing.T) {
+	t.Parallel()
+	client := NewClient()
+	account := newFundedAccount(t, client, 1_000)
+
+	_, err := client.AccountResource(account.Address, "0xcafe::counter::Counter")
+	require.ErrorIs(t, err, aptos.ErrResourceNotFound)
+	client.SetResource(account.Address, "0xcafe::counter::Counter", map[string]any{"value": "7"})
+	resource, err := client.AccountResource(account.Address, "0xcafe::counter::Counter")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"value": "7"}, resource["data"])
+	resources, err := client.AccountResources(account.Address)
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+
+	client.SetViewResult("0xcafe::counter::value", "7")
+	amount, err := bcs.SerializeU64(1)
+	require.NoError(t, err)
+	payload := &aptos.ViewPayload{
+		Module:   aptos.ModuleId{Address: aptos.AccountOne, Name: "counter"},
+		Function: "value",
+		ArgTypes: []aptos.TypeTag{},
+		Args:     [][]byte{amount},
+	}
+	_, err = client.View(payload)
+	require.ErrorIs(t, err, ErrUnsupported)
+	require.NoError(t, payload.Module.Address.ParseStringRelaxed("0xcafe"))
+	values, err := client.View(payload)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"7"}, values)
+	var value string
+	err = client.ViewWithResponse(&value, payload)
+	require.NoError(t, err)
+	assert.Equal(t, "7", value)
+
+	// The APT balance view is built in
+	values, err = client.View(&aptos.ViewPayload{
+		Module:   aptos.ModuleId{Address: aptos.AccountOne, Name: "coin"},
+		Function: "balance",
+		ArgTypes: []aptos.TypeTag{aptos.AptosCoinTypeTag},
+		Args:     [][]byte{account.Address[:]},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"1000"}, values)
+}
+
+func TestClient_Unsupported(t *testing.T) {
+	t.Parallel()
+	client := NewClient()
+	_, err := client.BlockByHeight(0, false)
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = client.AccountModule(aptos.AccountOne, "coin")
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = client.EventsByCreationNumber(aptos.AccountOne, "0", nil, nil)
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = client.TransactionByHash("0x1234")
+	require.ErrorIs(t, err, aptos.ErrTransactionNotFound)
+}
@@ -0,0 +1,18 @@
+// Package aptostest provides an in-memory fake of [aptos.AptosClient] for unit tests, in the spirit of
+// net/http/httptest.
+//
+// A [Client] keeps a ledger of accounts, with sequence numbers and APT balances.  Transactions submitted to it have
+// their signatures checked, and APT transfers are applied as soon as they are submitted.  Resources and the results of
+// view functions are set by the test.
+//
+//	client := aptostest.NewClient()
+//	err := client.Fund(sender.Address, 100_000_000)
+//	...
+//	service := NewPaymentService(client) // Takes an aptos.AptosClient
+//	err = service.Pay(receiver, 1_000)
+//	...
+//	balance, err := client.AccountAPTBalance(receiver)
+//
+// Anything the fake can't serve, such as blocks, modules, or functions other than APT transfers, fails with
+// [ErrUnsupported].
+package aptostest
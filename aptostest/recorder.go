@@ -0,0 +1,364 @@
+package aptostest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// ErrUnmatchedRequest is returned by a replaying [Recorder] for a request its cassette has no interaction left for
+var ErrUnmatchedRequest = errors.New("no recorded interaction matches the request")
+
+// RecorderMode is whether a [Recorder] records or replays
+type RecorderMode int
+
+const (
+	ModeReplay RecorderMode = iota // ModeReplay responds from the cassette, without any network access
+	ModeRecord                     // ModeRecord sends requests to the network, and records them for [Recorder.Save]
+)
+
+// RecordedRequest is a request of an [Interaction]
+type RecordedRequest struct {
+	Method     string `json:"method"`
+	Url        string `json:"url"`
+	Body       string `json:"body,omitempty"`        // Body if it's text, with JSON compacted and its object keys sorted
+	BodyBase64 string `json:"body_base64,omitempty"` // Body if it's binary e.g. BCS, in base64
+}
+
+// RecordedResponse is a response of an [Interaction]
+type RecordedResponse struct {
+	Status     int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`        // Body if it's text, with JSON compacted and its object keys sorted
+	BodyBase64 string      `json:"body_base64,omitempty"` // Body if it's binary e.g. BCS, in base64
+}
+
+// Interaction is a request and the response to it
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Cassette is the interactions recorded by a [Recorder], in the order they happened, saved as JSON
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Normalizer rewrites volatile parts of an interaction, such as timestamps and hashes, so that cassettes are stable
+// from one recording to the next and requests match them on replay.  Normalizers are applied to interactions as
+// they're saved to the cassette, the client still gets the responses as is, and to requests, with an empty Response,
+// before they're matched on replay.
+type Normalizer func(interaction *Interaction)
+
+// NormalizeJsonFields replaces the values of the named fields, at any depth of JSON request and response bodies, with
+// value e.g. "0" for the timestamps of transactions.
+func NormalizeJsonFields(value any, fields ...string) Normalizer {
+	names := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		names[field] = true
+	}
+	var replace func(v any) any
+	replace = func(v any) any {
+		switch typed := v.(type) {
+		case map[string]any:
+			for key, inner := range typed {
+				if names[key] {
+					typed[key] = value
+				} else {
+					typed[key] = replace(inner)
+				}
+			}
+		case []any:
+			for i, inner := range typed {
+				typed[i] = replace(inner)
+			}
+		}
+		return v
+	}
+	normalize := func(body string) string {
+		decoded, ok := decodeJson([]byte(body))
+		if !ok {
+			return body
+		}
+		normalized, err := encodeJson(replace(decoded))
+		if err != nil {
+			return body
+		}
+		return normalized
+	}
+	return func(interaction *Interaction) {
+		interaction.Request.Body = normalize(interaction.Request.Body)
+		interaction.Response.Body = normalize(interaction.Response.Body)
+	}
+}
+
+// NormalizeHeaders replaces the values of the named response headers with value e.g. "0" for
+// [aptos.LedgerTimestampHeader]
+func NormalizeHeaders(value string, headers ...string) Normalizer {
+	return func(interaction *Interaction) {
+		for _, header := range headers {
+			if interaction.Response.Header.Get(header) != "" {
+				interaction.Response.Header.Set(header, value)
+			}
+		}
+	}
+}
+
+// NormalizeUrls replaces the matches of pattern in request URLs with replacement e.g. transaction hashes in
+// /transactions/by_hash/{hash}
+func NormalizeUrls(pattern *regexp.Regexp, replacement string) Normalizer {
+	return func(interaction *Interaction) {
+		interaction.Request.Url = pattern.ReplaceAllString(interaction.Request.Url, replacement)
+	}
+}
+
+// IgnoreRequestBodies drops the bodies of the requests whose URL matches pattern, e.g. signed transactions whose
+// expiration changes on every run, so they're matched by method and URL alone
+func IgnoreRequestBodies(pattern *regexp.Regexp) Normalizer {
+	return func(interaction *Interaction) {
+		if pattern.MatchString(interaction.Request.Url) {
+			interaction.Request.Body = ""
+			interaction.Request.BodyBase64 = ""
+		}
+	}
+}
+
+// Recorder is an [http.RoundTripper] recording node, indexer and faucet interactions to a [Cassette], and replaying
+// them deterministically in tests.  Give its [Recorder.Client] to [aptos.NewNodeClientWithHttpClient] and
+// [aptos.NewIndexerClient].
+//
+//	recorder, err := aptostest.NewRecorder("testdata/transfer.json", aptostest.ModeReplay)
+//	...
+//	nodeClient, err := aptos.NewNodeClientWithHttpClient(aptos.DevnetConfig.NodeUrl, aptos.DevnetConfig.ChainId, recorder.Client())
+//	indexerClient := aptos.NewIndexerClient(recorder.Client(), aptos.DevnetConfig.IndexerUrl)
+//
+// On replay, each request is answered by the first interaction not yet replayed with the same method, URL, and body,
+// so repeated requests, such as polling for a transaction, get the responses in the order they were recorded.
+// Requests without one fail with [ErrUnmatchedRequest].
+type Recorder struct {
+	mode        RecorderMode
+	path        string
+	transport   http.RoundTripper
+	normalizers []Normalizer
+
+	mutex    sync.Mutex
+	cassette *Cassette
+	replayed []bool // Whether each interaction of the cassette was replayed
+}
+
+// NewRecorder creates a [Recorder] for the cassette at path, which is loaded on replay.
+//
+// Options:
+//   - [Normalizer] rewrites volatile parts of interactions, in the order given
+//   - [http.RoundTripper] sends the requests being recorded, [http.DefaultTransport] by default
+func NewRecorder(path string, mode RecorderMode, options ...any) (*Recorder, error) {
+	recorder := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: http.DefaultTransport,
+		cassette:  &Cassette{Interactions: []*Interaction{}},
+	}
+	for i, option := range options {
+		switch value := option.(type) {
+		case Normalizer:
+			recorder.normalizers = append(recorder.normalizers, value)
+		case http.RoundTripper:
+			recorder.transport = value
+		default:
+			return nil, fmt.Errorf("NewRecorder arg %d bad type %T", i+3, option)
+		}
+	}
+
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		err = json.Unmarshal(data, recorder.cassette)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+		recorder.replayed = make([]bool, len(recorder.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown recorder mode %d", mode)
+	}
+	return recorder, nil
+}
+
+// Client is an [http.Client] sending its requests through the recorder
+func (recorder *Recorder) Client() *http.Client {
+	return &http.Client{Transport: recorder}
+}
+
+// RoundTrip records or replays req
+//
+// Implements:
+//   - [http.RoundTripper]
+func (recorder *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	interaction := &Interaction{Request: RecordedRequest{Method: req.Method, Url: req.URL.String()}}
+	interaction.Request.Body, interaction.Request.BodyBase64 = encodeBody(body)
+	if recorder.mode == ModeReplay {
+		return recorder.replay(req, interaction)
+	}
+
+	// Send a copy of the request, as the body was consumed
+	sent := req.Clone(req.Context())
+	sent.Body = io.NopCloser(bytes.NewReader(body))
+	response, err := recorder.transport.RoundTrip(sent)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	interaction.Response = RecordedResponse{Status: response.StatusCode, Header: response.Header.Clone()}
+	interaction.Response.Body, interaction.Response.BodyBase64 = encodeBody(responseBody)
+
+	// The client gets the response as is, only the cassette is normalized
+	received, err := interaction.Response.toResponse(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, normalize := range recorder.normalizers {
+		normalize(interaction)
+	}
+	recorder.mutex.Lock()
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, interaction)
+	recorder.mutex.Unlock()
+	return received, nil
+}
+
+// Save writes the recorded cassette to its path
+func (recorder *Recorder) Save() error {
+	if recorder.mode != ModeRecord {
+		return errors.New("only a recording recorder can be saved")
+	}
+	recorder.mutex.Lock()
+	data, err := json.MarshalIndent(recorder.cassette, "", "  ")
+	recorder.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(recorder.path, append(data, '\n'), 0o644) //nolint:gosec // Cassettes are checked in fixtures
+}
+
+// Unreplayed is the number of interactions of the cassette not replayed yet, so a test can check it made every
+// request it recorded
+func (recorder *Recorder) Unreplayed() int {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	unreplayed := 0
+	for _, replayed := range recorder.replayed {
+		if !replayed {
+			unreplayed++
+		}
+	}
+	return unreplayed
+}
+
+// replay responds to req with the first matching interaction not replayed yet
+func (recorder *Recorder) replay(req *http.Request, interaction *Interaction) (*http.Response, error) {
+	for _, normalize := range recorder.normalizers {
+		normalize(interaction)
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	for i, recorded := range recorder.cassette.Interactions {
+		if !recorder.replayed[i] && recorded.Request == interaction.Request {
+			recorder.replayed[i] = true
+			return recorded.Response.toResponse(req)
+		}
+	}
+	return nil, fmt.Errorf("%s %s: %w", req.Method, interaction.Request.Url, ErrUnmatchedRequest)
+}
+
+// toResponse is the [http.Response] to req
+func (recorded *RecordedResponse) toResponse(req *http.Request) (*http.Response, error) {
+	body := []byte(recorded.Body)
+	if recorded.BodyBase64 != "" {
+		var err error
+		body, err = base64.StdEncoding.DecodeString(recorded.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("bad recorded response body: %w", err)
+		}
+	}
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(recorded.Status) + " " + http.StatusText(recorded.Status),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody reads the body of req, which may have none
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// encodeBody is body as text, with JSON compacted and its object keys sorted so equivalent requests match, or in
+// base64 if it's binary
+func encodeBody(body []byte) (string, string) {
+	if decoded, ok := decodeJson(body); ok {
+		canonical, err := encodeJson(decoded)
+		if err == nil {
+			return canonical, ""
+		}
+	}
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return "", base64.StdEncoding.EncodeToString(body)
+}
+
+// decodeJson decodes data if it's JSON, keeping numbers exact
+func decodeJson(data []byte) (any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded any
+	if decoder.Decode(&decoded) != nil || decoder.More() {
+		return nil, false
+	}
+	return decoded, true
+}
+
+// encodeJson encodes v compactly, with object keys sorted and without escaping HTML e.g. the <> of type arguments
+func encodeJson(v any) (string, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(v)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSuffix(buffer.Bytes(), []byte("\n"))), nil
+}
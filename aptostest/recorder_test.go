@@ -0,0 +1,130 @@
+package aptostest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIndexerServer serves the last success version of processor_status queries, 42 for every processor
+func newIndexerServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Contains(t, request.Query, "processor_status")
+		_, _ = w.Write([]byte(`{"data":{"processor_status":[{"last_success_version":42}]}}`))
+	}))
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	normalizers := []any{
+		NormalizeJsonFields("0", "timestamp", "expiration_timestamp_secs"),
+		NormalizeJsonFields("0x0", "hash"),
+		NormalizeHeaders("0", aptos.LedgerTimestampHeader, "Date"),
+		NormalizeUrls(regexp.MustCompile(`by_hash/0x[0-9a-f]+`), "by_hash/0x0"),
+		IgnoreRequestBodies(regexp.MustCompile(`/transactions$`)),
+	}
+	sender, err := aptos.NewEd25519Account()
+	require.NoError(t, err)
+
+	// The same calls are made when recording and replaying
+	run := func(recorder *Recorder, nodeUrl string, faucetUrl string, indexerUrl string) (uint64, *aptos.AccountInfo, uint64) {
+		nodeClient, err := aptos.NewNodeClientWithHttpClient(nodeUrl, DefaultConfig().ChainId, recorder.Client())
+		require.NoError(t, err)
+		faucetClient, err := aptos.NewFaucetClient(nodeClient, faucetUrl)
+		require.NoError(t, err)
+		indexerClient := aptos.NewIndexerClient(recorder.Client(), indexerUrl)
+
+		require.NoError(t, faucetClient.Fund(sender.Address, 100_000_000))
+		submitted, err := nodeClient.BuildSignAndSubmitTransaction(sender, transferPayload(t, aptos.AccountTwo, 1_000))
+		require.NoError(t, err)
+		txn, err := nodeClient.WaitForTransaction(submitted.Hash)
+		require.NoError(t, err)
+		assert.True(t, txn.Success)
+		balance, err := nodeClient.AccountAPTBalance(aptos.AccountTwo)
+		require.NoError(t, err)
+		info, err := nodeClient.Account(sender.Address)
+		require.NoError(t, err)
+		version, err := indexerClient.GetProcessorStatus("default_processor")
+		require.NoError(t, err)
+		return balance, &info, version
+	}
+
+	// Record against a fake node and indexer
+	server := NewServer()
+	indexer := newIndexerServer(t)
+	recorder, err := NewRecorder(path, ModeRecord, normalizers...)
+	require.NoError(t, err)
+	balance, info, version := run(recorder, server.NodeUrl(), server.FaucetUrl(), indexer.URL)
+	require.NoError(t, recorder.Save())
+	server.Close()
+	indexer.Close()
+	assert.Equal(t, uint64(1_000), balance)
+	assert.Equal(t, uint64(42), version)
+
+	// Volatile fields were normalized
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	cassette := &Cassette{}
+	require.NoError(t, json.Unmarshal(data, cassette))
+	require.NotEmpty(t, cassette.Interactions)
+	for _, interaction := range cassette.Interactions {
+		if strings.HasPrefix(interaction.Request.Url, server.URL) {
+			assert.Equal(t, "0", interaction.Response.Header.Get(aptos.LedgerTimestampHeader))
+		}
+		assert.NotRegexp(t, `"hash":"0x[0-9a-f]{64}"`, interaction.Response.Body)
+	}
+
+	// Replay without them, from the cassette alone
+	replayer, err := NewRecorder(path, ModeReplay, normalizers...)
+	require.NoError(t, err)
+	replayedBalance, replayedInfo, replayedVersion := run(replayer, server.NodeUrl(), server.FaucetUrl(), indexer.URL)
+	assert.Equal(t, balance, replayedBalance)
+	assert.Equal(t, info, replayedInfo)
+	assert.Equal(t, version, replayedVersion)
+	assert.Equal(t, 0, replayer.Unreplayed())
+
+	// Requests that weren't recorded fail, including GraphQL queries with other variables
+	nodeClient, err := aptos.NewNodeClientWithHttpClient(server.NodeUrl(), DefaultConfig().ChainId, replayer.Client())
+	require.NoError(t, err)
+	_, err = nodeClient.Account(aptos.AccountOne)
+	require.ErrorIs(t, err, ErrUnmatchedRequest)
+	_, err = aptos.NewIndexerClient(replayer.Client(), indexer.URL).GetProcessorStatus("other_processor")
+	require.ErrorIs(t, err, ErrUnmatchedRequest)
+}
+
+func TestRecorder_Errors(t *testing.T) {
+	t.Parallel()
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	require.Error(t, err)
+	_, err = NewRecorder("", ModeRecord, "not an option")
+	require.Error(t, err)
+
+	// An empty cassette matches nothing
+	path := filepath.Join(t.TempDir(), "empty.json")
+	recorder, err := NewRecorder(path, ModeRecord)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save())
+	replayer, err := NewRecorder(path, ModeReplay)
+	require.NoError(t, err)
+	require.Error(t, replayer.Save())
+	nodeClient, err := aptos.NewNodeClientWithHttpClient("http://localhost:1/v1", 4, replayer.Client())
+	require.NoError(t, err)
+	_, err = nodeClient.Info()
+	require.ErrorIs(t, err, ErrUnmatchedRequest)
+}
@@ -0,0 +1,677 @@
+package aptostest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// Fault is an error response injected by [Server.InjectFault], e.g. to exercise the retries of a
+// [aptos.RetryPolicy]
+type Fault struct {
+	Method string      // Method of the requests to fail, "" for any
+	Path   string      // Path of the requests to fail, and the paths under it, relative to the node URL e.g. "/transactions", "" for any
+	Status int         // Status of the response, defaults to 503
+	Error  api.Error   // Error in the response body
+	Header http.Header // Header of the response e.g. Retry-After
+	Times  int         // Times to fail before the fault is removed, 0 to fail until [Server.ClearFaults]
+}
+
+// Server is a fake full node and faucet serving the REST routes used by [aptos.NodeClient] and [aptos.FaucetClient]
+// from the in-memory ledger of a [Client], in the spirit of [httptest.Server].
+//
+//	server := aptostest.NewServer()
+//	defer server.Close()
+//	client, err := aptos.NewClient(aptos.NetworkConfig{
+//		ChainId:   aptostest.DefaultConfig().ChainId,
+//		NodeUrl:   server.NodeUrl(),
+//		FaucetUrl: server.FaucetUrl(),
+//	})
+//
+// The node is served under /v1, and the faucet's /mint at the root.  Every response has the ledger headers of
+// [aptos.ResponseInfo].  Requests the ledger can't serve, including node routes that aren't implemented and view
+// functions with BCS results, fail with status 501 and error code api_disabled.
+type Server struct {
+	*httptest.Server
+	Ledger *Client // Ledger served, which tests may also set up and inspect directly
+
+	mutex    sync.Mutex
+	latency  time.Duration
+	faults   []*Fault
+	requests uint64
+}
+
+// NewServer starts a [Server] with a new ledger, see [NewClient].  It must be closed with [Server.Close].
+func NewServer(config ...Config) *Server {
+	s := &Server{Ledger: NewClient(config...)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1", s.handleInfo)
+	mux.HandleFunc("GET /v1/{$}", s.handleInfo)
+	mux.HandleFunc("GET /v1/-/healthy", s.handleHealthy)
+	mux.HandleFunc("GET /v1/estimate_gas_price", s.handleEstimateGasPrice)
+	mux.HandleFunc("GET /v1/accounts/{address}", s.handleAccount)
+	mux.HandleFunc("GET /v1/accounts/{address}/resources", s.handleAccountResources)
+	mux.HandleFunc("GET /v1/accounts/{address}/resource/{type}", s.handleAccountResource)
+	mux.HandleFunc("GET /v1/accounts/{address}/transactions", s.handleAccountTransactions)
+	mux.HandleFunc("GET /v1/transactions", s.handleTransactions)
+	mux.HandleFunc("POST /v1/transactions", s.handleSubmit)
+	mux.HandleFunc("POST /v1/transactions/batch", s.handleBatchSubmit)
+	mux.HandleFunc("POST /v1/transactions/simulate", s.handleSimulate)
+	mux.HandleFunc("GET /v1/transactions/by_hash/{hash}", s.handleTransactionByHash)
+	mux.HandleFunc("GET /v1/transactions/wait_by_hash/{hash}", s.handleTransactionByHash)
+	mux.HandleFunc("GET /v1/transactions/by_version/{version}", s.handleTransactionByVersion)
+	mux.HandleFunc("POST /v1/view", s.handleView)
+	mux.HandleFunc("POST /mint", s.handleMint)
+	mux.HandleFunc("/v1/", handleUnsupported)
+	s.Server = httptest.NewServer(s.intercept(mux))
+	return s
+}
+
+// NodeUrl is the URL of the node API, for [aptos.NewNodeClient] or [aptos.NetworkConfig.NodeUrl]
+func (s *Server) NodeUrl() string {
+	return s.URL + "/v1"
+}
+
+// FaucetUrl is the URL of the faucet, for [aptos.NewFaucetClient] or [aptos.NetworkConfig.FaucetUrl]
+func (s *Server) FaucetUrl() string {
+	return s.URL
+}
+
+// SetLatency delays every response by latency, 0 to respond right away
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.latency = latency
+}
+
+// InjectFault fails the requests matching fault with its error response, instead of serving them.  Faults are
+// matched in the order they were injected.
+func (s *Server) InjectFault(fault Fault) {
+	if fault.Status == 0 {
+		fault.Status = http.StatusServiceUnavailable
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all the faults injected
+func (s *Server) ClearFaults() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults = nil
+}
+
+// Requests is the number of requests received, including the ones failed by faults
+func (s *Server) Requests() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests
+}
+
+// intercept counts requests, applies the latency, and fails requests with the faults before they reach next
+func (s *Server) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		s.requests++
+		latency := s.latency
+		fault := s.matchFault(r)
+		s.mutex.Unlock()
+
+		if latency > 0 {
+			timer := time.NewTimer(latency)
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			}
+		}
+
+		s.writeHeader(w)
+		if fault != nil {
+			for key, values := range fault.Header {
+				w.Header()[key] = values
+			}
+			writeJson(w, fault.Status, fault.Error)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matchFault returns the first fault matching r, counting it against its Times.  The mutex must be held.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	path := strings.TrimPrefix(r.URL.Path, "/v1")
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if fault.Path != "" && path != fault.Path && !strings.HasPrefix(path, strings.TrimSuffix(fault.Path, "/")+"/") {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// writeHeader sets the ledger headers of a response
+func (s *Server) writeHeader(w http.ResponseWriter) {
+	s.Ledger.mutex.RLock()
+	info := s.Ledger.responseInfo()
+	s.Ledger.mutex.RUnlock()
+	header := w.Header()
+	header.Set(aptos.ChainIdHeader, strconv.FormatUint(uint64(info.ChainId), 10))
+	header.Set(aptos.LedgerVersionHeader, strconv.FormatUint(info.LedgerVersion, 10))
+	header.Set(aptos.LedgerOldestVersionHeader, strconv.FormatUint(info.OldestLedgerVersion, 10))
+	header.Set(aptos.LedgerTimestampHeader, strconv.FormatUint(info.LedgerTimestamp, 10))
+	header.Set(aptos.EpochHeader, strconv.FormatUint(info.Epoch, 10))
+	header.Set(aptos.BlockHeightHeader, strconv.FormatUint(info.BlockHeight, 10))
+	header.Set(aptos.OldestBlockHeightHeader, strconv.FormatUint(info.OldestBlockHeight, 10))
+}
+
+// region Handlers
+
+func (s *Server) handleInfo(w http.ResponseWriter, _ *http.Request) {
+	info, err := s.Ledger.Info()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, info)
+}
+
+func (s *Server) handleHealthy(w http.ResponseWriter, _ *http.Request) {
+	health, err := s.Ledger.NodeAPIHealthCheck()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, health)
+}
+
+func (s *Server) handleEstimateGasPrice(w http.ResponseWriter, _ *http.Request) {
+	estimate, err := s.Ledger.EstimateGasPrice()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, estimate)
+}
+
+func (s *Server) handleAccount(w http.ResponseWriter, r *http.Request) {
+	address, ledgerVersion, err := accountRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	info, err := s.Ledger.Account(address, ledgerVersion...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, info)
+}
+
+func (s *Server) handleAccountResources(w http.ResponseWriter, r *http.Request) {
+	address, ledgerVersion, err := accountRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resources, err := s.Ledger.AccountResources(address, ledgerVersion...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body := make([]map[string]any, len(resources))
+	for i, resource := range resources {
+		body[i] = map[string]any{"type": resource.Type, "data": resource.Data}
+	}
+	writeJson(w, http.StatusOK, body)
+}
+
+func (s *Server) handleAccountResource(w http.ResponseWriter, r *http.Request) {
+	address, ledgerVersion, err := accountRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resource, err := s.Ledger.AccountResource(address, r.PathValue("type"), ledgerVersion...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, resource)
+}
+
+func (s *Server) handleAccountTransactions(w http.ResponseWriter, r *http.Request) {
+	address, _, err := accountRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	start, limit, err := pageRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	txns, err := s.Ledger.AccountTransactions(address, start, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeTransactions(w, txns)
+}
+
+func (s *Server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	start, limit, err := pageRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	txns, err := s.Ledger.Transactions(start, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeTransactions(w, txns)
+}
+
+func (s *Server) handleTransactionByHash(w http.ResponseWriter, r *http.Request) {
+	txn, err := s.Ledger.transactionByHash(r.PathValue("hash"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, committedTransactionJson(txn))
+}
+
+func (s *Server) handleTransactionByVersion(w http.ResponseWriter, r *http.Request) {
+	version, err := strconv.ParseUint(r.PathValue("version"), 10, 64)
+	if err != nil {
+		writeError(w, invalidInput("bad version: "+err.Error()))
+		return
+	}
+	txn, err := s.Ledger.TransactionByVersion(version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, committedTransactionJson(txn))
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	signedTxn := &aptos.SignedTransaction{}
+	err := readBcs(r, aptos.ContentTypeAptosSignedTxnBcs, signedTxn)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	pending, err := s.Ledger.SubmitTransaction(signedTxn)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusAccepted, pendingTransactionJson(pending))
+}
+
+func (s *Server) handleBatchSubmit(w http.ResponseWriter, r *http.Request) {
+	body, err := readBody(r, aptos.ContentTypeAptosSignedTxnBcs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	des := bcs.NewDeserializer(body)
+	signedTxns := bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, out **aptos.SignedTransaction) {
+		*out = &aptos.SignedTransaction{}
+		(*out).UnmarshalBCS(des)
+	})
+	if des.Error() != nil || des.Remaining() != 0 {
+		writeError(w, invalidInput("bad BCS signed transactions"))
+		return
+	}
+	response, err := s.Ledger.BatchSubmitTransaction(signedTxns)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	failures := make([]map[string]any, len(response.TransactionFailures))
+	for i, failure := range response.TransactionFailures {
+		failures[i] = map[string]any{"error": failure.Error, "transaction_index": failure.TransactionIndex}
+	}
+	writeJson(w, http.StatusAccepted, map[string]any{"transaction_failures": failures})
+}
+
+func (s *Server) handleSimulate(w http.ResponseWriter, r *http.Request) {
+	signedTxn := &aptos.SignedTransaction{}
+	err := readBcs(r, aptos.ContentTypeAptosSignedTxnBcs, signedTxn)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	options := make([]any, 0, 1)
+	if r.URL.Query().Get("estimate_max_gas_amount") == "true" {
+		options = append(options, aptos.EstimateMaxGasAmount(true))
+	}
+	if auth, ok := signedTxn.Authenticator.Auth.(*aptos.FeePayerTransactionAuthenticator); ok {
+		options = append(options, aptos.FeePayer(auth.FeePayer))
+	}
+	simulated, err := s.Ledger.SimulateTransaction(signedTxn.Transaction, nil, options...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body := make([]map[string]any, len(simulated))
+	for i, txn := range simulated {
+		body[i] = userTransactionJson(txn)
+	}
+	writeJson(w, http.StatusOK, body)
+}
+
+func (s *Server) handleView(w http.ResponseWriter, r *http.Request) {
+	// The values of a view function are only known as JSON, without the types to encode them in BCS
+	if r.Header.Get("Accept") == "application/x-bcs" {
+		writeError(w, unsupported("view with BCS results"))
+		return
+	}
+	payload := &aptos.ViewPayload{}
+	err := readBcs(r, aptos.ContentTypeAptosViewFunctionBcs, payload)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	ledgerVersion, err := ledgerVersionRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	values, err := s.Ledger.View(payload, ledgerVersion...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, values)
+}
+
+// handleUnsupported responds to the node routes that aren't served, or not with the request's method
+func handleUnsupported(w http.ResponseWriter, r *http.Request) {
+	writeError(w, fmt.Errorf("%s %s: %w", r.Method, r.URL.Path, ErrUnsupported))
+}
+
+func (s *Server) handleMint(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	address := aptos.AccountAddress{}
+	err := address.ParseStringRelaxed(query.Get("address"))
+	if err != nil {
+		writeError(w, invalidInput("bad address: "+err.Error()))
+		return
+	}
+	amount, err := strconv.ParseUint(query.Get("amount"), 10, 64)
+	if err != nil {
+		writeError(w, invalidInput("bad amount: "+err.Error()))
+		return
+	}
+	hash, err := s.Ledger.mint(address, amount)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, []string{hash})
+}
+
+// endregion
+
+// region Requests
+
+// accountRequest parses the address in the path and the ledger version in the query of r
+func accountRequest(r *http.Request) (aptos.AccountAddress, []uint64, error) {
+	address := aptos.AccountAddress{}
+	err := address.ParseStringRelaxed(r.PathValue("address"))
+	if err != nil {
+		return address, nil, invalidInput("bad address: " + err.Error())
+	}
+	ledgerVersion, err := ledgerVersionRequest(r)
+	return address, ledgerVersion, err
+}
+
+// ledgerVersionRequest parses the ledger version in the query of r, empty if there is none
+func ledgerVersionRequest(r *http.Request) ([]uint64, error) {
+	value := r.URL.Query().Get("ledger_version")
+	if value == "" {
+		return nil, nil
+	}
+	version, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, invalidInput("bad ledger_version: " + err.Error())
+	}
+	return []uint64{version}, nil
+}
+
+// pageRequest parses the start and limit in the query of r, nil if they're absent
+func pageRequest(r *http.Request) (*uint64, *uint64, error) {
+	query := r.URL.Query()
+	values := make([]*uint64, 2)
+	for i, name := range []string{"start", "limit"} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, nil, invalidInput("bad " + name + ": " + err.Error())
+		}
+		values[i] = &parsed
+	}
+	return values[0], values[1], nil
+}
+
+// readBody reads the body of r, which must have the contentType
+func readBody(r *http.Request, contentType string) ([]byte, error) {
+	if r.Header.Get("Content-Type") != contentType {
+		return nil, invalidInput("content type must be " + contentType)
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, invalidInput("bad body: " + err.Error())
+	}
+	return body, nil
+}
+
+// readBcs reads the BCS body of r into dest
+func readBcs(r *http.Request, contentType string, dest bcs.Unmarshaler) error {
+	body, err := readBody(r, contentType)
+	if err != nil {
+		return err
+	}
+	err = bcs.Deserialize(dest, body)
+	if err != nil {
+		return invalidInput("bad BCS body: " + err.Error())
+	}
+	return nil
+}
+
+// endregion
+
+// region Responses
+
+// invalidInput is a request error, responded to with a 400 invalid_input
+func invalidInput(message string) error {
+	return fmt.Errorf("%s: %w", message, aptos.ErrInvalidInput)
+}
+
+// writeJson responds with status and body encoded in JSON
+func writeJson(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// errorResponses are the status and error code of the node's response to each error of the ledger
+var errorResponses = []struct {
+	err       error
+	status    int
+	errorCode string
+}{
+	{aptos.ErrAccountNotFound, http.StatusNotFound, api.ErrorCodeAccountNotFound},
+	{aptos.ErrResourceNotFound, http.StatusNotFound, api.ErrorCodeResourceNotFound},
+	{aptos.ErrTransactionNotFound, http.StatusNotFound, api.ErrorCodeTransactionNotFound},
+	{aptos.ErrVersionNotFound, http.StatusNotFound, api.ErrorCodeVersionNotFound},
+	{aptos.ErrInvalidInput, http.StatusBadRequest, api.ErrorCodeInvalidInput},
+	{ErrUnsupported, http.StatusNotImplemented, api.ErrorCodeApiDisabled},
+}
+
+// writeError responds with the node's error response to err
+func writeError(w http.ResponseWriter, err error) {
+	apiError := api.Error{Message: err.Error(), ErrorCode: api.ErrorCodeInternalError}
+	status := http.StatusInternalServerError
+	var rejected *rejection
+	if errors.As(err, &rejected) {
+		// Rejected transactions are vm_errors, whichever sentinel error they match
+		apiError.ErrorCode = api.ErrorCodeVmError
+		apiError.VmErrorCode = rejected.vmErrorCode
+		writeJson(w, http.StatusBadRequest, apiError)
+		return
+	}
+	for _, response := range errorResponses {
+		if errors.Is(err, response.err) {
+			status = response.status
+			apiError.ErrorCode = response.errorCode
+			break
+		}
+	}
+	writeJson(w, status, apiError)
+}
+
+// writeTransactions responds with txns
+func writeTransactions(w http.ResponseWriter, txns []*api.CommittedTransaction) {
+	body := make([]map[string]any, len(txns))
+	for i, txn := range txns {
+		body[i] = committedTransactionJson(txn)
+	}
+	writeJson(w, http.StatusOK, body)
+}
+
+// committedTransactionJson is the node's JSON of txn, the api types only decode JSON
+func committedTransactionJson(txn *api.CommittedTransaction) map[string]any {
+	switch inner := txn.Inner.(type) {
+	case *api.UserTransaction:
+		return userTransactionJson(inner)
+	case *api.GenesisTransaction:
+		return map[string]any{
+			"type":                  api.TransactionVariantGenesis,
+			"version":               strconv.FormatUint(inner.Version, 10),
+			"hash":                  inner.Hash,
+			"accumulator_root_hash": inner.AccumulatorRootHash,
+			"state_change_hash":     inner.StateChangeHash,
+			"event_root_hash":       inner.EventRootHash,
+			"gas_used":              strconv.FormatUint(inner.GasUsed, 10),
+			"success":               inner.Success,
+			"vm_status":             inner.VmStatus,
+			"changes":               []any{},
+			"events":                eventsJson(inner.Events),
+		}
+	default:
+		// The ledger only commits user transactions after genesis
+		return map[string]any{"type": txn.Type}
+	}
+}
+
+// userTransactionJson is the node's JSON of txn
+func userTransactionJson(txn *api.UserTransaction) map[string]any {
+	return withReplayNonce(txn.ReplayProtectionNonce, map[string]any{
+		"type":                      api.TransactionVariantUser,
+		"version":                   strconv.FormatUint(txn.Version, 10),
+		"hash":                      txn.Hash,
+		"accumulator_root_hash":     txn.AccumulatorRootHash,
+		"state_change_hash":         txn.StateChangeHash,
+		"event_root_hash":           txn.EventRootHash,
+		"gas_used":                  strconv.FormatUint(txn.GasUsed, 10),
+		"success":                   txn.Success,
+		"vm_status":                 txn.VmStatus,
+		"changes":                   []any{},
+		"events":                    eventsJson(txn.Events),
+		"sender":                    txn.Sender.String(),
+		"sequence_number":           strconv.FormatUint(txn.SequenceNumber, 10),
+		"max_gas_amount":            strconv.FormatUint(txn.MaxGasAmount, 10),
+		"gas_unit_price":            strconv.FormatUint(txn.GasUnitPrice, 10),
+		"expiration_timestamp_secs": strconv.FormatUint(txn.ExpirationTimestampSecs, 10),
+		"payload":                   payloadJson(txn.Payload),
+		"timestamp":                 strconv.FormatUint(txn.Timestamp, 10),
+	})
+}
+
+// pendingTransactionJson is the node's JSON of a submitted transaction
+func pendingTransactionJson(txn *api.PendingTransaction) map[string]any {
+	return withReplayNonce(txn.ReplayProtectionNonce, map[string]any{
+		"type":                      api.TransactionVariantPending,
+		"hash":                      txn.Hash,
+		"sender":                    txn.Sender.String(),
+		"sequence_number":           strconv.FormatUint(txn.SequenceNumber, 10),
+		"max_gas_amount":            strconv.FormatUint(txn.MaxGasAmount, 10),
+		"gas_unit_price":            strconv.FormatUint(txn.GasUnitPrice, 10),
+		"expiration_timestamp_secs": strconv.FormatUint(txn.ExpirationTimestampSecs, 10),
+		"payload":                   payloadJson(txn.Payload),
+	})
+}
+
+// withReplayNonce adds the replay nonce of an orderless transaction to its JSON
+func withReplayNonce(replayNonce *uint64, txnJson map[string]any) map[string]any {
+	if replayNonce != nil {
+		txnJson["replay_protection_nonce"] = strconv.FormatUint(*replayNonce, 10)
+	}
+	return txnJson
+}
+
+// payloadJson is the node's JSON of an entry function payload, the only payload the ledger commits
+func payloadJson(payload *api.TransactionPayload) map[string]any {
+	if payload == nil {
+		return nil
+	}
+	entryFunction, ok := payload.Inner.(*api.TransactionPayloadEntryFunction)
+	if !ok {
+		return map[string]any{"type": payload.Type}
+	}
+	return map[string]any{
+		"type":           payload.Type,
+		"function":       entryFunction.Function,
+		"type_arguments": entryFunction.TypeArguments,
+		"arguments":      entryFunction.Arguments,
+	}
+}
+
+// eventsJson is the node's JSON of the events of a transaction
+func eventsJson(events []*api.Event) []map[string]any {
+	body := make([]map[string]any, len(events))
+	for i, event := range events {
+		body[i] = map[string]any{
+			"guid": map[string]any{
+				"creation_number": strconv.FormatUint(event.Guid.CreationNumber, 10),
+				"account_address": event.Guid.AccountAddress.String(),
+			},
+			"sequence_number": strconv.FormatUint(event.SequenceNumber, 10),
+			"type":            event.Type,
+			"data":            event.Data,
+		}
+	}
+	return body
+}
+
+// endregion
@@ -0,0 +1,200 @@
+package aptostest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServerClient(t *testing.T, server *Server, options ...any) *aptos.Client {
+	t.Helper()
+	client, err := aptos.NewClient(aptos.NetworkConfig{
+		ChainId:   DefaultConfig().ChainId,
+		NodeUrl:   server.NodeUrl(),
+		FaucetUrl: server.FaucetUrl(),
+	}, options...)
+	require.NoError(t, err)
+	return client
+}
+
+func TestServer_Transfer(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client := newServerClient(t, server)
+
+	sender, err := aptos.NewEd25519Account()
+	require.NoError(t, err)
+	receiver, err := aptos.NewEd25519Account()
+	require.NoError(t, err)
+	require.NoError(t, client.Fund(sender.Address, 100_000_000))
+
+	// Simulate, then submit and wait, as a program would against a node
+	payload := transferPayload(t, receiver.Address, 1_000)
+	rawTxn, err := client.BuildTransaction(sender.Address, payload)
+	require.NoError(t, err)
+	simulated, err := client.SimulateTransaction(rawTxn, sender)
+	require.NoError(t, err)
+	assert.True(t, simulated[0].Success)
+	assert.Equal(t, DefaultConfig().GasUsed, simulated[0].GasUsed)
+
+	submitted, err := client.BuildSignAndSubmitTransaction(sender, payload)
+	require.NoError(t, err)
+	txn, err := client.WaitForTransaction(submitted.Hash)
+	require.NoError(t, err)
+	assert.True(t, txn.Success)
+	assert.Equal(t, sender.Address, *txn.Sender)
+	assert.Len(t, txn.Events, 3)
+	byHash, err := client.TransactionByHash(submitted.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, api.TransactionVariantUser, byHash.Type)
+
+	balance, err := client.AccountAPTBalance(receiver.Address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1_000), balance)
+	info, err := client.Account(sender.Address)
+	require.NoError(t, err)
+	sequenceNumber, err := info.SequenceNumber()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), sequenceNumber)
+
+	// Genesis, the mint and the transfer are in the ledger
+	txns, err := client.Transactions(nil, nil)
+	require.NoError(t, err)
+	require.Len(t, txns, 3)
+	assert.Equal(t, api.TransactionVariantGenesis, txns[0].Type)
+	assert.Equal(t, submitted.Hash, txns[2].Hash())
+	sent, err := client.AccountTransactions(sender.Address, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, sent, 1)
+}
+
+func TestServer_Routes(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client := newServerClient(t, server)
+	account, err := aptos.NewEd25519Account()
+	require.NoError(t, err)
+	require.NoError(t, server.Ledger.Fund(account.Address, 1_000))
+
+	nodeInfo, err := client.Info()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig().ChainId, nodeInfo.ChainId)
+	health, err := client.NodeAPIHealthCheck()
+	require.NoError(t, err)
+	assert.Equal(t, "aptos-node:ok", health.Message)
+	gas, err := client.EstimateGasPrice()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig().GasUnitPrice, gas.GasEstimate)
+
+	server.Ledger.SetResource(account.Address, "0xcafe::counter::Counter<0x1::aptos_coin::AptosCoin>", map[string]any{"value": "7"})
+	resource, err := client.AccountResource(account.Address, "0xcafe::counter::Counter<0x1::aptos_coin::AptosCoin>")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"value": "7"}, resource["data"])
+	resources, err := client.AccountResources(account.Address)
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+
+	// Responses carry the ledger headers
+	_, responseInfo, err := client.AccountWithResponseInfo(account.Address)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig().ChainId, responseInfo.ChainId)
+	assert.Equal(t, uint64(0), responseInfo.LedgerVersion)
+
+	// Errors decode to the same sentinels as a node's
+	stranger, err := aptos.NewEd25519Account()
+	require.NoError(t, err)
+	_, err = client.Account(stranger.Address)
+	require.ErrorIs(t, err, aptos.ErrAccountNotFound)
+	_, err = client.TransactionByVersion(10)
+	require.ErrorIs(t, err, aptos.ErrVersionNotFound)
+	_, err = client.BuildSignAndSubmitTransaction(account, transferPayload(t, stranger.Address, 1), aptos.SequenceNumber(1))
+	require.ErrorIs(t, err, aptos.ErrSequenceNumberTooNew)
+
+	// Routes that aren't served are disabled, like on a node, rather than not found
+	_, err = client.BlockByHeight(1, false)
+	var httpErr *aptos.HttpError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotImplemented, httpErr.StatusCode)
+	require.NotNil(t, httpErr.ApiError)
+	assert.Equal(t, api.ErrorCodeApiDisabled, httpErr.ApiError.ErrorCode)
+	response, err := http.Post(server.NodeUrl()+"/accounts/0x1", "application/json", nil)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusNotImplemented, response.StatusCode)
+}
+
+func TestServer_View(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client := newServerClient(t, server)
+
+	server.Ledger.SetViewResult("0xcafe::counter::value", "7")
+	payload := &aptos.ViewPayload{
+		Module:   aptos.ModuleId{Address: aptos.AccountOne, Name: "counter"},
+		Function: "value",
+		ArgTypes: []aptos.TypeTag{},
+		Args:     [][]byte{},
+	}
+	require.NoError(t, payload.Module.Address.ParseStringRelaxed("0xcafe"))
+	values, err := client.View(payload)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"7"}, values)
+
+	// BCS results are disabled, rather than answered in JSON
+	_, err = client.ViewRaw(payload)
+	var httpErr *aptos.HttpError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotImplemented, httpErr.StatusCode)
+	require.NotNil(t, httpErr.ApiError)
+	assert.Equal(t, api.ErrorCodeApiDisabled, httpErr.ApiError.ErrorCode)
+}
+
+func TestServer_Faults(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client := newServerClient(t, server, aptos.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	// Retried until the fault runs out
+	server.InjectFault(Fault{
+		Method: http.MethodGet,
+		Path:   "/estimate_gas_price",
+		Error:  api.Error{Message: "busy", ErrorCode: api.ErrorCodeInternalError},
+		Times:  2,
+	})
+	_, err := client.EstimateGasPrice()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), server.Requests())
+
+	// Failing every time
+	server.InjectFault(Fault{
+		Path:   "/accounts",
+		Status: http.StatusNotFound,
+		Error:  api.Error{Message: "gone", ErrorCode: api.ErrorCodeAccountNotFound},
+	})
+	_, err = client.Account(aptos.AccountOne)
+	require.ErrorIs(t, err, aptos.ErrAccountNotFound)
+	var httpErr *aptos.HttpError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, "gone", httpErr.ApiError.Message)
+	server.ClearFaults()
+	_, err = client.AccountResources(aptos.AccountOne)
+	require.NoError(t, err)
+
+	// Latency
+	server.SetLatency(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.InfoCtx(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
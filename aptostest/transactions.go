@@ -0,0 +1,1205 @@
+package aptostest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+)
+
+// VM status codes of validation failures without a constant in [api]
+const (
+	vmErrorCodeSendingAccountDoesNotExist = uint64(7)
+	vmErrorCodeBadChainId                 = uint64(23)
+)
+
+// executedSuccessfully is the VM status of a successful transaction
+const executedSuccessfully = "Executed successfully"
+
+// rejection is a transaction rejected on submission, as the node rejects it with a vm_error.  It matches the same
+// sentinel error as the node's response, if there is one.
+type rejection struct {
+	vmStatus    string // e.g. SEQUENCE_NUMBER_TOO_OLD
+	vmErrorCode uint64
+	sentinel    error
+}
+
+// Error returns the message the node would
+//
+// Implements:
+//   - [error]
+func (r *rejection) Error() string {
+	return "Invalid transaction: Type: Validation Code: " + r.vmStatus
+}
+
+// Unwrap returns the sentinel error for the validation failure, nil if there is none
+func (r *rejection) Unwrap() error {
+	return r.sentinel
+}
+
+// transfer is an APT transfer made by a transaction
+type transfer struct {
+	receiver aptos.AccountAddress
+	amount   uint64
+}
+
+// execution is the outcome of a transaction, computed before it's committed
+type execution struct {
+	rawTxn       *aptos.RawTransaction
+	payer        aptos.AccountAddress // Sender, or fee payer, paying the gas
+	maxGasAmount uint64
+	gasUsed      uint64
+	vmStatus     string
+	transfer     *transfer // Transfer made, nil if the transaction failed
+	payload      *api.TransactionPayload
+	replayNonce  *uint64 // Replay nonce of an orderless transaction, nil if it has a sequence number
+}
+
+// region Transactions
+
+// TransactionByHash returns a committed transaction, there are no pending transactions
+func (c *Client) TransactionByHash(txnHash string) (*api.Transaction, error) {
+	return c.TransactionByHashCtx(context.Background(), txnHash)
+}
+
+// TransactionByHashCtx is [Client.TransactionByHash]
+func (c *Client) TransactionByHashCtx(_ context.Context, txnHash string) (*api.Transaction, error) {
+	txn, err := c.transactionByHash(txnHash)
+	if err != nil {
+		return nil, err
+	}
+	return &api.Transaction{Type: txn.Type, Inner: txn.Inner}, nil
+}
+
+// WaitTransactionByHash is [Client.TransactionByHash], as transactions are committed once submitted
+func (c *Client) WaitTransactionByHash(txnHash string) (*api.Transaction, error) {
+	return c.WaitTransactionByHashCtx(context.Background(), txnHash)
+}
+
+// WaitTransactionByHashCtx is [Client.WaitTransactionByHash]
+func (c *Client) WaitTransactionByHashCtx(ctx context.Context, txnHash string) (*api.Transaction, error) {
+	return c.TransactionByHashCtx(ctx, txnHash)
+}
+
+// TransactionByVersion returns the transaction at version
+func (c *Client) TransactionByVersion(version uint64) (*api.CommittedTransaction, error) {
+	return c.TransactionByVersionCtx(context.Background(), version)
+}
+
+// TransactionByVersionCtx is [Client.TransactionByVersion]
+func (c *Client) TransactionByVersionCtx(_ context.Context, version uint64) (*api.CommittedTransaction, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if version > c.ledgerVersion() {
+		return nil, fmt.Errorf("transaction at version %d: %w", version, aptos.ErrVersionNotFound)
+	}
+	return c.transactions[version], nil
+}
+
+// PollForTransaction returns the user transaction with the hash, without waiting, as transactions are committed once
+// submitted.  Options are ignored.
+func (c *Client) PollForTransaction(hash string, options ...any) (*api.UserTransaction, error) {
+	return c.PollForTransactionCtx(context.Background(), hash, options...)
+}
+
+// PollForTransactionCtx is [Client.PollForTransaction]
+func (c *Client) PollForTransactionCtx(_ context.Context, hash string, _ ...any) (*api.UserTransaction, error) {
+	txn, err := c.transactionByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return txn.UserTransaction()
+}
+
+// PollForTransactions checks all the transactions were committed, without waiting.  Options are ignored.
+func (c *Client) PollForTransactions(txnHashes []string, options ...any) error {
+	return c.PollForTransactionsCtx(context.Background(), txnHashes, options...)
+}
+
+// PollForTransactionsCtx is [Client.PollForTransactions]
+func (c *Client) PollForTransactionsCtx(_ context.Context, txnHashes []string, _ ...any) error {
+	for _, hash := range txnHashes {
+		_, err := c.transactionByHash(hash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WaitForTransaction is [Client.PollForTransaction]
+func (c *Client) WaitForTransaction(txnHash string, options ...any) (*api.UserTransaction, error) {
+	return c.PollForTransaction(txnHash, options...)
+}
+
+// WaitForTransactionCtx is [Client.PollForTransaction]
+func (c *Client) WaitForTransactionCtx(ctx context.Context, txnHash string, options ...any) (*api.UserTransaction, error) {
+	return c.PollForTransactionCtx(ctx, txnHash, options...)
+}
+
+// Transactions returns up to limit transactions from version start, or the latest ones if start is nil.  limit
+// defaults to 25.
+func (c *Client) Transactions(start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	return c.TransactionsCtx(context.Background(), start, limit)
+}
+
+// TransactionsCtx is [Client.Transactions]
+func (c *Client) TransactionsCtx(_ context.Context, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return page(c.transactions, start, limit, func(txn *api.CommittedTransaction) uint64 {
+		return txn.Version()
+	})
+}
+
+// TransactionsIter iterates over the transactions from version start
+func (c *Client) TransactionsIter(start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return c.TransactionsIterCtx(context.Background(), start)
+}
+
+// TransactionsIterCtx is [Client.TransactionsIter]
+func (c *Client) TransactionsIterCtx(ctx context.Context, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return func(yield func(*api.CommittedTransaction, error) bool) {
+		limit := uint64(math.MaxUint64)
+		txns, err := c.TransactionsCtx(ctx, &start, &limit)
+		yieldAll(yield, txns, err)
+	}
+}
+
+// AccountTransactions returns up to limit transactions sent by address from sequence number start, or the latest ones
+// if start is nil.  limit defaults to 25.
+func (c *Client) AccountTransactions(address aptos.AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	return c.AccountTransactionsCtx(context.Background(), address, start, limit)
+}
+
+// AccountTransactionsCtx is [Client.AccountTransactions]
+func (c *Client) AccountTransactionsCtx(_ context.Context, address aptos.AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if _, ok := c.accounts[address]; !ok {
+		return nil, fmt.Errorf("account %s: %w", address.String(), aptos.ErrAccountNotFound)
+	}
+	sent := make([]*api.CommittedTransaction, 0)
+	for _, txn := range c.transactions {
+		if userTxn, ok := txn.Inner.(*api.UserTransaction); ok && *userTxn.Sender == address {
+			sent = append(sent, txn)
+		}
+	}
+	if len(sent) == 0 {
+		return sent, nil
+	}
+	return page(sent, start, limit, func(txn *api.CommittedTransaction) uint64 {
+		userTxn, _ := txn.UserTransaction()
+		return userTxn.SequenceNumber
+	})
+}
+
+// AccountTransactionsIter iterates over the transactions sent by address from sequence number start
+func (c *Client) AccountTransactionsIter(address aptos.AccountAddress, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return c.AccountTransactionsIterCtx(context.Background(), address, start)
+}
+
+// AccountTransactionsIterCtx is [Client.AccountTransactionsIter]
+func (c *Client) AccountTransactionsIterCtx(ctx context.Context, address aptos.AccountAddress, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return func(yield func(*api.CommittedTransaction, error) bool) {
+		limit := uint64(math.MaxUint64)
+		txns, err := c.AccountTransactionsCtx(ctx, address, &start, &limit)
+		yieldAll(yield, txns, err)
+	}
+}
+
+// transactionByHash returns the committed transaction with the hash
+func (c *Client) transactionByHash(txnHash string) (*api.CommittedTransaction, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	version, ok := c.hashes[strings.ToLower(txnHash)]
+	if !ok {
+		return nil, fmt.Errorf("transaction %s: %w", txnHash, aptos.ErrTransactionNotFound)
+	}
+	return c.transactions[version], nil
+}
+
+// page returns up to limit txns from the one numbered start, or the last ones if start is nil.  txns are in ascending
+// order of number, without gaps, and there is at least one.
+func page(txns []*api.CommittedTransaction, start *uint64, limit *uint64, number func(*api.CommittedTransaction) uint64) ([]*api.CommittedTransaction, error) {
+	count := uint64(25)
+	if limit != nil {
+		count = *limit
+	}
+	first := number(txns[0])
+	last := number(txns[len(txns)-1])
+	var from uint64
+	switch {
+	case start == nil:
+		from = uint64(len(txns)) - min(count, uint64(len(txns)))
+	case *start > last:
+		return nil, fmt.Errorf("start %d is after %d: %w", *start, last, aptos.ErrVersionNotFound)
+	default:
+		from = max(*start, first) - first
+	}
+	to := from + min(count, uint64(len(txns))-from)
+	return slices.Clone(txns[from:to]), nil
+}
+
+// yieldAll yields each of txns, or err
+func yieldAll(yield func(*api.CommittedTransaction, error) bool, txns []*api.CommittedTransaction, err error) {
+	if err != nil {
+		yield(nil, err)
+		return
+	}
+	for _, txn := range txns {
+		if !yield(txn, nil) {
+			return
+		}
+	}
+}
+
+// endregion
+
+// region Events
+
+// EventsByHandle is unsupported, transactions only emit module events
+func (c *Client) EventsByHandle(account aptos.AccountAddress, eventHandle string, fieldName string, start *uint64, limit *uint64) ([]*api.Event, error) {
+	return c.EventsByHandleCtx(context.Background(), account, eventHandle, fieldName, start, limit)
+}
+
+// EventsByHandleCtx is unsupported, transactions only emit module events
+func (c *Client) EventsByHandleCtx(context.Context, aptos.AccountAddress, string, string, *uint64, *uint64) ([]*api.Event, error) {
+	return nil, unsupported("EventsByHandle")
+}
+
+// EventsByCreationNumber is unsupported, transactions only emit module events
+func (c *Client) EventsByCreationNumber(account aptos.AccountAddress, creationNumber string, start *uint64, limit *uint64) ([]*api.Event, error) {
+	return c.EventsByCreationNumberCtx(context.Background(), account, creationNumber, start, limit)
+}
+
+// EventsByCreationNumberCtx is unsupported, transactions only emit module events
+func (c *Client) EventsByCreationNumberCtx(context.Context, aptos.AccountAddress, string, *uint64, *uint64) ([]*api.Event, error) {
+	return nil, unsupported("EventsByCreationNumber")
+}
+
+// EventsByHandleIter is unsupported, transactions only emit module events
+func (c *Client) EventsByHandleIter(account aptos.AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error] {
+	return c.EventsByHandleIterCtx(context.Background(), account, eventHandle, fieldName, start)
+}
+
+// EventsByHandleIterCtx is unsupported, transactions only emit module events
+func (c *Client) EventsByHandleIterCtx(context.Context, aptos.AccountAddress, string, string, uint64) iter.Seq2[*api.Event, error] {
+	return failing[*api.Event](unsupported("EventsByHandleIter"))
+}
+
+// EventsByCreationNumberIter is unsupported, transactions only emit module events
+func (c *Client) EventsByCreationNumberIter(account aptos.AccountAddress, creationNumber string, start uint64) iter.Seq2[*api.Event, error] {
+	return c.EventsByCreationNumberIterCtx(context.Background(), account, creationNumber, start)
+}
+
+// EventsByCreationNumberIterCtx is unsupported, transactions only emit module events
+func (c *Client) EventsByCreationNumberIterCtx(context.Context, aptos.AccountAddress, string, uint64) iter.Seq2[*api.Event, error] {
+	return failing[*api.Event](unsupported("EventsByCreationNumberIter"))
+}
+
+// endregion
+
+// region Building transactions
+
+// buildOptions are the options of BuildTransaction and BuildTransactionMultiAgent
+type buildOptions struct {
+	maxGasAmount      uint64
+	haveMaxGasAmount  bool
+	gasUnitPrice      uint64
+	expirationSeconds uint64
+	haveExpiration    bool
+	sequenceNumber    *uint64
+	orderless         bool
+	replayNonce       *uint64
+	chainId           uint8
+	simulateMaxGas    *aptos.SimulateMaxGas
+	feePayer          *aptos.AccountAddress
+	additionalSigners []aptos.AccountAddress
+}
+
+// BuildTransaction builds a transaction with the sender's sequence number, the configured chain ID and gas unit price.
+// It accepts the same options as [aptos.NodeClient.BuildTransaction].
+func (c *Client) BuildTransaction(sender aptos.AccountAddress, payload aptos.TransactionPayload, options ...any) (*aptos.RawTransaction, error) {
+	return c.BuildTransactionCtx(context.Background(), sender, payload, options...)
+}
+
+// BuildTransactionCtx is [Client.BuildTransaction]
+func (c *Client) BuildTransactionCtx(ctx context.Context, sender aptos.AccountAddress, payload aptos.TransactionPayload, options ...any) (*aptos.RawTransaction, error) {
+	opts, err := c.buildOptions("BuildTransaction", false, options)
+	if err != nil {
+		return nil, err
+	}
+	rawTxn, err := c.buildTransaction(sender, payload, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.simulateMaxGas != nil {
+		err = aptos.ApplySimulatedMaxGas(rawTxn, *opts.simulateMaxGas, opts.haveMaxGasAmount, func(signer aptos.TransactionSigner) ([]*api.UserTransaction, error) {
+			return c.SimulateTransactionCtx(ctx, rawTxn, signer, aptos.EstimateMaxGasAmount(true))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rawTxn, nil
+}
+
+// BuildTransactionMultiAgent builds a fee payer or multi-agent transaction like [Client.BuildTransaction].  It accepts
+// the same options as [aptos.NodeClient.BuildTransactionMultiAgent].
+func (c *Client) BuildTransactionMultiAgent(sender aptos.AccountAddress, payload aptos.TransactionPayload, options ...any) (*aptos.RawTransactionWithData, error) {
+	return c.BuildTransactionMultiAgentCtx(context.Background(), sender, payload, options...)
+}
+
+// BuildTransactionMultiAgentCtx is [Client.BuildTransactionMultiAgent]
+func (c *Client) BuildTransactionMultiAgentCtx(ctx context.Context, sender aptos.AccountAddress, payload aptos.TransactionPayload, options ...any) (*aptos.RawTransactionWithData, error) {
+	opts, err := c.buildOptions("BuildTransactionMultiAgent", true, options)
+	if err != nil {
+		return nil, err
+	}
+	rawTxn, err := c.buildTransaction(sender, payload, opts)
+	if err != nil {
+		return nil, err
+	}
+	var rawTxnWithData *aptos.RawTransactionWithData
+	if opts.feePayer != nil {
+		rawTxnWithData = &aptos.RawTransactionWithData{
+			Variant: aptos.MultiAgentWithFeePayerRawTransactionWithDataVariant,
+			Inner: &aptos.MultiAgentWithFeePayerRawTransactionWithData{
+				RawTxn:           rawTxn,
+				FeePayer:         opts.feePayer,
+				SecondarySigners: opts.additionalSigners,
+			},
+		}
+	} else {
+		rawTxnWithData = &aptos.RawTransactionWithData{
+			Variant: aptos.MultiAgentRawTransactionWithDataVariant,
+			Inner: &aptos.MultiAgentRawTransactionWithData{
+				RawTxn:           rawTxn,
+				SecondarySigners: opts.additionalSigners,
+			},
+		}
+	}
+	if opts.simulateMaxGas != nil {
+		err = aptos.ApplySimulatedMaxGas(rawTxn, *opts.simulateMaxGas, opts.haveMaxGasAmount, func(signer aptos.TransactionSigner) ([]*api.UserTransaction, error) {
+			return c.SimulateTransactionMultiAgentCtx(ctx, rawTxnWithData, signer, aptos.EstimateMaxGasAmount(true))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rawTxnWithData, nil
+}
+
+// BuildSignAndSubmitTransaction builds a transaction like [Client.BuildTransaction], signs it, and submits it
+func (c *Client) BuildSignAndSubmitTransaction(sender aptos.TransactionSigner, payload aptos.TransactionPayload, options ...any) (*api.SubmitTransactionResponse, error) {
+	return c.BuildSignAndSubmitTransactionCtx(context.Background(), sender, payload, options...)
+}
+
+// BuildSignAndSubmitTransactionCtx is [Client.BuildSignAndSubmitTransaction]
+func (c *Client) BuildSignAndSubmitTransactionCtx(ctx context.Context, sender aptos.TransactionSigner, payload aptos.TransactionPayload, options ...any) (*api.SubmitTransactionResponse, error) {
+	// A SimulateMaxGas without a Signer simulates as the sender
+	for i, option := range options {
+		if simulate, ok := option.(aptos.SimulateMaxGas); ok && simulate.Signer == nil {
+			simulate.Signer = sender
+			options = slices.Clone(options)
+			options[i] = simulate
+			break
+		}
+	}
+	rawTxn, err := c.BuildTransactionCtx(ctx, sender.AccountAddress(), payload, options...)
+	if err != nil {
+		return nil, err
+	}
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	if err != nil {
+		return nil, err
+	}
+	return c.SubmitTransactionCtx(ctx, signedTxn)
+}
+
+// buildOptions parses the options of a build method, the multi-agent ones only if multiAgent
+func (c *Client) buildOptions(method string, multiAgent bool, options []any) (*buildOptions, error) {
+	opts := &buildOptions{
+		maxGasAmount:      aptos.DefaultMaxGasAmount,
+		gasUnitPrice:      c.config.GasUnitPrice,
+		expirationSeconds: aptos.DefaultExpirationSeconds,
+		chainId:           c.config.ChainId,
+	}
+	for i, option := range options {
+		switch value := option.(type) {
+		case aptos.MaxGasAmount:
+			opts.maxGasAmount = uint64(value)
+			opts.haveMaxGasAmount = true
+		case aptos.GasUnitPrice:
+			opts.gasUnitPrice = uint64(value)
+		case aptos.ExpirationSeconds:
+			opts.expirationSeconds = uint64(value)
+			opts.haveExpiration = true
+		case aptos.Orderless:
+			opts.orderless = bool(value)
+		case aptos.ReplayNonce:
+			replayNonce := uint64(value)
+			opts.replayNonce = &replayNonce
+		case aptos.SequenceNumber:
+			sequenceNumber := uint64(value)
+			opts.sequenceNumber = &sequenceNumber
+		case aptos.ChainIdOption:
+			opts.chainId = uint8(value)
+		case aptos.SimulateMaxGas:
+			opts.simulateMaxGas = &value
+		case aptos.FeePayer:
+			if !multiAgent {
+				return nil, fmt.Errorf("%s arg [%d] unknown option type %T", method, i+4, option)
+			}
+			opts.feePayer = value
+		case aptos.AdditionalSigners:
+			if !multiAgent {
+				return nil, fmt.Errorf("%s arg [%d] unknown option type %T", method, i+4, option)
+			}
+			opts.additionalSigners = value
+		default:
+			return nil, fmt.Errorf("%s arg [%d] unknown option type %T", method, i+4, option)
+		}
+	}
+	return opts, nil
+}
+
+// buildTransaction builds a transaction, looking up the sender's sequence number unless it's given or the transaction
+// is orderless
+func (c *Client) buildTransaction(sender aptos.AccountAddress, payload aptos.TransactionPayload, opts *buildOptions) (*aptos.RawTransaction, error) {
+	if opts.orderless && opts.replayNonce == nil {
+		replayNonce, err := aptos.NewReplayNonce()
+		if err != nil {
+			return nil, err
+		}
+		opts.replayNonce = &replayNonce
+	}
+	if opts.replayNonce != nil {
+		var err error
+		payload, err = aptos.OrderlessPayload(payload, *opts.replayNonce)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if aptos.PayloadReplayNonce(&payload) != nil {
+		if !opts.haveExpiration {
+			opts.expirationSeconds = aptos.MaxOrderlessExpirationSeconds
+		} else if opts.expirationSeconds > aptos.MaxOrderlessExpirationSeconds {
+			return nil, fmt.Errorf("orderless transactions must expire within %d seconds, not %d", aptos.MaxOrderlessExpirationSeconds, opts.expirationSeconds)
+		}
+		if opts.sequenceNumber == nil {
+			sequenceNumber := aptos.OrderlessSequenceNumber
+			opts.sequenceNumber = &sequenceNumber
+		}
+	}
+	if opts.sequenceNumber == nil {
+		c.mutex.RLock()
+		acct, ok := c.accounts[sender]
+		c.mutex.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("account %s: %w", sender.String(), aptos.ErrAccountNotFound)
+		}
+		opts.sequenceNumber = &acct.sequenceNumber
+	}
+	return &aptos.RawTransaction{
+		Sender:                     sender,
+		SequenceNumber:             *opts.sequenceNumber,
+		Payload:                    payload,
+		MaxGasAmount:               opts.maxGasAmount,
+		GasUnitPrice:               opts.gasUnitPrice,
+		ExpirationTimestampSeconds: uint64(time.Now().Unix()) + opts.expirationSeconds, //nolint:gosec // The clock is after 1970
+		ChainId:                    opts.chainId,
+	}, nil
+}
+
+// endregion
+
+// region Submitting transactions
+
+// SubmitTransaction verifies and commits a transaction.  Single signer transactions are verified with
+// [aptos.SignedTransaction.Verify], multi-agent and fee payer transactions against their [aptos.RawTransactionWithData],
+// and each signer's key must match the authentication key of their account, if it exists.
+//
+// Like a node, transactions with a bad signature, sequence number, chain ID, or expiration, or whose payer can't afford
+// the max gas, are rejected with the matching error e.g. [aptos.ErrSequenceNumberTooOld].  Sequence numbers ahead of
+// the account's are rejected with [aptos.ErrSequenceNumberTooNew], rather than waiting in a mempool.  Orderless
+// transactions are rejected if they expire more than [aptos.MaxOrderlessExpirationSeconds] away, or reuse a replay
+// nonce of the sender that hasn't expired.
+//
+// Transactions calling 0x1::aptos_account::transfer, or 0x1::aptos_account::transfer_coins and 0x1::coin::transfer
+// with APT, are committed, emitting 0x1::coin::CoinWithdraw, 0x1::coin::CoinDeposit and
+// 0x1::transaction_fee::FeeStatement module events.  They fail, still charging gas, if the sender can't afford the
+// transfer.  Transactions with any other payload are rejected with [ErrUnsupported].
+func (c *Client) SubmitTransaction(signedTxn *aptos.SignedTransaction) (*api.SubmitTransactionResponse, error) {
+	return c.SubmitTransactionCtx(context.Background(), signedTxn)
+}
+
+// SubmitTransactionCtx is [Client.SubmitTransaction]
+func (c *Client) SubmitTransactionCtx(_ context.Context, signedTxn *aptos.SignedTransaction) (*api.SubmitTransactionResponse, error) {
+	if signedTxn == nil || signedTxn.Transaction == nil || signedTxn.Authenticator == nil {
+		return nil, fmt.Errorf("incomplete signed transaction: %w", aptos.ErrInvalidInput)
+	}
+	hash, err := signedTxn.Hash()
+	if err != nil {
+		return nil, err
+	}
+	feePayer, err := verifySignatures(signedTxn)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	err = c.checkAuthKeys(signedTxn.Transaction.Sender, signedTxn.Authenticator)
+	if err != nil {
+		return nil, err
+	}
+	exec, err := c.execute(signedTxn.Transaction, feePayer, false, false)
+	if err != nil {
+		return nil, err
+	}
+	c.commit(exec, hash)
+
+	rawTxn := signedTxn.Transaction
+	sender := rawTxn.Sender
+	return &api.SubmitTransactionResponse{
+		Hash:                    hash,
+		Sender:                  &sender,
+		SequenceNumber:          rawTxn.SequenceNumber,
+		ReplayProtectionNonce:   exec.replayNonce,
+		MaxGasAmount:            rawTxn.MaxGasAmount,
+		GasUnitPrice:            rawTxn.GasUnitPrice,
+		ExpirationTimestampSecs: rawTxn.ExpirationTimestampSeconds,
+		Payload:                 exec.payload,
+	}, nil
+}
+
+// BatchSubmitTransaction submits each transaction with [Client.SubmitTransaction], returning the failures
+func (c *Client) BatchSubmitTransaction(signedTxns []*aptos.SignedTransaction) (*api.BatchSubmitTransactionResponse, error) {
+	return c.BatchSubmitTransactionCtx(context.Background(), signedTxns)
+}
+
+// BatchSubmitTransactionCtx is [Client.BatchSubmitTransaction]
+func (c *Client) BatchSubmitTransactionCtx(ctx context.Context, signedTxns []*aptos.SignedTransaction) (*api.BatchSubmitTransactionResponse, error) {
+	response := &api.BatchSubmitTransactionResponse{TransactionFailures: []api.BatchSubmitTransactionFailure{}}
+	for i, signedTxn := range signedTxns {
+		_, err := c.SubmitTransactionCtx(ctx, signedTxn)
+		if err == nil {
+			continue
+		}
+		apiError := api.Error{Message: err.Error(), ErrorCode: api.ErrorCodeInvalidInput}
+		var rejected *rejection
+		if errors.As(err, &rejected) {
+			apiError.ErrorCode = api.ErrorCodeVmError
+			apiError.VmErrorCode = rejected.vmErrorCode
+		}
+		index, err := util.IntToU32(i)
+		if err != nil {
+			return nil, err
+		}
+		response.TransactionFailures = append(response.TransactionFailures, api.BatchSubmitTransactionFailure{
+			Error:            apiError,
+			TransactionIndex: index,
+		})
+	}
+	return response, nil
+}
+
+// SimulateTransaction returns the outcome of a transaction without committing it, or signing it.  Like
+// [aptos.NodeClient.SimulateTransaction], it ignores unknown options, and [aptos.EstimateMaxGasAmount] sets the max gas
+// amount to what the payer can afford.
+func (c *Client) SimulateTransaction(rawTxn *aptos.RawTransaction, sender aptos.TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
+	return c.SimulateTransactionCtx(context.Background(), rawTxn, sender, options...)
+}
+
+// SimulateTransactionCtx is [Client.SimulateTransaction]
+func (c *Client) SimulateTransactionCtx(_ context.Context, rawTxn *aptos.RawTransaction, _ aptos.TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
+	return c.simulate(rawTxn, nil, options)
+}
+
+// SimulateTransactionMultiAgent is [Client.SimulateTransaction] for fee payer and multi-agent transactions.  The
+// [aptos.FeePayer] option overrides the fee payer of rawTxn.
+func (c *Client) SimulateTransactionMultiAgent(rawTxn *aptos.RawTransactionWithData, sender aptos.TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
+	return c.SimulateTransactionMultiAgentCtx(context.Background(), rawTxn, sender, options...)
+}
+
+// SimulateTransactionMultiAgentCtx is [Client.SimulateTransactionMultiAgent]
+func (c *Client) SimulateTransactionMultiAgentCtx(_ context.Context, rawTxn *aptos.RawTransactionWithData, _ aptos.TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
+	switch inner := rawTxn.Inner.(type) {
+	case *aptos.MultiAgentRawTransactionWithData:
+		return c.simulate(inner.RawTxn, nil, options)
+	case *aptos.MultiAgentWithFeePayerRawTransactionWithData:
+		return c.simulate(inner.RawTxn, inner.FeePayer, options)
+	default:
+		return nil, fmt.Errorf("unknown raw transaction with data %T: %w", rawTxn.Inner, aptos.ErrInvalidInput)
+	}
+}
+
+// simulate executes rawTxn without committing it
+func (c *Client) simulate(rawTxn *aptos.RawTransaction, feePayer *aptos.AccountAddress, options []any) ([]*api.UserTransaction, error) {
+	estimateMaxGas := false
+	for _, option := range options {
+		switch value := option.(type) {
+		case aptos.FeePayer:
+			feePayer = value
+		case aptos.EstimateMaxGasAmount:
+			estimateMaxGas = bool(value)
+		default:
+			// Silently ignore other options, as the node client does, the gas unit price is fixed
+		}
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	exec, err := c.execute(rawTxn, feePayer, true, estimateMaxGas)
+	if err != nil {
+		return nil, err
+	}
+	return []*api.UserTransaction{exec.userTransaction(uint64(len(c.transactions)), "", c.now())}, nil
+}
+
+// verifySignatures verifies the signatures of a transaction, returning its fee payer if it has one
+func verifySignatures(signedTxn *aptos.SignedTransaction) (*aptos.AccountAddress, error) {
+	invalid := &rejection{"INVALID_SIGNATURE", api.VmErrorCodeInvalidSignature, aptos.ErrInvalidSignature}
+	var withData *aptos.RawTransactionWithData
+	var feePayer *aptos.AccountAddress
+	switch auth := signedTxn.Authenticator.Auth.(type) {
+	case *aptos.MultiAgentTransactionAuthenticator:
+		withData = &aptos.RawTransactionWithData{
+			Variant: aptos.MultiAgentRawTransactionWithDataVariant,
+			Inner: &aptos.MultiAgentRawTransactionWithData{
+				RawTxn:           signedTxn.Transaction,
+				SecondarySigners: auth.SecondarySignerAddresses,
+			},
+		}
+	case *aptos.FeePayerTransactionAuthenticator:
+		feePayer = auth.FeePayer
+		withData = &aptos.RawTransactionWithData{
+			Variant: aptos.MultiAgentWithFeePayerRawTransactionWithDataVariant,
+			Inner: &aptos.MultiAgentWithFeePayerRawTransactionWithData{
+				RawTxn:           signedTxn.Transaction,
+				SecondarySigners: auth.SecondarySignerAddresses,
+				FeePayer:         auth.FeePayer,
+			},
+		}
+	default:
+		if signedTxn.Verify() != nil {
+			return nil, invalid
+		}
+		return nil, nil
+	}
+
+	message, err := withData.SigningMessage()
+	if err != nil {
+		return nil, err
+	}
+	if !signedTxn.Authenticator.Verify(message) {
+		return nil, invalid
+	}
+	return feePayer, nil
+}
+
+// checkAuthKeys checks each signer's public key matches the authentication key of their account.  Accounts that don't
+// exist yet accept any key, as a fee payer transaction may create the sender.
+func (c *Client) checkAuthKeys(sender aptos.AccountAddress, auth *aptos.TransactionAuthenticator) error {
+	addresses := []aptos.AccountAddress{sender}
+	signers := make([]*crypto.AccountAuthenticator, 0, 1)
+	switch inner := auth.Auth.(type) {
+	case *aptos.Ed25519TransactionAuthenticator:
+		signers = append(signers, inner.Sender)
+	case *aptos.MultiEd25519TransactionAuthenticator:
+		signers = append(signers, inner.Sender)
+	case *aptos.SingleSenderTransactionAuthenticator:
+		signers = append(signers, inner.Sender)
+	case *aptos.MultiAgentTransactionAuthenticator:
+		addresses = append(addresses, inner.SecondarySignerAddresses...)
+		signers = append(signers, inner.Sender)
+		for i := range inner.SecondarySigners {
+			signers = append(signers, &inner.SecondarySigners[i])
+		}
+	case *aptos.FeePayerTransactionAuthenticator:
+		addresses = append(addresses, inner.SecondarySignerAddresses...)
+		addresses = append(addresses, *inner.FeePayer)
+		signers = append(signers, inner.Sender)
+		for i := range inner.SecondarySigners {
+			signers = append(signers, &inner.SecondarySigners[i])
+		}
+		signers = append(signers, inner.FeePayerAuthenticator)
+	default:
+		return fmt.Errorf("unknown authenticator %T: %w", auth.Auth, aptos.ErrInvalidInput)
+	}
+	if len(addresses) != len(signers) {
+		return &rejection{"INVALID_SIGNATURE", api.VmErrorCodeInvalidSignature, aptos.ErrInvalidSignature}
+	}
+
+	for i, address := range addresses {
+		acct, ok := c.accounts[address]
+		if ok && *signers[i].PubKey().AuthKey() != acct.authKey {
+			return &rejection{"INVALID_AUTH_KEY", api.VmErrorCodeInvalidAuthKey, nil}
+		}
+	}
+	return nil
+}
+
+// execute validates rawTxn and computes its outcome, without changing the ledger.  With estimateMaxGas, the max gas
+// amount is what the payer can afford.  A simulation may leave the fee payer as 0x0, which then pays for nothing.
+func (c *Client) execute(rawTxn *aptos.RawTransaction, feePayer *aptos.AccountAddress, simulation bool, estimateMaxGas bool) (*execution, error) {
+	replayNonce := aptos.PayloadReplayNonce(&rawTxn.Payload)
+	sender, ok := c.accounts[rawTxn.Sender]
+	if !ok {
+		// A fee payer may create the sender with its first transaction
+		if feePayer == nil || (replayNonce == nil && rawTxn.SequenceNumber != 0) {
+			return nil, &rejection{"SENDING_ACCOUNT_DOES_NOT_EXIST", vmErrorCodeSendingAccountDoesNotExist, aptos.ErrAccountNotFound}
+		}
+		sender = &account{}
+	}
+	now := uint64(time.Now().Unix()) //nolint:gosec // The clock is after 1970
+	switch {
+	case rawTxn.ChainId != c.config.ChainId:
+		return nil, &rejection{"BAD_CHAIN_ID", vmErrorCodeBadChainId, nil}
+	case rawTxn.ExpirationTimestampSeconds <= now:
+		return nil, &rejection{"TRANSACTION_EXPIRED", api.VmErrorCodeTransactionExpired, aptos.ErrTransactionExpired}
+	case replayNonce != nil:
+		// Orderless transactions have no sequence number, but a nonce that can't be reused until they expire
+		if rawTxn.ExpirationTimestampSeconds > now+aptos.MaxOrderlessExpirationSeconds {
+			return nil, &rejection{"TRANSACTION_EXPIRATION_TOO_FAR_IN_FUTURE", 0, nil}
+		}
+		if expiration, used := sender.replayNonces[*replayNonce]; used && expiration > now {
+			return nil, &rejection{"NONCE_ALREADY_USED", 0, nil}
+		}
+	case rawTxn.SequenceNumber < sender.sequenceNumber:
+		return nil, &rejection{"SEQUENCE_NUMBER_TOO_OLD", api.VmErrorCodeSequenceNumberTooOld, aptos.ErrSequenceNumberTooOld}
+	case rawTxn.SequenceNumber > sender.sequenceNumber:
+		return nil, &rejection{"SEQUENCE_NUMBER_TOO_NEW", api.VmErrorCodeSequenceNumberTooNew, aptos.ErrSequenceNumberTooNew}
+	}
+
+	exec := &execution{rawTxn: rawTxn, payer: rawTxn.Sender, maxGasAmount: rawTxn.MaxGasAmount, replayNonce: replayNonce}
+	if feePayer != nil {
+		exec.payer = *feePayer
+	}
+	payerBalance := uint64(0)
+	if payer, ok := c.accounts[exec.payer]; ok {
+		payerBalance = payer.balance
+	} else if simulation && exec.payer == aptos.AccountZero {
+		payerBalance = math.MaxUint64
+	} else if exec.payer != rawTxn.Sender {
+		return nil, &rejection{"SENDING_ACCOUNT_DOES_NOT_EXIST", vmErrorCodeSendingAccountDoesNotExist, aptos.ErrAccountNotFound}
+	}
+	if rawTxn.GasUnitPrice > 0 {
+		if estimateMaxGas {
+			exec.maxGasAmount = payerBalance / rawTxn.GasUnitPrice
+		} else if exec.maxGasAmount > payerBalance/rawTxn.GasUnitPrice {
+			return nil, &rejection{"INSUFFICIENT_BALANCE_FOR_TRANSACTION_FEE", api.VmErrorCodeInsufficientBalanceForTransactionFee, aptos.ErrInsufficientBalance}
+		}
+	}
+
+	var err error
+	exec.transfer, exec.payload, err = decodeTransfer(rawTxn.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// The fee is charged whether the transaction succeeds or fails
+	exec.gasUsed = c.config.GasUsed
+	exec.vmStatus = executedSuccessfully
+	available := sender.balance
+	if exec.payer == rawTxn.Sender {
+		available -= min(exec.gasUsed*rawTxn.GasUnitPrice, available)
+	}
+	receiver, receiverExists := c.accounts[exec.transfer.receiver]
+	switch {
+	case exec.maxGasAmount < exec.gasUsed:
+		exec.gasUsed = exec.maxGasAmount
+		exec.vmStatus = "Out of gas"
+	case available < exec.transfer.amount:
+		exec.vmStatus = "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006): Not enough coins to complete transaction"
+	case receiverExists && receiver.balance > math.MaxUint64-exec.transfer.amount:
+		exec.vmStatus = "ARITHMETIC_ERROR"
+	}
+	if exec.vmStatus != executedSuccessfully {
+		exec.transfer = nil
+	}
+	return exec, nil
+}
+
+// decodeTransfer decodes the APT transfer made by a payload, [ErrUnsupported] if it isn't one
+func decodeTransfer(payload aptos.TransactionPayload) (*transfer, *api.TransactionPayload, error) {
+	entryFunction, ok := payload.Payload.(*aptos.EntryFunction)
+	if inner, isInner := payload.Payload.(*aptos.TransactionInnerPayload); isInner {
+		// An orderless transaction's entry function, which isn't a multisig one
+		if v1, isV1 := inner.Payload.(*aptos.TransactionInnerPayloadV1); isV1 {
+			config, _ := v1.ExtraConfig.Inner.(*aptos.TransactionExtraConfigV1)
+			if config == nil || config.MultisigAddress == nil {
+				entryFunction, ok = v1.Executable.Inner.(*aptos.EntryFunction)
+			}
+		}
+	}
+	if !ok {
+		return nil, nil, fmt.Errorf("payload %T: %w", payload.Payload, ErrUnsupported)
+	}
+	function := entryFunction.Module.Address.String() + "::" + entryFunction.Module.Name + "::" + entryFunction.Function
+	typeArgs := make([]string, len(entryFunction.ArgTypes))
+	for i := range entryFunction.ArgTypes {
+		typeArgs[i] = entryFunction.ArgTypes[i].String()
+	}
+	switch function {
+	case "0x1::aptos_account::transfer":
+		if len(typeArgs) != 0 {
+			return nil, nil, fmt.Errorf("%s takes no type arguments: %w", function, aptos.ErrInvalidInput)
+		}
+	case "0x1::aptos_account::transfer_coins", "0x1::coin::transfer":
+		if len(typeArgs) != 1 || typeArgs[0] != aptos.AptosCoinTypeTag.String() {
+			return nil, nil, fmt.Errorf("%s of coins other than APT: %w", function, ErrUnsupported)
+		}
+	default:
+		return nil, nil, fmt.Errorf("entry function %s: %w", function, ErrUnsupported)
+	}
+	if len(entryFunction.Args) != 2 {
+		return nil, nil, fmt.Errorf("%s takes 2 arguments, got %d: %w", function, len(entryFunction.Args), aptos.ErrInvalidInput)
+	}
+
+	decoded := &transfer{}
+	err := bcs.Deserialize(&decoded.receiver, entryFunction.Args[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("bad receiver argument to %s: %w", function, aptos.ErrInvalidInput)
+	}
+	err = bcs.Unmarshal(entryFunction.Args[1], &decoded.amount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bad amount argument to %s: %w", function, aptos.ErrInvalidInput)
+	}
+	return decoded, &api.TransactionPayload{
+		Type: api.TransactionPayloadVariantEntryFunction,
+		Inner: &api.TransactionPayloadEntryFunction{
+			Function:      function,
+			TypeArguments: typeArgs,
+			Arguments:     []any{decoded.receiver.String(), strconv.FormatUint(decoded.amount, 10)},
+		},
+	}, nil
+}
+
+// commit applies an execution to the ledger, as the next transaction
+func (c *Client) commit(exec *execution, hash string) {
+	sender := c.createAccount(exec.rawTxn.Sender)
+	if exec.replayNonce == nil {
+		sender.sequenceNumber++
+	} else {
+		if sender.replayNonces == nil {
+			sender.replayNonces = map[uint64]uint64{}
+		}
+		sender.replayNonces[*exec.replayNonce] = exec.rawTxn.ExpirationTimestampSeconds
+	}
+	c.accounts[exec.payer].balance -= exec.gasUsed * exec.rawTxn.GasUnitPrice
+	if exec.transfer != nil {
+		sender.balance -= exec.transfer.amount
+		c.createAccount(exec.transfer.receiver).balance += exec.transfer.amount
+	}
+
+	c.timestamp = c.now()
+	version := uint64(len(c.transactions))
+	c.transactions = append(c.transactions, &api.CommittedTransaction{
+		Type:  api.TransactionVariantUser,
+		Inner: exec.userTransaction(version, hash, c.timestamp),
+	})
+	c.hashes[hash] = version
+}
+
+// mint funds address like [Client.Fund], but commits a transaction for it, as a faucet does.  The transaction is sent
+// by 0x1, and its hash is its version, so a ledger that sees the same requests has the same hashes.
+func (c *Client) mint(address aptos.AccountAddress, amount uint64) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	acct := c.createAccount(address)
+	if acct.balance > math.MaxUint64-amount {
+		return "", fmt.Errorf("balance of %s would overflow", address.String())
+	}
+	acct.balance += amount
+
+	c.timestamp = c.now()
+	version := uint64(len(c.transactions))
+	hash := fmt.Sprintf("0x%064x", version)
+	minter := aptos.AccountOne
+	c.transactions = append(c.transactions, &api.CommittedTransaction{
+		Type: api.TransactionVariantUser,
+		Inner: &api.UserTransaction{
+			Version:  version,
+			Hash:     hash,
+			Success:  true,
+			VmStatus: executedSuccessfully,
+			Changes:  []*api.WriteSetChange{},
+			Events: []*api.Event{moduleEvent(version, "0x1::coin::CoinDeposit", map[string]any{
+				"coin_type": aptos.AptosCoinTypeTag.String(),
+				"account":   address.String(),
+				"amount":    strconv.FormatUint(amount, 10),
+			})},
+			Sender:                  &minter,
+			SequenceNumber:          c.mints,
+			GasUnitPrice:            c.config.GasUnitPrice,
+			ExpirationTimestampSecs: c.timestamp/1_000_000 + aptos.DefaultExpirationSeconds,
+			Payload: &api.TransactionPayload{
+				Type: api.TransactionPayloadVariantEntryFunction,
+				Inner: &api.TransactionPayloadEntryFunction{
+					Function:      "0x1::aptos_account::transfer",
+					TypeArguments: []string{},
+					Arguments:     []any{address.String(), strconv.FormatUint(amount, 10)},
+				},
+			},
+			Timestamp: c.timestamp,
+		},
+	})
+	c.hashes[hash] = version
+	c.mints++
+	return hash, nil
+}
+
+// now is the timestamp of the next transaction in microseconds, never before the latest one
+func (c *Client) now() uint64 {
+	return max(uint64(time.Now().UnixMicro()), c.timestamp) //nolint:gosec // The clock is after 1970
+}
+
+// userTransaction is the transaction an execution results in, at version
+func (exec *execution) userTransaction(version uint64, hash string, timestamp uint64) *api.UserTransaction {
+	rawTxn := exec.rawTxn
+	sender := rawTxn.Sender
+	return &api.UserTransaction{
+		Version:                 version,
+		Hash:                    hash,
+		GasUsed:                 exec.gasUsed,
+		Success:                 exec.vmStatus == executedSuccessfully,
+		VmStatus:                exec.vmStatus,
+		Changes:                 []*api.WriteSetChange{},
+		Events:                  exec.events(version),
+		Sender:                  &sender,
+		SequenceNumber:          rawTxn.SequenceNumber,
+		ReplayProtectionNonce:   exec.replayNonce,
+		MaxGasAmount:            exec.maxGasAmount,
+		GasUnitPrice:            rawTxn.GasUnitPrice,
+		ExpirationTimestampSecs: rawTxn.ExpirationTimestampSeconds,
+		Payload:                 exec.payload,
+		Timestamp:               timestamp,
+	}
+}
+
+// events are the module events emitted by an execution at version
+func (exec *execution) events(version uint64) []*api.Event {
+	events := make([]*api.Event, 0, 3)
+	if exec.transfer != nil {
+		amount := strconv.FormatUint(exec.transfer.amount, 10)
+		coinType := aptos.AptosCoinTypeTag.String()
+		events = append(events,
+			moduleEvent(version, "0x1::coin::CoinWithdraw", map[string]any{
+				"coin_type": coinType,
+				"account":   exec.rawTxn.Sender.String(),
+				"amount":    amount,
+			}),
+			moduleEvent(version, "0x1::coin::CoinDeposit", map[string]any{
+				"coin_type": coinType,
+				"account":   exec.transfer.receiver.String(),
+				"amount":    amount,
+			}))
+	}
+	gasUsed := strconv.FormatUint(exec.gasUsed, 10)
+	return append(events, moduleEvent(version, "0x1::transaction_fee::FeeStatement", map[string]any{
+		"total_charge_gas_units":   gasUsed,
+		"execution_gas_units":      gasUsed,
+		"io_gas_units":             "0",
+		"storage_fee_octas":        "0",
+		"storage_fee_refund_octas": "0",
+	}))
+}
+
+// moduleEvent is a module event, which has no event handle
+func moduleEvent(version uint64, eventType string, data map[string]any) *api.Event {
+	zero := aptos.AccountZero
+	return &api.Event{
+		Version: version,
+		Type:    eventType,
+		Guid:    &api.GUID{AccountAddress: &zero},
+		Data:    data,
+	}
+}
+
+// endregion
+
+// region Transaction pipelines
+
+// BuildTransactions builds each payload with [Client.BuildTransaction] or [Client.BuildTransactionMultiAgent] until
+// payloads is closed, then closes responses.  Transactions take consecutive sequence numbers, starting from the
+// sender's, or from the last one sent to setSequenceNumber, unless they're [aptos.Orderless].
+func (c *Client) BuildTransactions(sender aptos.AccountAddress, payloads chan aptos.TransactionBuildPayload, responses chan aptos.TransactionBuildResponse, setSequenceNumber chan uint64, options ...any) {
+	c.BuildTransactionsCtx(context.Background(), sender, payloads, responses, setSequenceNumber, options...)
+}
+
+// BuildTransactionsCtx is [Client.BuildTransactions], failing the remaining payloads once ctx is done
+func (c *Client) BuildTransactionsCtx(ctx context.Context, sender aptos.AccountAddress, payloads chan aptos.TransactionBuildPayload, responses chan aptos.TransactionBuildResponse, setSequenceNumber chan uint64, options ...any) {
+	defer close(responses)
+	// Sequence numbers are tracked here, rather than by an aptos.AccountSequenceNumber
+	options = slices.DeleteFunc(slices.Clone(options), func(option any) bool {
+		_, ok := option.(*aptos.AccountSequenceNumber)
+		return ok
+	})
+	orderless := false
+	sharedNonce := false
+	for _, option := range options {
+		switch value := option.(type) {
+		case aptos.Orderless:
+			orderless = orderless || bool(value)
+		case aptos.ReplayNonce:
+			sharedNonce = true
+		}
+	}
+	var next *uint64
+	for {
+		select {
+		case payload, ok := <-payloads:
+			if !ok {
+				return
+			}
+			if payload.Type != aptos.TransactionSubmissionTypeSingle && payload.Type != aptos.TransactionSubmissionTypeMultiAgent {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				responses <- aptos.TransactionBuildResponse{Id: payload.Id, Err: err}
+				continue
+			}
+			if sharedNonce {
+				responses <- aptos.TransactionBuildResponse{Id: payload.Id, Err: errors.New("a ReplayNonce can't be shared by transactions, use Orderless")}
+				continue
+			}
+			buildOptions := options
+			if next != nil && !orderless {
+				buildOptions = append(slices.Clone(options), aptos.SequenceNumber(*next))
+			}
+			rawTxn, inner, err := c.buildPipelineTransaction(ctx, sender, payload, buildOptions)
+			if err != nil {
+				responses <- aptos.TransactionBuildResponse{Id: payload.Id, Err: err}
+				continue
+			}
+			if !orderless {
+				sequenceNumber := inner.SequenceNumber + 1
+				next = &sequenceNumber
+			}
+			responses <- aptos.TransactionBuildResponse{Id: payload.Id, Response: rawTxn}
+		case sequenceNumber := <-setSequenceNumber:
+			next = &sequenceNumber
+		}
+	}
+}
+
+// buildPipelineTransaction builds a payload of [Client.BuildTransactionsCtx], returning it and its inner transaction
+func (c *Client) buildPipelineTransaction(ctx context.Context, sender aptos.AccountAddress, payload aptos.TransactionBuildPayload, options []any) (aptos.RawTransactionImpl, *aptos.RawTransaction, error) {
+	if payload.Type == aptos.TransactionSubmissionTypeSingle {
+		rawTxn, err := c.BuildTransactionCtx(ctx, sender, payload.Inner, options...)
+		return rawTxn, rawTxn, err
+	}
+	rawTxn, err := c.BuildTransactionMultiAgentCtx(ctx, sender, payload.Inner, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch inner := rawTxn.Inner.(type) {
+	case *aptos.MultiAgentRawTransactionWithData:
+		return rawTxn, inner.RawTxn, nil
+	case *aptos.MultiAgentWithFeePayerRawTransactionWithData:
+		return rawTxn, inner.RawTxn, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown raw transaction with data %T: %w", rawTxn.Inner, aptos.ErrInvalidInput)
+	}
+}
+
+// SubmitTransactions submits each request with [Client.SubmitTransaction] until requests is closed, then closes
+// responses
+func (c *Client) SubmitTransactions(requests chan aptos.TransactionSubmissionRequest, responses chan aptos.TransactionSubmissionResponse) {
+	c.SubmitTransactionsCtx(context.Background(), requests, responses)
+}
+
+// SubmitTransactionsCtx is [Client.SubmitTransactions], failing the remaining requests once ctx is done
+func (c *Client) SubmitTransactionsCtx(ctx context.Context, requests chan aptos.TransactionSubmissionRequest, responses chan aptos.TransactionSubmissionResponse) {
+	defer close(responses)
+	for request := range requests {
+		if err := ctx.Err(); err != nil {
+			responses <- aptos.TransactionSubmissionResponse{Id: request.Id, Err: err}
+			continue
+		}
+		response, err := c.SubmitTransactionCtx(ctx, request.SignedTxn)
+		responses <- aptos.TransactionSubmissionResponse{Id: request.Id, Response: response, Err: err}
+	}
+}
+
+// BatchSubmitTransactions is [Client.SubmitTransactions], as submitting in batches makes no difference here
+func (c *Client) BatchSubmitTransactions(requests chan aptos.TransactionSubmissionRequest, responses chan aptos.TransactionSubmissionResponse) {
+	c.SubmitTransactionsCtx(context.Background(), requests, responses)
+}
+
+// BatchSubmitTransactionsCtx is [Client.SubmitTransactionsCtx]
+func (c *Client) BatchSubmitTransactionsCtx(ctx context.Context, requests chan aptos.TransactionSubmissionRequest, responses chan aptos.TransactionSubmissionResponse) {
+	c.SubmitTransactionsCtx(ctx, requests, responses)
+}
+
+// BuildSignAndSubmitTransactions builds the payloads with [Client.BuildTransactions], signs them with sender, and
+// submits them in order, until payloads is closed, then closes responses.  Only single signer transactions can be
+// signed, use [Client.BuildSignAndSubmitTransactionsWithSignFunction] for the others.
+func (c *Client) BuildSignAndSubmitTransactions(sender aptos.TransactionSigner, payloads chan aptos.TransactionBuildPayload, responses chan aptos.TransactionSubmissionResponse, buildOptions ...any) {
+	c.BuildSignAndSubmitTransactionsCtx(context.Background(), sender, payloads, responses, buildOptions...)
+}
+
+// BuildSignAndSubmitTransactionsCtx is [Client.BuildSignAndSubmitTransactions]
+func (c *Client) BuildSignAndSubmitTransactionsCtx(ctx context.Context, sender aptos.TransactionSigner, payloads chan aptos.TransactionBuildPayload, responses chan aptos.TransactionSubmissionResponse, buildOptions ...any) {
+	sign := func(rawTxn aptos.RawTransactionImpl) (*aptos.SignedTransaction, error) {
+		single, ok := rawTxn.(*aptos.RawTransaction)
+		if !ok {
+			return nil, fmt.Errorf("can't sign %T without a signer function: %w", rawTxn, aptos.ErrInvalidInput)
+		}
+		return single.SignedTransaction(sender)
+	}
+	c.BuildSignAndSubmitTransactionsWithSignFunctionCtx(ctx, sender.AccountAddress(), payloads, responses, sign, buildOptions...)
+}
+
+// BuildSignAndSubmitTransactionsWithSignFunction is [Client.BuildSignAndSubmitTransactions], signing with sign
+func (c *Client) BuildSignAndSubmitTransactionsWithSignFunction(sender aptos.AccountAddress, payloads chan aptos.TransactionBuildPayload, responses chan aptos.TransactionSubmissionResponse, sign func(rawTxn aptos.RawTransactionImpl) (*aptos.SignedTransaction, error), buildOptions ...any) {
+	c.BuildSignAndSubmitTransactionsWithSignFunctionCtx(context.Background(), sender, payloads, responses, sign, buildOptions...)
+}
+
+// BuildSignAndSubmitTransactionsWithSignFunctionCtx is [Client.BuildSignAndSubmitTransactionsWithSignFunction]
+func (c *Client) BuildSignAndSubmitTransactionsWithSignFunctionCtx(ctx context.Context, sender aptos.AccountAddress, payloads chan aptos.TransactionBuildPayload, responses chan aptos.TransactionSubmissionResponse, sign func(rawTxn aptos.RawTransactionImpl) (*aptos.SignedTransaction, error), buildOptions ...any) {
+	defer close(responses)
+	buildResponses := make(chan aptos.TransactionBuildResponse)
+	go c.BuildTransactionsCtx(ctx, sender, payloads, buildResponses, nil, buildOptions...)
+	for buildResponse := range buildResponses {
+		if buildResponse.Err != nil {
+			responses <- aptos.TransactionSubmissionResponse{Id: buildResponse.Id, Err: buildResponse.Err}
+			continue
+		}
+		signedTxn, err := sign(buildResponse.Response)
+		if err != nil {
+			responses <- aptos.TransactionSubmissionResponse{Id: buildResponse.Id, Err: err}
+			continue
+		}
+		response, err := c.SubmitTransactionCtx(ctx, signedTxn)
+		responses <- aptos.TransactionSubmissionResponse{Id: buildResponse.Id, Response: response, Err: err}
+	}
+}
+
+// BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool is [Client.BuildSignAndSubmitTransactionsWithSignFunction],
+// the transactions are signed in order rather than by a pool of workers
+func (c *Client) BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool(sender aptos.AccountAddress, payloads chan aptos.TransactionBuildPayload, responses chan aptos.TransactionSubmissionResponse, sign func(rawTxn aptos.RawTransactionImpl) (*aptos.SignedTransaction, error), _ aptos.WorkerPoolConfig, buildOptions ...any) {
+	c.BuildSignAndSubmitTransactionsWithSignFunctionCtx(context.Background(), sender, payloads, responses, sign, buildOptions...)
+}
+
+// BuildSignAndSubmitTransactionsWithSignFnAndWorkerPoolCtx is
+// [Client.BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool]
+func (c *Client) BuildSignAndSubmitTransactionsWithSignFnAndWorkerPoolCtx(ctx context.Context, sender aptos.AccountAddress, payloads chan aptos.TransactionBuildPayload, responses chan aptos.TransactionSubmissionResponse, sign func(rawTxn aptos.RawTransactionImpl) (*aptos.SignedTransaction, error), _ aptos.WorkerPoolConfig, buildOptions ...any) {
+	c.BuildSignAndSubmitTransactionsWithSignFunctionCtx(ctx, sender, payloads, responses, sign, buildOptions...)
+}
+
+// endregion
@@ -0,0 +1,538 @@
+package bcs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+
+	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+)
+
+// Marshal serializes v into BCS by walking it with reflection, for types without hand-written [Marshaler]
+// implementations.  A pointer given as v is serialized as the value it points to.
+//
+// Types are mapped as follows:
+//   - bool, uint8, uint16, uint32, uint64 as themselves
+//   - string as a length prefixed UTF-8 string
+//   - slices as sequences, prefixed with their length, and []byte as bytes
+//   - arrays as fixed length sequences, without a length prefix
+//   - structs as their exported fields in order, fields tagged `bcs:"-"` are skipped
+//   - pointers as an Option, nil for None
+//   - big.Int and *big.Int as u128 or u256, and must be tagged `bcs:"u128"` or `bcs:"u256"`, the tag applies to the
+//     elements of slices and arrays of them
+//   - interfaces as an enum of the variants registered with [RegisterEnum]
+//
+// Any type implementing [Marshaler] uses it instead.
+//
+//	type Transfer struct {
+//		To     [32]byte
+//		Amount *big.Int `bcs:"u128"`
+//		Memo   *string
+//	}
+//
+//	bytes, err := Marshal(&Transfer{Amount: big.NewInt(100)})
+func Marshal(v any) ([]byte, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, errors.New("cannot marshal nil")
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return nil, errors.New("cannot marshal nil")
+	}
+	codec, err := codecFor(value.Type(), "")
+	if err != nil {
+		return nil, err
+	}
+	return SerializeSingle(func(ser *Serializer) {
+		codec.encode(ser, value)
+	})
+}
+
+// Unmarshal deserializes BCS data into the value v points to, the reverse of [Marshal].  Pointers are allocated for
+// Some values and set to nil for None values.
+//
+// This function will error if there are remaining bytes.
+//
+//	transfer := &Transfer{}
+//	err := Unmarshal(bytes, transfer)
+func Unmarshal(data []byte, v any) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("cannot unmarshal into non-pointer or nil %T", v)
+	}
+	codec, err := codecFor(value.Type().Elem(), "")
+	if err != nil {
+		return err
+	}
+	des := NewDeserializer(data)
+	codec.decode(des, value.Elem())
+	if des.err != nil {
+		return des.err
+	}
+	if des.Remaining() > 0 {
+		return fmt.Errorf("deserialize failed: remaining %d byte(s)", des.Remaining())
+	}
+	return nil
+}
+
+// RegisterEnum registers the variants of an enum represented by the interface T, for [Marshal] and [Unmarshal].  The
+// index of each variant is its position in variants, and the type of each is taken from its value.
+//
+//	type Shape interface{ isShape() }
+//
+//	type Circle struct{ Radius uint64 }
+//	type Square struct{ Side uint64 }
+//
+//	func (*Circle) isShape() {}
+//	func (*Square) isShape() {}
+//
+//	err := RegisterEnum[Shape](&Circle{}, &Square{})
+func RegisterEnum[T any](variants ...T) error {
+	enumType := reflect.TypeFor[T]()
+	if enumType.Kind() != reflect.Interface {
+		return fmt.Errorf("enum type %s is not an interface", enumType)
+	}
+	enum := &enumInfo{
+		variants: make([]reflect.Type, len(variants)),
+		indices:  make(map[reflect.Type]uint32, len(variants)),
+	}
+	for i, variant := range variants {
+		variantType := reflect.TypeOf(variant)
+		if variantType == nil {
+			return fmt.Errorf("enum %s variant %d is nil", enumType, i)
+		}
+		if _, ok := enum.indices[variantType]; ok {
+			return fmt.Errorf("enum %s variant %s registered twice", enumType, variantType)
+		}
+		index, err := util.IntToU32(i)
+		if err != nil {
+			return err
+		}
+		enum.variants[i] = variantType
+		enum.indices[variantType] = index
+	}
+
+	codecsMutex.Lock()
+	defer codecsMutex.Unlock()
+	if _, ok := enums[enumType]; ok {
+		return fmt.Errorf("enum %s already registered", enumType)
+	}
+	enums[enumType] = enum
+	return nil
+}
+
+// enumInfo is the registered variants of an enum interface
+type enumInfo struct {
+	variants []reflect.Type          // Variant types by index
+	indices  map[reflect.Type]uint32 // Variant indices by type
+}
+
+// codec serializes and deserializes a single type, decode is always given an addressable value
+type codec struct {
+	encode func(ser *Serializer, value reflect.Value)
+	decode func(des *Deserializer, value reflect.Value)
+}
+
+// codecKey is the type a codec is built for, and the integer tag of the field it came from
+type codecKey struct {
+	t   reflect.Type
+	tag string
+}
+
+var (
+	codecsMutex sync.Mutex
+	codecs      = map[codecKey]*codec{}
+	enums       = map[reflect.Type]*enumInfo{}
+
+	marshalerType   = reflect.TypeFor[Marshaler]()
+	unmarshalerType = reflect.TypeFor[Unmarshaler]()
+	bigIntType      = reflect.TypeFor[big.Int]()
+)
+
+// codecFor returns the cached codec for t, building it on first use
+func codecFor(t reflect.Type, tag string) (*codec, error) {
+	codecsMutex.Lock()
+	defer codecsMutex.Unlock()
+	if c, ok := codecs[codecKey{t, tag}]; ok {
+		return c, nil
+	}
+	builder := &codecBuilder{pending: map[codecKey]*codec{}}
+	c, err := builder.codecFor(t, tag)
+	if err != nil {
+		return nil, err
+	}
+	// Only complete codecs are cached, a failed build leaves nothing behind
+	for key, pending := range builder.pending {
+		codecs[key] = pending
+	}
+	return c, nil
+}
+
+// codecBuilder builds the codecs for a type and the types it contains, while holding codecsMutex
+type codecBuilder struct {
+	pending map[codecKey]*codec // Codecs built so far, including ones still being filled in
+}
+
+// codecFor returns the codec for t, building it if needed.  Codecs are added to pending before they're filled in, so
+// recursive types refer to themselves.
+func (b *codecBuilder) codecFor(t reflect.Type, tag string) (*codec, error) {
+	key := codecKey{t, tag}
+	if c, ok := codecs[key]; ok {
+		return c, nil
+	}
+	if c, ok := b.pending[key]; ok {
+		return c, nil
+	}
+	c := &codec{}
+	b.pending[key] = c
+	err := b.build(c, t, tag)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// build fills in c for t
+func (b *codecBuilder) build(c *codec, t reflect.Type, tag string) error {
+	switch {
+	case t == bigIntType:
+		return buildBigIntCodec(c, t, tag)
+	case t.Kind() == reflect.Pointer && t.Elem() == bigIntType:
+		return buildBigIntCodec(c, t, tag)
+	case t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface && reflect.PointerTo(t).Implements(marshalerType):
+		buildMarshalerCodec(c, t)
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		c.encode = func(ser *Serializer, value reflect.Value) { ser.Bool(value.Bool()) }
+		c.decode = func(des *Deserializer, value reflect.Value) { value.SetBool(des.Bool()) }
+	case reflect.Uint8:
+		//nolint:gosec // The kind guarantees the value fits
+		c.encode = func(ser *Serializer, value reflect.Value) { ser.U8(uint8(value.Uint())) }
+		c.decode = func(des *Deserializer, value reflect.Value) { value.SetUint(uint64(des.U8())) }
+	case reflect.Uint16:
+		//nolint:gosec // The kind guarantees the value fits
+		c.encode = func(ser *Serializer, value reflect.Value) { ser.U16(uint16(value.Uint())) }
+		c.decode = func(des *Deserializer, value reflect.Value) { value.SetUint(uint64(des.U16())) }
+	case reflect.Uint32:
+		//nolint:gosec // The kind guarantees the value fits
+		c.encode = func(ser *Serializer, value reflect.Value) { ser.U32(uint32(value.Uint())) }
+		c.decode = func(des *Deserializer, value reflect.Value) { value.SetUint(uint64(des.U32())) }
+	case reflect.Uint64:
+		c.encode = func(ser *Serializer, value reflect.Value) { ser.U64(value.Uint()) }
+		c.decode = func(des *Deserializer, value reflect.Value) { value.SetUint(des.U64()) }
+	case reflect.String:
+		c.encode = func(ser *Serializer, value reflect.Value) { ser.WriteString(value.String()) }
+		c.decode = func(des *Deserializer, value reflect.Value) { value.SetString(des.ReadString()) }
+	case reflect.Slice:
+		return b.buildSliceCodec(c, t, tag)
+	case reflect.Array:
+		return b.buildArrayCodec(c, t, tag)
+	case reflect.Struct:
+		return b.buildStructCodec(c, t)
+	case reflect.Pointer:
+		return b.buildOptionCodec(c, t)
+	case reflect.Interface:
+		return b.buildEnumCodec(c, t)
+	default:
+		return fmt.Errorf("cannot marshal type %s, kind %s has no BCS representation", t, t.Kind())
+	}
+	return nil
+}
+
+// buildMarshalerCodec uses the type's own [Marshaler] and [Unmarshaler] implementations
+func buildMarshalerCodec(c *codec, t reflect.Type) {
+	c.encode = func(ser *Serializer, value reflect.Value) {
+		if !value.CanAddr() {
+			addressable := reflect.New(t).Elem()
+			addressable.Set(value)
+			value = addressable
+		}
+		marshaler, _ := value.Addr().Interface().(Marshaler)
+		marshaler.MarshalBCS(ser)
+	}
+	if !reflect.PointerTo(t).Implements(unmarshalerType) {
+		c.decode = func(des *Deserializer, value reflect.Value) {
+			des.setError("cannot unmarshal type %s, it is not an Unmarshaler", t)
+		}
+		return
+	}
+	c.decode = func(des *Deserializer, value reflect.Value) {
+		unmarshaler, _ := value.Addr().Interface().(Unmarshaler)
+		unmarshaler.UnmarshalBCS(des)
+	}
+}
+
+// buildBigIntCodec handles big.Int and *big.Int tagged as u128 or u256, a nil *big.Int can't be serialized
+func buildBigIntCodec(c *codec, t reflect.Type, tag string) error {
+	var encode func(ser *Serializer, v *big.Int)
+	var decode func(des *Deserializer) big.Int
+	switch tag {
+	case "u128":
+		encode = func(ser *Serializer, v *big.Int) { serializeBigIntInRange(ser, 128, v) }
+		decode = (*Deserializer).U128
+	case "u256":
+		encode = func(ser *Serializer, v *big.Int) { serializeBigIntInRange(ser, 256, v) }
+		decode = (*Deserializer).U256
+	default:
+		return fmt.Errorf("cannot marshal type %s without a `bcs:\"u128\"` or `bcs:\"u256\"` tag", t)
+	}
+
+	if t == bigIntType {
+		c.encode = func(ser *Serializer, value reflect.Value) {
+			v, _ := value.Interface().(big.Int)
+			encode(ser, &v)
+		}
+		c.decode = func(des *Deserializer, value reflect.Value) {
+			value.Set(reflect.ValueOf(decode(des)))
+		}
+		return nil
+	}
+	c.encode = func(ser *Serializer, value reflect.Value) {
+		if value.IsNil() {
+			ser.SetError(fmt.Errorf("cannot marshal nil %s as %s", t, tag))
+			return
+		}
+		v, _ := value.Interface().(*big.Int)
+		encode(ser, v)
+	}
+	c.decode = func(des *Deserializer, value reflect.Value) {
+		v := decode(des)
+		value.Set(reflect.ValueOf(&v))
+	}
+	return nil
+}
+
+// serializeBigIntInRange serializes v as an unsigned integer of bits, or sets an error if it's negative or too large
+func serializeBigIntInRange(ser *Serializer, bits int, v *big.Int) {
+	if v.Sign() < 0 || v.BitLen() > bits {
+		ser.SetError(fmt.Errorf("cannot marshal %s as u%d, it is out of range", v.String(), bits))
+		return
+	}
+	ser.serializeUBigInt(uint(bits/8), v)
+}
+
+// buildSliceCodec handles slices as length prefixed sequences, with byte slices as bytes
+func (b *codecBuilder) buildSliceCodec(c *codec, t reflect.Type, tag string) error {
+	if t.Elem().Kind() == reflect.Uint8 && !reflect.PointerTo(t.Elem()).Implements(marshalerType) {
+		c.encode = func(ser *Serializer, value reflect.Value) { ser.WriteBytes(value.Bytes()) }
+		c.decode = func(des *Deserializer, value reflect.Value) { value.SetBytes(des.ReadBytes()) }
+		return nil
+	}
+
+	elem, err := b.codecFor(t.Elem(), tag)
+	if err != nil {
+		return err
+	}
+	c.encode = func(ser *Serializer, value reflect.Value) {
+		length, err := util.IntToU32(value.Len())
+		if err != nil {
+			ser.SetError(err)
+			return
+		}
+		ser.Uleb128(length)
+		encodeElements(ser, value, elem)
+	}
+	c.decode = func(des *Deserializer, value reflect.Value) {
+		length := des.Uleb128()
+		if des.err != nil {
+			return
+		}
+		if t.Elem().Size() != 0 && int(length) > des.Remaining() {
+			// Every element of a sized type takes at least a byte, so this would only allocate for garbage
+			des.setError("not enough bytes remaining to deserialize sequence of %d %s", length, t.Elem())
+			return
+		}
+		value.Set(reflect.MakeSlice(t, int(length), int(length)))
+		decodeElements(des, value, elem)
+	}
+	return nil
+}
+
+// buildArrayCodec handles arrays as fixed length sequences, with byte arrays as fixed bytes
+func (b *codecBuilder) buildArrayCodec(c *codec, t reflect.Type, tag string) error {
+	if t.Elem().Kind() == reflect.Uint8 && !reflect.PointerTo(t.Elem()).Implements(marshalerType) {
+		c.encode = func(ser *Serializer, value reflect.Value) {
+			if !value.CanAddr() {
+				addressable := reflect.New(t).Elem()
+				addressable.Set(value)
+				value = addressable
+			}
+			ser.FixedBytes(value.Bytes())
+		}
+		c.decode = func(des *Deserializer, value reflect.Value) { des.ReadFixedBytesInto(value.Bytes()) }
+		return nil
+	}
+
+	elem, err := b.codecFor(t.Elem(), tag)
+	if err != nil {
+		return err
+	}
+	c.encode = func(ser *Serializer, value reflect.Value) { encodeElements(ser, value, elem) }
+	c.decode = func(des *Deserializer, value reflect.Value) { decodeElements(des, value, elem) }
+	return nil
+}
+
+// encodeElements serializes each element of a slice or array, stopping at the first error
+func encodeElements(ser *Serializer, value reflect.Value, elem *codec) {
+	for i := range value.Len() {
+		elem.encode(ser, value.Index(i))
+		if ser.err != nil {
+			ser.SetError(fmt.Errorf("could not serialize sequence[%d] member of %s %w", i, value.Type(), ser.err))
+			return
+		}
+	}
+}
+
+// decodeElements deserializes each element of a slice or array, stopping at the first error
+func decodeElements(des *Deserializer, value reflect.Value, elem *codec) {
+	for i := range value.Len() {
+		elem.decode(des, value.Index(i))
+		if des.err != nil {
+			des.err = fmt.Errorf("could not deserialize sequence[%d] member of %s %w", i, value.Type(), des.err)
+			return
+		}
+	}
+}
+
+// buildStructCodec handles structs as their exported fields in order
+func (b *codecBuilder) buildStructCodec(c *codec, t reflect.Type) error {
+	type field struct {
+		index int
+		name  string
+		codec *codec
+	}
+	fields := make([]field, 0, t.NumField())
+	for i := range t.NumField() {
+		structField := t.Field(i)
+		tag := structField.Tag.Get("bcs")
+		if !structField.IsExported() || tag == "-" {
+			continue
+		}
+		fieldCodec, err := b.codecFor(structField.Type, tag)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, structField.Name, err)
+		}
+		fields = append(fields, field{i, structField.Name, fieldCodec})
+	}
+
+	c.encode = func(ser *Serializer, value reflect.Value) {
+		for _, f := range fields {
+			f.codec.encode(ser, value.Field(f.index))
+			if ser.err != nil {
+				ser.SetError(fmt.Errorf("could not serialize %s.%s: %w", t, f.name, ser.err))
+				return
+			}
+		}
+	}
+	c.decode = func(des *Deserializer, value reflect.Value) {
+		for _, f := range fields {
+			f.codec.decode(des, value.Field(f.index))
+			if des.err != nil {
+				des.err = fmt.Errorf("could not deserialize %s.%s: %w", t, f.name, des.err)
+				return
+			}
+		}
+	}
+	return nil
+}
+
+// buildOptionCodec handles pointers as an Option, a sequence of 0 or 1 elements
+func (b *codecBuilder) buildOptionCodec(c *codec, t reflect.Type) error {
+	elem, err := b.codecFor(t.Elem(), "")
+	if err != nil {
+		return err
+	}
+	c.encode = func(ser *Serializer, value reflect.Value) {
+		if value.IsNil() {
+			ser.Uleb128(0)
+			return
+		}
+		ser.Uleb128(1)
+		elem.encode(ser, value.Elem())
+	}
+	c.decode = func(des *Deserializer, value reflect.Value) {
+		switch length := des.Uleb128(); {
+		case des.err != nil:
+		case length == 0:
+			value.SetZero()
+		case length == 1:
+			some := reflect.New(t.Elem())
+			elem.decode(des, some.Elem())
+			value.Set(some)
+		default:
+			des.setError("expected 0 or 1 element as an option, got %d", length)
+		}
+	}
+	return nil
+}
+
+// buildEnumCodec handles an interface registered with [RegisterEnum], as its variant index followed by the variant
+func (b *codecBuilder) buildEnumCodec(c *codec, t reflect.Type) error {
+	enum, ok := enums[t]
+	if !ok {
+		return fmt.Errorf("cannot marshal interface %s, it is not registered with RegisterEnum", t)
+	}
+	variants := make([]*codec, len(enum.variants))
+	for i, variantType := range enum.variants {
+		// A pointer variant is the value it points to, not an Option
+		if variantType.Kind() == reflect.Pointer {
+			variantType = variantType.Elem()
+		}
+		variant, err := b.codecFor(variantType, "")
+		if err != nil {
+			return err
+		}
+		variants[i] = variant
+	}
+
+	c.encode = func(ser *Serializer, value reflect.Value) {
+		if value.IsNil() {
+			ser.SetError(fmt.Errorf("cannot marshal nil %s", t))
+			return
+		}
+		variantValue := value.Elem()
+		index, ok := enum.indices[variantValue.Type()]
+		if !ok {
+			ser.SetError(fmt.Errorf("type %s is not a registered variant of %s", variantValue.Type(), t))
+			return
+		}
+		if variantValue.Kind() == reflect.Pointer {
+			if variantValue.IsNil() {
+				ser.SetError(fmt.Errorf("cannot marshal nil %s variant of %s", variantValue.Type(), t))
+				return
+			}
+			variantValue = variantValue.Elem()
+		}
+		ser.Uleb128(index)
+		variants[index].encode(ser, variantValue)
+	}
+	c.decode = func(des *Deserializer, value reflect.Value) {
+		index := des.Uleb128()
+		if des.err != nil {
+			return
+		}
+		if int(index) >= len(variants) {
+			des.setError("unknown variant %d of %s", index, t)
+			return
+		}
+		variantType := enum.variants[index]
+		if variantType.Kind() == reflect.Pointer {
+			variant := reflect.New(variantType.Elem())
+			variants[index].decode(des, variant.Elem())
+			value.Set(variant)
+		} else {
+			variant := reflect.New(variantType).Elem()
+			variants[index].decode(des, variant)
+			value.Set(variant)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package bcs
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reflectShape interface{ isShape() }
+
+type reflectCircle struct{ Radius uint64 }
+
+type reflectSquare struct{ Side uint32 }
+
+func (*reflectCircle) isShape() {}
+func (reflectSquare) isShape()  {}
+
+type reflectAll struct {
+	Flag     bool
+	Small    uint8
+	Medium   uint16
+	Large    uint32
+	Huge     uint64
+	Name     string
+	Bytes    []byte
+	Fixed    [4]byte
+	Numbers  []uint16
+	Pair     [2]uint8
+	Balance  *big.Int  `bcs:"u128"`
+	Supply   big.Int   `bcs:"u256"`
+	Amounts  []big.Int `bcs:"u128"`
+	Memo     *string
+	NoMemo   *string
+	Inner    TestStruct
+	Shapes   []reflectShape
+	Skipped  uint64 `bcs:"-"`
+	internal uint64
+}
+
+func init() {
+	err := RegisterEnum[reflectShape](&reflectCircle{}, reflectSquare{})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// handWritten serializes reflectAll field by field, the way a MarshalBCS implementation would
+func (st *reflectAll) handWritten(ser *Serializer) {
+	ser.Bool(st.Flag)
+	ser.U8(st.Small)
+	ser.U16(st.Medium)
+	ser.U32(st.Large)
+	ser.U64(st.Huge)
+	ser.WriteString(st.Name)
+	ser.WriteBytes(st.Bytes)
+	ser.FixedBytes(st.Fixed[:])
+	SerializeSequenceWithFunction(st.Numbers, ser, (*Serializer).U16)
+	ser.U8(st.Pair[0])
+	ser.U8(st.Pair[1])
+	ser.U128(*st.Balance)
+	ser.U256(st.Supply)
+	SerializeSequenceWithFunction(st.Amounts, ser, (*Serializer).U128)
+	SerializeOption(ser, st.Memo, (*Serializer).WriteString)
+	SerializeOption(ser, st.NoMemo, (*Serializer).WriteString)
+	ser.Struct(&st.Inner)
+	SerializeSequenceWithFunction(st.Shapes, ser, func(ser *Serializer, shape reflectShape) {
+		switch shape := shape.(type) {
+		case *reflectCircle:
+			ser.Uleb128(0)
+			ser.U64(shape.Radius)
+		case reflectSquare:
+			ser.Uleb128(1)
+			ser.U32(shape.Side)
+		}
+	})
+}
+
+func newReflectAll() *reflectAll {
+	memo := "hello"
+	return &reflectAll{
+		Flag:    true,
+		Small:   1,
+		Medium:  515,
+		Large:   70000,
+		Huge:    1 << 40,
+		Name:    "aptos",
+		Bytes:   []byte{0xde, 0xad},
+		Fixed:   [4]byte{1, 2, 3, 4},
+		Numbers: []uint16{7, 8, 9},
+		Pair:    [2]uint8{5, 6},
+		Balance: big.NewInt(1_000_000),
+		Supply:  *big.NewInt(42),
+		Amounts: []big.Int{*big.NewInt(1), *big.NewInt(2)},
+		Memo:    &memo,
+		Inner:   TestStruct{num: 3, b: true},
+		Shapes:  []reflectShape{&reflectCircle{Radius: 10}, reflectSquare{Side: 4}},
+		Skipped: 99,
+	}
+}
+
+func Test_MarshalReflect(t *testing.T) {
+	t.Parallel()
+	value := newReflectAll()
+	expected, err := SerializeSingle(value.handWritten)
+	require.NoError(t, err)
+
+	bytes, err := Marshal(value)
+	require.NoError(t, err)
+	assert.Equal(t, expected, bytes)
+
+	// By value is the same as by pointer
+	bytes, err = Marshal(*value)
+	require.NoError(t, err)
+	assert.Equal(t, expected, bytes)
+
+	out := &reflectAll{}
+	require.NoError(t, Unmarshal(bytes, out))
+	value.Skipped = 0
+	assert.Equal(t, value, out)
+
+	require.Error(t, Unmarshal(append(bytes, 0), out))
+	require.Error(t, Unmarshal(bytes[:len(bytes)-1], &reflectAll{}))
+	require.Error(t, Unmarshal(bytes, *out))
+}
+
+func Test_MarshalReflectPrimitives(t *testing.T) {
+	t.Parallel()
+	bytes, err := Marshal([]string{"a", "bc"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2, 1, 'a', 2, 'b', 'c'}, bytes)
+
+	var strs []string
+	require.NoError(t, Unmarshal(bytes, &strs))
+	assert.Equal(t, []string{"a", "bc"}, strs)
+
+	// Types implementing Marshaler use it
+	bytes, err = Marshal(&TestStruct{num: 5, b: true})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{5, 1}, bytes)
+
+	// Recursive types
+	type node struct {
+		Value uint8
+		Next  *node
+	}
+	bytes, err = Marshal(&node{Value: 1, Next: &node{Value: 2}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 1, 2, 0}, bytes)
+	out := &node{}
+	require.NoError(t, Unmarshal(bytes, out))
+	assert.Equal(t, uint8(2), out.Next.Value)
+	assert.Nil(t, out.Next.Next)
+
+	// Zero size elements are only a length
+	bytes, err = Marshal([]struct{}{{}, {}, {}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3}, bytes)
+	var empties []struct{}
+	require.NoError(t, Unmarshal(bytes, &empties))
+	assert.Len(t, empties, 3)
+}
+
+func Test_MarshalReflectErrors(t *testing.T) {
+	t.Parallel()
+	_, err := Marshal(nil)
+	require.Error(t, err)
+	_, err = Marshal(int64(1))
+	require.Error(t, err)
+	_, err = Marshal(map[string]uint8{})
+	require.Error(t, err)
+
+	// Integers need a tag
+	type untagged struct{ Amount *big.Int }
+	_, err = Marshal(&untagged{Amount: big.NewInt(1)})
+	require.Error(t, err)
+	type tagged struct {
+		Amount *big.Int `bcs:"u128"`
+	}
+	_, err = Marshal(&tagged{})
+	require.Error(t, err)
+
+	// Integers must fit their tag
+	_, err = Marshal(&tagged{Amount: big.NewInt(-1)})
+	require.Error(t, err)
+	_, err = Marshal(&tagged{Amount: new(big.Int).Lsh(big.NewInt(1), 128)})
+	require.Error(t, err)
+	type taggedU256 struct {
+		Amount big.Int `bcs:"u256"`
+	}
+	_, err = Marshal(&taggedU256{Amount: *new(big.Int).Lsh(big.NewInt(1), 256)})
+	require.Error(t, err)
+	_, err = Marshal(&taggedU256{Amount: *new(big.Int).Lsh(big.NewInt(1), 255)})
+	require.NoError(t, err)
+
+	// Only registered enums and variants
+	type unregistered struct{ Value any }
+	_, err = Marshal(&unregistered{Value: uint8(1)})
+	require.Error(t, err)
+	_, err = Marshal([]reflectShape{&reflectSquare{}})
+	require.Error(t, err)
+	var shapes []reflectShape
+	require.Error(t, Unmarshal([]byte{1, 2}, &shapes))
+
+	require.Error(t, RegisterEnum[reflectShape](&reflectCircle{}))
+	require.Error(t, RegisterEnum[reflectCircle](reflectCircle{}))
+	require.Error(t, Unmarshal([]byte{2}, &shapes))
+}
+
+func Benchmark_MarshalReflect(b *testing.B) {
+	value := newReflectAll()
+	for range b.N {
+		_, err := Marshal(value)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_MarshalHandWritten(b *testing.B) {
+	value := newReflectAll()
+	for range b.N {
+		_, err := SerializeSingle(value.handWritten)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_UnmarshalReflect(b *testing.B) {
+	bytes, err := Marshal(newReflectAll())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for range b.N {
+		err = Unmarshal(bytes, &reflectAll{})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_UnmarshalHandWritten(b *testing.B) {
+	bytes, err := Marshal(newReflectAll())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for range b.N {
+		des := NewDeserializer(bytes)
+		out := &reflectAll{}
+		out.Flag = des.Bool()
+		out.Small = des.U8()
+		out.Medium = des.U16()
+		out.Large = des.U32()
+		out.Huge = des.U64()
+		out.Name = des.ReadString()
+		out.Bytes = des.ReadBytes()
+		des.ReadFixedBytesInto(out.Fixed[:])
+		out.Numbers = DeserializeSequenceWithFunction(des, func(des *Deserializer, out *uint16) { *out = des.U16() })
+		out.Pair[0] = des.U8()
+		out.Pair[1] = des.U8()
+		balance := des.U128()
+		out.Balance = &balance
+		out.Supply = des.U256()
+		out.Amounts = DeserializeSequenceWithFunction(des, func(des *Deserializer, out *big.Int) { *out = des.U128() })
+		out.Memo = DeserializeOption(des, func(des *Deserializer, out *string) { *out = des.ReadString() })
+		out.NoMemo = DeserializeOption(des, func(des *Deserializer, out *string) { *out = des.ReadString() })
+		des.Struct(&out.Inner)
+		out.Shapes = DeserializeSequenceWithFunction(des, func(des *Deserializer, out *reflectShape) {
+			if des.Uleb128() == 0 {
+				*out = &reflectCircle{Radius: des.U64()}
+			} else {
+				*out = reflectSquare{Side: des.U32()}
+			}
+		})
+		if des.Error() != nil {
+			b.Fatal(des.Error())
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package aptos
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"time"
 
@@ -96,6 +98,17 @@ type EstimatePrioritizedGasUnitPrice bool
 // MaxGasAmount will set the max gas amount in gas units for a transaction
 type MaxGasAmount uint64
 
+// SimulateMaxGas will simulate a transaction while building it, and set its max gas amount to the gas used in the
+// simulation times GasMultiplier.  If the simulation fails, building returns a [*SimulationError].
+//
+// The simulation is signed with the Signer's [TransactionSigner.SimulationAuthenticator], Signer may be left nil for
+// BuildSignAndSubmitTransaction, which uses the sender.  A GasMultiplier of 0 uses [DefaultSimulationGasMultiplier].
+// If [MaxGasAmount] is also given, it is an upper bound on the estimate.
+type SimulateMaxGas struct {
+	Signer        TransactionSigner
+	GasMultiplier float64
+}
+
 // GasUnitPrice will set the gas unit price in octas (1/10^8 APT) for a transaction
 type GasUnitPrice uint64
 
@@ -145,9 +158,24 @@ type AptosRpcClient interface {
 	// Info Retrieves the node info about the network and it's current state
 	Info() (NodeInfo, error)
 
+	// InfoCtx is Info with its requests bound to ctx
+	InfoCtx(ctx context.Context) (NodeInfo, error)
+
 	// Account Retrieves information about the account such as [SequenceNumber] and [crypto.AuthenticationKey]
 	Account(address AccountAddress, ledgerVersion ...uint64) (AccountInfo, error)
 
+	// AccountCtx is Account with its requests bound to ctx
+	AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (AccountInfo, error)
+
+	// AccountWithResponseInfo is Account, additionally returning the ledger metadata of the response
+	//
+	//	info, responseInfo, _ := client.AccountWithResponseInfo(address)
+	//	fmt.Printf("sequence number read at version %d\n", responseInfo.LedgerVersion)
+	AccountWithResponseInfo(address AccountAddress, ledgerVersion ...uint64) (AccountInfo, *ResponseInfo, error)
+
+	// AccountWithResponseInfoCtx is AccountWithResponseInfo with its requests bound to ctx
+	AccountWithResponseInfoCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (AccountInfo, *ResponseInfo, error)
+
 	// AccountResource Retrieves a single resource given its struct name.
 	//
 	//	address := AccountOne
@@ -159,6 +187,15 @@ type AptosRpcClient interface {
 	//	dataMap, _ := client.AccountResource(address, "0x1::coin::CoinStore", 1)
 	AccountResource(address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, error)
 
+	// AccountResourceCtx is AccountResource with its requests bound to ctx
+	AccountResourceCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, error)
+
+	// AccountResourceWithResponseInfo is AccountResource, additionally returning the ledger metadata of the response
+	AccountResourceWithResponseInfo(address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, *ResponseInfo, error)
+
+	// AccountResourceWithResponseInfoCtx is AccountResourceWithResponseInfo with its requests bound to ctx
+	AccountResourceWithResponseInfoCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, *ResponseInfo, error)
+
 	// AccountResources fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
 	// For fetching raw Move structs as BCS, See #AccountResourcesBCS
 	//
@@ -171,15 +208,59 @@ type AptosRpcClient interface {
 	//	dataMap, _ := client.AccountResource(address, 1)
 	AccountResources(address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceInfo, error)
 
+	// AccountResourcesCtx is AccountResources with its requests bound to ctx
+	AccountResourcesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceInfo, error)
+
 	// AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
 	AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceRecord, error)
 
+	// AccountResourcesBCSCtx is AccountResourcesBCS with its requests bound to ctx
+	AccountResourcesBCSCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceRecord, error)
+
+	// GetTableItem fetches an item of a 0x1::table::Table by its key, decoded into JSON-like data
+	//
+	//	value, err := client.GetTableItem(handle, "address", "u64", "0x1")
+	GetTableItem(handle string, keyType string, valueType string, key any, ledgerVersion ...uint64) (any, error)
+
+	// GetTableItemCtx is GetTableItem with its requests bound to ctx
+	GetTableItemCtx(ctx context.Context, handle string, keyType string, valueType string, key any, ledgerVersion ...uint64) (any, error)
+
+	// GetRawTableItem fetches an item of a 0x1::table::Table by its BCS encoded key, returning the BCS encoded value.
+	// See [GetTableItemBCS] to decode the value into a type.
+	GetRawTableItem(handle string, key []byte, ledgerVersion ...uint64) ([]byte, error)
+
+	// GetRawTableItemCtx is GetRawTableItem with its requests bound to ctx
+	GetRawTableItemCtx(ctx context.Context, handle string, key []byte, ledgerVersion ...uint64) ([]byte, error)
+
 	// AccountModule fetches a single account module's bytecode and ABI from on-chain state.
 	AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (*api.MoveBytecode, error)
 
+	// AccountModuleCtx is AccountModule with its requests bound to ctx
+	AccountModuleCtx(ctx context.Context, address AccountAddress, moduleName string, ledgerVersion ...uint64) (*api.MoveBytecode, error)
+
+	// AccountModules fetches all modules of an account with their bytecode and ABI from on-chain state
+	//
+	//	modules, _ := client.AccountModules(AccountOne)
+	//	for _, module := range modules {
+	//		fmt.Println(module.Abi.Name)
+	//	}
+	AccountModules(address AccountAddress, ledgerVersion ...uint64) ([]api.MoveBytecode, error)
+
+	// AccountModulesCtx is AccountModules with its requests bound to ctx
+	AccountModulesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]api.MoveBytecode, error)
+
+	// AccountModulesBCS fetches all modules of an account as raw bytecode in AccountModuleRecord.Bytecode, without ABIs
+	AccountModulesBCS(address AccountAddress, ledgerVersion ...uint64) ([]AccountModuleRecord, error)
+
+	// AccountModulesBCSCtx is AccountModulesBCS with its requests bound to ctx
+	AccountModulesBCSCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountModuleRecord, error)
+
 	// EntryFunctionWithArgs generates an EntryFunction from on-chain Module ABI, and converts simple inputs to BCS encoded ones.
 	EntryFunctionWithArgs(moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, options ...any) (*EntryFunction, error)
 
+	// EntryFunctionWithArgsCtx is EntryFunctionWithArgs with its requests bound to ctx
+	EntryFunctionWithArgsCtx(ctx context.Context, moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, options ...any) (*EntryFunction, error)
+
 	// BlockByHeight fetches a block by height
 	//
 	//	block, _ := client.BlockByHeight(1, false)
@@ -189,6 +270,9 @@ type AptosRpcClient interface {
 	//	block, _ := client.BlockByHeight(1, true)
 	BlockByHeight(blockHeight uint64, withTransactions bool) (*api.Block, error)
 
+	// BlockByHeightCtx is BlockByHeight with its requests bound to ctx
+	BlockByHeightCtx(ctx context.Context, blockHeight uint64, withTransactions bool) (*api.Block, error)
+
 	// BlockByVersion fetches a block by ledger version
 	//
 	//	block, _ := client.BlockByVersion(123, false)
@@ -198,15 +282,16 @@ type AptosRpcClient interface {
 	//	block, _ := client.BlockByVersion(123, true)
 	BlockByVersion(ledgerVersion uint64, withTransactions bool) (*api.Block, error)
 
+	// BlockByVersionCtx is BlockByVersion with its requests bound to ctx
+	BlockByVersionCtx(ctx context.Context, ledgerVersion uint64, withTransactions bool) (*api.Block, error)
+
 	// TransactionByHash gets info on a transaction
 	// The transaction may be pending or recently committed.
 	//
 	//	data, err := client.TransactionByHash("0xabcd")
 	//	if err != nil {
-	//		if httpErr, ok := err.(aptos.HttpError) {
-	//			if httpErr.StatusCode == 404 {
-	//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-	//			}
+	//		if errors.Is(err, aptos.ErrTransactionNotFound) {
+	//			// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
 	//		}
 	//	} else {
 	//		if data["type"] == "pending_transaction" {
@@ -215,10 +300,16 @@ type AptosRpcClient interface {
 	//	}
 	TransactionByHash(txnHash string) (*api.Transaction, error)
 
+	// TransactionByHashCtx is TransactionByHash with its requests bound to ctx
+	TransactionByHashCtx(ctx context.Context, txnHash string) (*api.Transaction, error)
+
 	// WaitTransactionByHash waits for a transaction to be confirmed by its hash.
 	// This function allows you to monitor the status of a transaction until it is finalized.
 	WaitTransactionByHash(txnHash string) (*api.Transaction, error)
 
+	// WaitTransactionByHashCtx is WaitTransactionByHash with its requests bound to ctx
+	WaitTransactionByHashCtx(ctx context.Context, txnHash string) (*api.Transaction, error)
+
 	// TransactionByVersion gets info on a transaction from its LedgerVersion.  It must have been
 	// committed to have a ledger version
 	//
@@ -232,11 +323,17 @@ type AptosRpcClient interface {
 	//	}
 	TransactionByVersion(version uint64) (*api.CommittedTransaction, error)
 
+	// TransactionByVersionCtx is TransactionByVersion with its requests bound to ctx
+	TransactionByVersionCtx(ctx context.Context, version uint64) (*api.CommittedTransaction, error)
+
 	// PollForTransaction waits up to 10 seconds for a transaction to be done, polling at 10Hz
 	// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
 	// Not just a degenerate case of PollForTransactions, it may return additional information for the single transaction polled.
 	PollForTransaction(hash string, options ...any) (*api.UserTransaction, error)
 
+	// PollForTransactionCtx is PollForTransaction with its requests bound to ctx
+	PollForTransactionCtx(ctx context.Context, hash string, options ...any) (*api.UserTransaction, error)
+
 	// PollForTransactions Waits up to 10 seconds for transactions to be done, polling at 10Hz
 	// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
 	//
@@ -249,11 +346,17 @@ type AptosRpcClient interface {
 	//	err := client.PollForTransactions(hashes, PollPeriod(500 * time.Milliseconds), PollTimeout(5 * time.Seconds))
 	PollForTransactions(txnHashes []string, options ...any) error
 
+	// PollForTransactionsCtx is PollForTransactions with its requests bound to ctx
+	PollForTransactionsCtx(ctx context.Context, txnHashes []string, options ...any) error
+
 	// WaitForTransaction Do a long-GET for one transaction and wait for it to complete
 	//
 	//	data, err := client.WaitForTransaction("0x1234")
 	WaitForTransaction(txnHash string, options ...any) (*api.UserTransaction, error)
 
+	// WaitForTransactionCtx is WaitForTransaction with its requests bound to ctx
+	WaitForTransactionCtx(ctx context.Context, txnHash string, options ...any) (*api.UserTransaction, error)
+
 	// Transactions Get recent transactions.
 	// Start is a version number. Nil for most recent transactions.
 	// Limit is a number of transactions to return. 'about a hundred' by default.
@@ -262,6 +365,9 @@ type AptosRpcClient interface {
 	//	client.Transactions(1, 100) // Returns 100 transactions
 	Transactions(start *uint64, limit *uint64) ([]*api.CommittedTransaction, error)
 
+	// TransactionsCtx is Transactions with its requests bound to ctx
+	TransactionsCtx(ctx context.Context, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error)
+
 	// AccountTransactions Get transactions associated with an account.
 	// Start is a version number. Nil for most recent transactions.
 	// Limit is a number of transactions to return. 'about a hundred' by default.
@@ -270,6 +376,9 @@ type AptosRpcClient interface {
 	//	client.AccountTransactions(AccountOne, 1, 100) // Returns 100 transactions for 0x1
 	AccountTransactions(address AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error)
 
+	// AccountTransactionsCtx is AccountTransactions with its requests bound to ctx
+	AccountTransactionsCtx(ctx context.Context, address AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error)
+
 	// EventsByHandle retrieves events by event handle and field name for a given account.
 	//
 	// Arguments:
@@ -286,6 +395,16 @@ type AptosRpcClient interface {
 		limit *uint64,
 	) ([]*api.Event, error)
 
+	// EventsByHandleCtx is EventsByHandle with its requests bound to ctx
+	EventsByHandleCtx(
+		ctx context.Context,
+		account AccountAddress,
+		eventHandle string,
+		fieldName string,
+		start *uint64,
+		limit *uint64,
+	) ([]*api.Event, error)
+
 	// EventsByCreationNumber retrieves events by creation number for a given account.
 	//
 	// Arguments:
@@ -300,6 +419,77 @@ type AptosRpcClient interface {
 		limit *uint64,
 	) ([]*api.Event, error)
 
+	// EventsByCreationNumberCtx is EventsByCreationNumber with its requests bound to ctx
+	EventsByCreationNumberCtx(
+		ctx context.Context,
+		account AccountAddress,
+		creationNumber string,
+		start *uint64,
+		limit *uint64,
+	) ([]*api.Event, error)
+
+	// TransactionsIter iterates over committed transactions in ascending version order, starting at version start.
+	// Pages are fetched lazily, and iteration stops cleanly on break.
+	//
+	//	for txn, err := range client.TransactionsIter(0) {
+	//		if err != nil {
+	//			return err
+	//		}
+	//		fmt.Println(txn.Version())
+	//	}
+	TransactionsIter(start uint64) iter.Seq2[*api.CommittedTransaction, error]
+
+	// TransactionsIterCtx is TransactionsIter with its requests bound to ctx
+	TransactionsIterCtx(ctx context.Context, start uint64) iter.Seq2[*api.CommittedTransaction, error]
+
+	// AccountTransactionsIter iterates over the transactions sent by an account in ascending sequence number order,
+	// starting at sequence number start.  Pages are fetched lazily, and iteration stops cleanly on break.
+	//
+	//	for txn, err := range client.AccountTransactionsIter(AccountOne, 0) {
+	//		if err != nil {
+	//			return err
+	//		}
+	//		fmt.Println(txn.Hash())
+	//	}
+	AccountTransactionsIter(address AccountAddress, start uint64) iter.Seq2[*api.CommittedTransaction, error]
+
+	// AccountTransactionsIterCtx is AccountTransactionsIter with its requests bound to ctx
+	AccountTransactionsIterCtx(ctx context.Context, address AccountAddress, start uint64) iter.Seq2[*api.CommittedTransaction, error]
+
+	// EventsByHandleIter iterates over the events of an event handle in ascending sequence number order, starting at
+	// sequence number start
+	EventsByHandleIter(account AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error]
+
+	// EventsByHandleIterCtx is EventsByHandleIter with its requests bound to ctx
+	EventsByHandleIterCtx(ctx context.Context, account AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error]
+
+	// EventsByCreationNumberIter iterates over the events of an event stream in ascending sequence number order,
+	// starting at sequence number start
+	EventsByCreationNumberIter(account AccountAddress, creationNumber string, start uint64) iter.Seq2[*api.Event, error]
+
+	// EventsByCreationNumberIterCtx is EventsByCreationNumberIter with its requests bound to ctx
+	EventsByCreationNumberIterCtx(ctx context.Context, account AccountAddress, creationNumber string, start uint64) iter.Seq2[*api.Event, error]
+
+	// AccountResourcesIter iterates over the resources of an account, following the node's cursor from page to page
+	//
+	//	for resource, err := range client.AccountResourcesIter(AccountOne) {
+	//		if err != nil {
+	//			return err
+	//		}
+	//		fmt.Println(resource.Type)
+	//	}
+	AccountResourcesIter(address AccountAddress, ledgerVersion ...uint64) iter.Seq2[AccountResourceInfo, error]
+
+	// AccountResourcesIterCtx is AccountResourcesIter with its requests bound to ctx
+	AccountResourcesIterCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) iter.Seq2[AccountResourceInfo, error]
+
+	// AccountModulesIter iterates over the modules of an account with their ABIs, following the node's cursor from page
+	// to page
+	AccountModulesIter(address AccountAddress, ledgerVersion ...uint64) iter.Seq2[*api.MoveBytecode, error]
+
+	// AccountModulesIterCtx is AccountModulesIter with its requests bound to ctx
+	AccountModulesIterCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) iter.Seq2[*api.MoveBytecode, error]
+
 	// SubmitTransaction Submits an already signed transaction to the blockchain
 	//
 	//	sender := NewEd25519Account()
@@ -322,6 +512,9 @@ type AptosRpcClient interface {
 	//	submitResponse, err := client.SubmitTransaction(signedTxn)
 	SubmitTransaction(signedTransaction *SignedTransaction) (*api.SubmitTransactionResponse, error)
 
+	// SubmitTransactionCtx is SubmitTransaction with its requests bound to ctx
+	SubmitTransactionCtx(ctx context.Context, signedTransaction *SignedTransaction) (*api.SubmitTransactionResponse, error)
+
 	// BatchSubmitTransaction submits a collection of signed transactions to the network in a single request
 	//
 	// It will return the responses in the same order as the input transactions that failed.  If the response is empty, then
@@ -347,6 +540,9 @@ type AptosRpcClient interface {
 	//	submitResponse, err := client.BatchSubmitTransaction([]*SignedTransaction{signedTxn})
 	BatchSubmitTransaction(signedTxns []*SignedTransaction) (*api.BatchSubmitTransactionResponse, error)
 
+	// BatchSubmitTransactionCtx is BatchSubmitTransaction with its requests bound to ctx
+	BatchSubmitTransactionCtx(ctx context.Context, signedTxns []*SignedTransaction) (*api.BatchSubmitTransactionResponse, error)
+
 	// SimulateTransaction Simulates a raw transaction without sending it to the blockchain
 	//
 	//	sender := NewEd25519Account()
@@ -368,13 +564,22 @@ type AptosRpcClient interface {
 	//	simResponse, err := client.SimulateTransaction(rawTxn, sender)
 	SimulateTransaction(rawTxn *RawTransaction, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error)
 
+	// SimulateTransactionCtx is SimulateTransaction with its requests bound to ctx
+	SimulateTransactionCtx(ctx context.Context, rawTxn *RawTransaction, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error)
+
 	// SimulateTransactionMultiAgent simulates a transaction as fee payer or multi agent
 	SimulateTransactionMultiAgent(rawTxn *RawTransactionWithData, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error)
 
+	// SimulateTransactionMultiAgentCtx is SimulateTransactionMultiAgent with its requests bound to ctx
+	SimulateTransactionMultiAgentCtx(ctx context.Context, rawTxn *RawTransactionWithData, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error)
+
 	// GetChainId Retrieves the ChainId of the network
 	// Note this will be cached forever, or taken directly from the config
 	GetChainId() (uint8, error)
 
+	// GetChainIdCtx is GetChainId with its requests bound to ctx
+	GetChainIdCtx(ctx context.Context) (uint8, error)
+
 	// BuildTransaction Builds a raw transaction from the payload and fetches any necessary information from on-chain
 	//
 	//	sender := NewEd25519Account()
@@ -395,6 +600,9 @@ type AptosRpcClient interface {
 	//	rawTxn, err := client.BuildTransaction(sender.AccountAddress(), txnPayload)
 	BuildTransaction(sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransaction, error)
 
+	// BuildTransactionCtx is BuildTransaction with its requests bound to ctx
+	BuildTransactionCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransaction, error)
+
 	// BuildTransactionMultiAgent Builds a raw transaction for MultiAgent or FeePayer from the payload and fetches any necessary information from on-chain
 	//
 	//	sender := NewEd25519Account()
@@ -415,6 +623,9 @@ type AptosRpcClient interface {
 	//	rawTxn, err := client.BuildTransactionMultiAgent(sender.AccountAddress(), txnPayload, FeePayer(AccountZero))
 	BuildTransactionMultiAgent(sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransactionWithData, error)
 
+	// BuildTransactionMultiAgentCtx is BuildTransactionMultiAgent with its requests bound to ctx
+	BuildTransactionMultiAgentCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransactionWithData, error)
+
 	// BuildSignAndSubmitTransaction Convenience function to do all three in one
 	// for more configuration, please use them separately
 	//
@@ -436,6 +647,55 @@ type AptosRpcClient interface {
 	//	submitResponse, err := client.BuildSignAndSubmitTransaction(sender, txnPayload)
 	BuildSignAndSubmitTransaction(sender TransactionSigner, payload TransactionPayload, options ...any) (*api.SubmitTransactionResponse, error)
 
+	// BuildSignAndSubmitTransactionCtx is BuildSignAndSubmitTransaction with its requests bound to ctx
+	BuildSignAndSubmitTransactionCtx(ctx context.Context, sender TransactionSigner, payload TransactionPayload, options ...any) (*api.SubmitTransactionResponse, error)
+
+	// BuildTransactions builds the payloads into raw transactions for sender until payloads is closed, then closes
+	// responses.  Sequence numbers can be changed at any time through setSequenceNumber.
+	BuildTransactions(sender AccountAddress, payloads chan TransactionBuildPayload, responses chan TransactionBuildResponse, setSequenceNumber chan uint64, options ...any)
+
+	// BuildTransactionsCtx is BuildTransactions with its requests bound to ctx
+	BuildTransactionsCtx(ctx context.Context, sender AccountAddress, payloads chan TransactionBuildPayload, responses chan TransactionBuildResponse, setSequenceNumber chan uint64, options ...any)
+
+	// SubmitTransactions submits signed transactions one by one until requests is closed, then closes responses
+	SubmitTransactions(requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse)
+
+	// SubmitTransactionsCtx is SubmitTransactions with its requests bound to ctx
+	SubmitTransactionsCtx(ctx context.Context, requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse)
+
+	// BatchSubmitTransactions submits signed transactions in batches until requests is closed, then closes responses
+	BatchSubmitTransactions(requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse)
+
+	// BatchSubmitTransactionsCtx is BatchSubmitTransactions with its requests bound to ctx
+	BatchSubmitTransactionsCtx(ctx context.Context, requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse)
+
+	// BuildSignAndSubmitTransactions builds, signs and submits the payloads for sender until payloads is closed, then
+	// closes responses
+	//
+	//	payloads := make(chan TransactionBuildPayload)
+	//	responses := make(chan TransactionSubmissionResponse)
+	//	go client.BuildSignAndSubmitTransactions(sender, payloads, responses)
+	BuildSignAndSubmitTransactions(sender TransactionSigner, payloads chan TransactionBuildPayload, responses chan TransactionSubmissionResponse, buildOptions ...any)
+
+	// BuildSignAndSubmitTransactionsCtx is BuildSignAndSubmitTransactions with its requests bound to ctx
+	BuildSignAndSubmitTransactionsCtx(ctx context.Context, sender TransactionSigner, payloads chan TransactionBuildPayload, responses chan TransactionSubmissionResponse, buildOptions ...any)
+
+	// BuildSignAndSubmitTransactionsWithSignFunction is BuildSignAndSubmitTransactions, signing with sign, e.g. for fee
+	// payer or multi-agent transactions
+	BuildSignAndSubmitTransactionsWithSignFunction(sender AccountAddress, payloads chan TransactionBuildPayload, responses chan TransactionSubmissionResponse, sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error), buildOptions ...any)
+
+	// BuildSignAndSubmitTransactionsWithSignFunctionCtx is BuildSignAndSubmitTransactionsWithSignFunction with its
+	// requests bound to ctx
+	BuildSignAndSubmitTransactionsWithSignFunctionCtx(ctx context.Context, sender AccountAddress, payloads chan TransactionBuildPayload, responses chan TransactionSubmissionResponse, sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error), buildOptions ...any)
+
+	// BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool is BuildSignAndSubmitTransactionsWithSignFunction, signing
+	// with a fixed size pool of workers
+	BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool(sender AccountAddress, payloads chan TransactionBuildPayload, responses chan TransactionSubmissionResponse, sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error), workerPoolConfig WorkerPoolConfig, buildOptions ...any)
+
+	// BuildSignAndSubmitTransactionsWithSignFnAndWorkerPoolCtx is BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool
+	// with its requests bound to ctx
+	BuildSignAndSubmitTransactionsWithSignFnAndWorkerPoolCtx(ctx context.Context, sender AccountAddress, payloads chan TransactionBuildPayload, responses chan TransactionSubmissionResponse, sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error), workerPoolConfig WorkerPoolConfig, buildOptions ...any)
+
 	// View Runs a view function on chain returning a list of return values.
 	//
 	//	 address := AccountOne
@@ -452,6 +712,40 @@ type AptosRpcClient interface {
 	//		balance := StrToU64(vals.(any[])[0].(string))
 	View(payload *ViewPayload, ledgerVersion ...uint64) ([]any, error)
 
+	// ViewCtx is View with its requests bound to ctx
+	ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, error)
+
+	// ViewWithResponseInfo is View, additionally returning the ledger metadata of the response, including the gas used
+	// by the view function
+	ViewWithResponseInfo(payload *ViewPayload, ledgerVersion ...uint64) ([]any, *ResponseInfo, error)
+
+	// ViewWithResponseInfoCtx is ViewWithResponseInfo with its requests bound to ctx
+	ViewWithResponseInfoCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, *ResponseInfo, error)
+
+	// ViewRaw calls a view function, returning each of its return values BCS encoded
+	ViewRaw(payload *ViewPayload, ledgerVersion ...uint64) ([][]byte, error)
+
+	// ViewRawCtx is ViewRaw with its requests bound to ctx
+	ViewRawCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([][]byte, error)
+
+	// ViewTyped calls a view function, and decodes its return values from BCS into Go types by the module's ABI
+	//
+	//	values, err := client.ViewTyped(payload)
+	//	balance := values[0].(uint64)
+	ViewTyped(payload *ViewPayload, ledgerVersion ...uint64) ([]any, error)
+
+	// ViewTypedCtx is ViewTyped with its requests bound to ctx
+	ViewTypedCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, error)
+
+	// ViewWithArgs calls a view function with simple inputs converted to BCS by the module's ABI, and decodes its
+	// return values like ViewTyped
+	//
+	//	values, err := client.ViewWithArgs(AccountOne, "coin", "balance", []any{"0x1::aptos_coin::AptosCoin"}, []any{"0x1"})
+	ViewWithArgs(moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, ledgerVersion ...uint64) ([]any, error)
+
+	// ViewWithArgsCtx is ViewWithArgs with its requests bound to ctx
+	ViewWithArgsCtx(ctx context.Context, moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, ledgerVersion ...uint64) ([]any, error)
+
 	// ViewWithResponse runs a view function and unmarshals the JSON result into
 	// the provided `response` destination.
 	//
@@ -474,14 +768,32 @@ type AptosRpcClient interface {
 	//	// out[0] contains the balance as a string
 	ViewWithResponse(response any, payload *ViewPayload, ledgerVersion ...uint64) error
 
+	// ViewWithResponseCtx is ViewWithResponse with its requests bound to ctx
+	ViewWithResponseCtx(ctx context.Context, response any, payload *ViewPayload, ledgerVersion ...uint64) error
+
 	// EstimateGasPrice Retrieves the gas estimate from the network.
 	EstimateGasPrice() (EstimateGasInfo, error)
 
+	// EstimateGasPriceCtx is EstimateGasPrice with its requests bound to ctx
+	EstimateGasPriceCtx(ctx context.Context) (EstimateGasInfo, error)
+
 	// AccountAPTBalance retrieves the APT balance in the account
 	AccountAPTBalance(address AccountAddress, ledgerVersion ...uint64) (uint64, error)
 
+	// AccountAPTBalanceCtx is AccountAPTBalance with its requests bound to ctx
+	AccountAPTBalanceCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (uint64, error)
+
+	// AccountAPTBalanceWithResponseInfo is AccountAPTBalance, additionally returning the ledger metadata of the response
+	AccountAPTBalanceWithResponseInfo(address AccountAddress, ledgerVersion ...uint64) (uint64, *ResponseInfo, error)
+
+	// AccountAPTBalanceWithResponseInfoCtx is AccountAPTBalanceWithResponseInfo with its requests bound to ctx
+	AccountAPTBalanceWithResponseInfoCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (uint64, *ResponseInfo, error)
+
 	// NodeAPIHealthCheck checks if the node is within durationSecs of the current time, if not provided the node default is used
 	NodeAPIHealthCheck(durationSecs ...uint64) (api.HealthCheckResponse, error)
+
+	// NodeAPIHealthCheckCtx is NodeAPIHealthCheck with its requests bound to ctx
+	NodeAPIHealthCheckCtx(ctx context.Context, durationSecs ...uint64) (api.HealthCheckResponse, error)
 }
 
 // AptosFaucetClient is an interface for all functionality on the Client that is Faucet related.  Its main implementation
@@ -489,6 +801,9 @@ type AptosRpcClient interface {
 type AptosFaucetClient interface {
 	// Fund Uses the faucet to fund an address, only applies to non-production networks
 	Fund(address AccountAddress, amount uint64) error
+
+	// FundCtx is Fund with its requests bound to ctx
+	FundCtx(ctx context.Context, address AccountAddress, amount uint64) error
 }
 
 // AptosIndexerClient is an interface for all functionality on the Client that is Indexer related.  Its main implementation
@@ -522,11 +837,20 @@ type AptosIndexerClient interface {
 	//	return out, nil
 	QueryIndexer(query any, variables map[string]any, options ...graphql.Option) error
 
+	// QueryIndexerCtx is QueryIndexer with its requests bound to ctx
+	QueryIndexerCtx(ctx context.Context, query any, variables map[string]any, options ...graphql.Option) error
+
 	// GetProcessorStatus returns the ledger version up to which the processor has processed
 	GetProcessorStatus(processorName string) (uint64, error)
 
+	// GetProcessorStatusCtx is GetProcessorStatus with its requests bound to ctx
+	GetProcessorStatusCtx(ctx context.Context, processorName string) (uint64, error)
+
 	// GetCoinBalances gets the balances of all coins associated with a given address
 	GetCoinBalances(address AccountAddress) ([]CoinBalance, error)
+
+	// GetCoinBalancesCtx is GetCoinBalances with its requests bound to ctx
+	GetCoinBalancesCtx(ctx context.Context, address AccountAddress) ([]CoinBalance, error)
 }
 
 // Client is a facade over the multiple types of underlying clients, as the user doesn't actually care where the data
@@ -544,8 +868,18 @@ type Client struct {
 }
 
 // NewClient Creates a new client with a specific network config that can be extended in the future
+//
+// Accepts options:
+//   - *http.Client
+//   - [RetryPolicy]
+//   - [*AbiCache]
+//   - [FailoverNodeUrls]
+//   - [FailoverPolicy], only with [FailoverNodeUrls]
 func NewClient(config NetworkConfig, options ...any) (*Client, error) {
 	var httpClient *http.Client
+	var nodeOptions []any
+	var failoverUrls FailoverNodeUrls
+	hasFailoverPolicy := false
 	for i, arg := range options {
 		switch value := arg.(type) {
 		case *http.Client:
@@ -553,16 +887,32 @@ func NewClient(config NetworkConfig, options ...any) (*Client, error) {
 				return nil, errors.New("NewClient only accepts one http.Client")
 			}
 			httpClient = value
+		case RetryPolicy, *AbiCache:
+			nodeOptions = append(nodeOptions, value)
+		case FailoverNodeUrls:
+			failoverUrls = append(failoverUrls, value...)
+		case FailoverPolicy:
+			nodeOptions = append(nodeOptions, value)
+			hasFailoverPolicy = true
 		default:
 			return nil, fmt.Errorf("NewClient arg %d bad type %T", i+1, arg)
 		}
 	}
 	var err error
 	var nodeClient *NodeClient
-	if httpClient == nil {
-		nodeClient, err = NewNodeClient(config.NodeUrl, config.ChainId)
-	} else {
-		nodeClient, err = NewNodeClientWithHttpClient(config.NodeUrl, config.ChainId, httpClient)
+	if hasFailoverPolicy && len(failoverUrls) == 0 {
+		return nil, errors.New("NewClient FailoverPolicy requires FailoverNodeUrls")
+	}
+	nodeUrls := append([]string{config.NodeUrl}, failoverUrls...)
+	switch {
+	case len(nodeUrls) > 1 && httpClient == nil:
+		nodeClient, err = NewMultiNodeClient(nodeUrls, config.ChainId, nodeOptions...)
+	case len(nodeUrls) > 1:
+		nodeClient, err = NewMultiNodeClientWithHttpClient(nodeUrls, config.ChainId, httpClient, nodeOptions...)
+	case httpClient == nil:
+		nodeClient, err = NewNodeClient(config.NodeUrl, config.ChainId, nodeOptions...)
+	default:
+		nodeClient, err = NewNodeClientWithHttpClient(config.NodeUrl, config.ChainId, httpClient, nodeOptions...)
 	}
 	if err != nil {
 		return nil, err
@@ -615,16 +965,77 @@ func (client *Client) RemoveHeader(key string) {
 	client.nodeClient.RemoveHeader(key)
 }
 
+// SetRetryPolicy sets the [RetryPolicy] for all future node requests, nil disables retries
+//
+//	policy := DefaultRetryPolicy()
+//	client.SetRetryPolicy(&policy)
+func (client *Client) SetRetryPolicy(policy *RetryPolicy) {
+	client.nodeClient.SetRetryPolicy(policy)
+}
+
+// EndpointStatus returns the state of each node endpoint when created with [FailoverNodeUrls], nil otherwise
+func (client *Client) EndpointStatus() []NodeEndpointStatus {
+	return client.nodeClient.EndpointStatus()
+}
+
+// CheckEndpointHealth probes every node endpoint, marking failing ones as unhealthy, see
+// [NodeClient.CheckEndpointHealth]
+func (client *Client) CheckEndpointHealth(ctx context.Context) error {
+	return client.nodeClient.CheckEndpointHealth(ctx)
+}
+
+// MonitorEndpointHealth probes every node endpoint each interval until ctx is done, see
+// [NodeClient.MonitorEndpointHealth]
+func (client *Client) MonitorEndpointHealth(ctx context.Context, interval time.Duration) {
+	client.nodeClient.MonitorEndpointHealth(ctx, interval)
+}
+
+// AtVersion returns a [SnapshotClient] with every node read pinned to ledger version, see [NodeClient.AtVersion]
+//
+//	info, _ := client.Info()
+//	snapshot, err := client.AtVersion(info.LedgerVersion())
+func (client *Client) AtVersion(version uint64) (*SnapshotClient, error) {
+	return client.nodeClient.AtVersion(version)
+}
+
+// AtVersionCtx is [Client.AtVersion] with its requests bound to ctx
+func (client *Client) AtVersionCtx(ctx context.Context, version uint64) (*SnapshotClient, error) {
+	return client.nodeClient.AtVersionCtx(ctx, version)
+}
+
 // Info Retrieves the node info about the network and it's current state
 func (client *Client) Info() (NodeInfo, error) {
 	return client.nodeClient.Info()
 }
 
+// InfoCtx is [Client.Info] with its requests bound to ctx
+func (client *Client) InfoCtx(ctx context.Context) (NodeInfo, error) {
+	return client.nodeClient.InfoCtx(ctx)
+}
+
 // Account Retrieves information about the account such as [SequenceNumber] and [crypto.AuthenticationKey]
 func (client *Client) Account(address AccountAddress, ledgerVersion ...uint64) (AccountInfo, error) {
 	return client.nodeClient.Account(address, ledgerVersion...)
 }
 
+// AccountCtx is [Client.Account] with its requests bound to ctx
+func (client *Client) AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (AccountInfo, error) {
+	return client.nodeClient.AccountCtx(ctx, address, ledgerVersion...)
+}
+
+// AccountWithResponseInfo is [Client.Account], additionally returning the ledger metadata of the response
+//
+//	info, responseInfo, _ := client.AccountWithResponseInfo(address)
+//	fmt.Printf("sequence number read at version %d\n", responseInfo.LedgerVersion)
+func (client *Client) AccountWithResponseInfo(address AccountAddress, ledgerVersion ...uint64) (AccountInfo, *ResponseInfo, error) {
+	return client.nodeClient.AccountWithResponseInfo(address, ledgerVersion...)
+}
+
+// AccountWithResponseInfoCtx is [Client.AccountWithResponseInfo] with its requests bound to ctx
+func (client *Client) AccountWithResponseInfoCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (AccountInfo, *ResponseInfo, error) {
+	return client.nodeClient.AccountWithResponseInfoCtx(ctx, address, ledgerVersion...)
+}
+
 // AccountResource Retrieves a single resource given its struct name.
 //
 //	address := AccountOne
@@ -638,6 +1049,22 @@ func (client *Client) AccountResource(address AccountAddress, resourceType strin
 	return client.nodeClient.AccountResource(address, resourceType, ledgerVersion...)
 }
 
+// AccountResourceCtx is [Client.AccountResource] with its requests bound to ctx
+func (client *Client) AccountResourceCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, error) {
+	return client.nodeClient.AccountResourceCtx(ctx, address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceWithResponseInfo is [Client.AccountResource], additionally returning the ledger metadata of the
+// response
+func (client *Client) AccountResourceWithResponseInfo(address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, *ResponseInfo, error) {
+	return client.nodeClient.AccountResourceWithResponseInfo(address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceWithResponseInfoCtx is [Client.AccountResourceWithResponseInfo] with its requests bound to ctx
+func (client *Client) AccountResourceWithResponseInfoCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, *ResponseInfo, error) {
+	return client.nodeClient.AccountResourceWithResponseInfoCtx(ctx, address, resourceType, ledgerVersion...)
+}
+
 // AccountResources fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
 // For fetching raw Move structs as BCS, See #AccountResourcesBCS
 //
@@ -652,11 +1079,44 @@ func (client *Client) AccountResources(address AccountAddress, ledgerVersion ...
 	return client.nodeClient.AccountResources(address, ledgerVersion...)
 }
 
+// AccountResourcesCtx is [Client.AccountResources] with its requests bound to ctx
+func (client *Client) AccountResourcesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceInfo, error) {
+	return client.nodeClient.AccountResourcesCtx(ctx, address, ledgerVersion...)
+}
+
 // AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
 func (client *Client) AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceRecord, error) {
 	return client.nodeClient.AccountResourcesBCS(address, ledgerVersion...)
 }
 
+// AccountResourcesBCSCtx is [Client.AccountResourcesBCS] with its requests bound to ctx
+func (client *Client) AccountResourcesBCSCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceRecord, error) {
+	return client.nodeClient.AccountResourcesBCSCtx(ctx, address, ledgerVersion...)
+}
+
+// GetTableItem fetches an item of a 0x1::table::Table by its key, decoded into JSON-like data
+//
+//	value, err := client.GetTableItem(handle, "address", "u64", "0x1")
+func (client *Client) GetTableItem(handle string, keyType string, valueType string, key any, ledgerVersion ...uint64) (any, error) {
+	return client.nodeClient.GetTableItem(handle, keyType, valueType, key, ledgerVersion...)
+}
+
+// GetTableItemCtx is [Client.GetTableItem] with its requests bound to ctx
+func (client *Client) GetTableItemCtx(ctx context.Context, handle string, keyType string, valueType string, key any, ledgerVersion ...uint64) (any, error) {
+	return client.nodeClient.GetTableItemCtx(ctx, handle, keyType, valueType, key, ledgerVersion...)
+}
+
+// GetRawTableItem fetches an item of a 0x1::table::Table by its BCS encoded key, returning the BCS encoded value.
+// See [GetTableItemBCS] to decode the value into a type.
+func (client *Client) GetRawTableItem(handle string, key []byte, ledgerVersion ...uint64) ([]byte, error) {
+	return client.nodeClient.GetRawTableItem(handle, key, ledgerVersion...)
+}
+
+// GetRawTableItemCtx is [Client.GetRawTableItem] with its requests bound to ctx
+func (client *Client) GetRawTableItemCtx(ctx context.Context, handle string, key []byte, ledgerVersion ...uint64) ([]byte, error) {
+	return client.nodeClient.GetRawTableItemCtx(ctx, handle, key, ledgerVersion...)
+}
+
 // BlockByHeight fetches a block by height
 //
 //	block, _ := client.BlockByHeight(1, false)
@@ -668,6 +1128,11 @@ func (client *Client) BlockByHeight(blockHeight uint64, withTransactions bool) (
 	return client.nodeClient.BlockByHeight(blockHeight, withTransactions)
 }
 
+// BlockByHeightCtx is [Client.BlockByHeight] with its requests bound to ctx
+func (client *Client) BlockByHeightCtx(ctx context.Context, blockHeight uint64, withTransactions bool) (*api.Block, error) {
+	return client.nodeClient.BlockByHeightCtx(ctx, blockHeight, withTransactions)
+}
+
 // BlockByVersion fetches a block by ledger version
 //
 //	block, _ := client.BlockByVersion(123, false)
@@ -679,15 +1144,18 @@ func (client *Client) BlockByVersion(ledgerVersion uint64, withTransactions bool
 	return client.nodeClient.BlockByVersion(ledgerVersion, withTransactions)
 }
 
+// BlockByVersionCtx is [Client.BlockByVersion] with its requests bound to ctx
+func (client *Client) BlockByVersionCtx(ctx context.Context, ledgerVersion uint64, withTransactions bool) (*api.Block, error) {
+	return client.nodeClient.BlockByVersionCtx(ctx, ledgerVersion, withTransactions)
+}
+
 // TransactionByHash gets info on a transaction
 // The transaction may be pending or recently committed.
 //
 //	data, err := client.TransactionByHash("0xabcd")
 //	if err != nil {
-//		if httpErr, ok := err.(aptos.HttpError) {
-//			if httpErr.StatusCode == 404 {
-//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-//			}
+//		if errors.Is(err, aptos.ErrTransactionNotFound) {
+//			// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
 //		}
 //	} else {
 //		if data["type"] == "pending_transaction" {
@@ -698,11 +1166,21 @@ func (client *Client) TransactionByHash(txnHash string) (*api.Transaction, error
 	return client.nodeClient.TransactionByHash(txnHash)
 }
 
+// TransactionByHashCtx is [Client.TransactionByHash] with its requests bound to ctx
+func (client *Client) TransactionByHashCtx(ctx context.Context, txnHash string) (*api.Transaction, error) {
+	return client.nodeClient.TransactionByHashCtx(ctx, txnHash)
+}
+
 // WaitTransactionByHash waits for a transaction to complete and returns it's data when finished.
 func (client *Client) WaitTransactionByHash(txnHash string) (*api.Transaction, error) {
 	return client.nodeClient.WaitTransactionByHash(txnHash)
 }
 
+// WaitTransactionByHashCtx is [Client.WaitTransactionByHash] with its requests bound to ctx
+func (client *Client) WaitTransactionByHashCtx(ctx context.Context, txnHash string) (*api.Transaction, error) {
+	return client.nodeClient.WaitTransactionByHashCtx(ctx, txnHash)
+}
+
 // TransactionByVersion gets info on a transaction from its LedgerVersion.  It must have been
 // committed to have a ledger version
 //
@@ -718,11 +1196,21 @@ func (client *Client) TransactionByVersion(version uint64) (*api.CommittedTransa
 	return client.nodeClient.TransactionByVersion(version)
 }
 
+// TransactionByVersionCtx is [Client.TransactionByVersion] with its requests bound to ctx
+func (client *Client) TransactionByVersionCtx(ctx context.Context, version uint64) (*api.CommittedTransaction, error) {
+	return client.nodeClient.TransactionByVersionCtx(ctx, version)
+}
+
 // PollForTransaction Waits up to 10 seconds for as single transaction to be done, polling at 10Hz
 func (client *Client) PollForTransaction(hash string, options ...any) (*api.UserTransaction, error) {
 	return client.nodeClient.PollForTransaction(hash, options...)
 }
 
+// PollForTransactionCtx is [Client.PollForTransaction] with its requests bound to ctx
+func (client *Client) PollForTransactionCtx(ctx context.Context, hash string, options ...any) (*api.UserTransaction, error) {
+	return client.nodeClient.PollForTransactionCtx(ctx, hash, options...)
+}
+
 // PollForTransactions Waits up to 10 seconds for transactions to be done, polling at 10Hz
 // Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
 //
@@ -737,6 +1225,11 @@ func (client *Client) PollForTransactions(txnHashes []string, options ...any) er
 	return client.nodeClient.PollForTransactions(txnHashes, options...)
 }
 
+// PollForTransactionsCtx is [Client.PollForTransactions] with its requests bound to ctx
+func (client *Client) PollForTransactionsCtx(ctx context.Context, txnHashes []string, options ...any) error {
+	return client.nodeClient.PollForTransactionsCtx(ctx, txnHashes, options...)
+}
+
 // WaitForTransaction Do a long-GET for one transaction and wait for it to complete
 //
 //	data, err := client.WaitForTransaction("0x1234")
@@ -744,6 +1237,11 @@ func (client *Client) WaitForTransaction(txnHash string, options ...any) (*api.U
 	return client.nodeClient.WaitForTransaction(txnHash, options...)
 }
 
+// WaitForTransactionCtx is [Client.WaitForTransaction] with its requests bound to ctx
+func (client *Client) WaitForTransactionCtx(ctx context.Context, txnHash string, options ...any) (*api.UserTransaction, error) {
+	return client.nodeClient.WaitForTransactionCtx(ctx, txnHash, options...)
+}
+
 // Transactions Get recent transactions.
 // Start is a version number. Nil for most recent transactions.
 // Limit is a number of transactions to return. 'about a hundred' by default.
@@ -754,6 +1252,11 @@ func (client *Client) Transactions(start *uint64, limit *uint64) ([]*api.Committ
 	return client.nodeClient.Transactions(start, limit)
 }
 
+// TransactionsCtx is [Client.Transactions] with its requests bound to ctx
+func (client *Client) TransactionsCtx(ctx context.Context, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	return client.nodeClient.TransactionsCtx(ctx, start, limit)
+}
+
 // AccountTransactions Get transactions associated with an account.
 // Start is a version number. Nil for most recent transactions.
 // Limit is a number of transactions to return. 'about a hundred' by default.
@@ -764,6 +1267,11 @@ func (client *Client) AccountTransactions(address AccountAddress, start *uint64,
 	return client.nodeClient.AccountTransactions(address, start, limit)
 }
 
+// AccountTransactionsCtx is [Client.AccountTransactions] with its requests bound to ctx
+func (client *Client) AccountTransactionsCtx(ctx context.Context, address AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	return client.nodeClient.AccountTransactionsCtx(ctx, address, start, limit)
+}
+
 // EventsByHandle Get events by handle and field name for an account.
 // Start is a sequence number. Nil for most recent events.
 // Limit is a number of events to return, 100 by default.
@@ -774,6 +1282,11 @@ func (client *Client) EventsByHandle(account AccountAddress, eventHandle string,
 	return client.nodeClient.EventsByHandle(account, eventHandle, fieldName, start, limit)
 }
 
+// EventsByHandleCtx is [Client.EventsByHandle] with its requests bound to ctx
+func (client *Client) EventsByHandleCtx(ctx context.Context, account AccountAddress, eventHandle string, fieldName string, start *uint64, limit *uint64) ([]*api.Event, error) {
+	return client.nodeClient.EventsByHandleCtx(ctx, account, eventHandle, fieldName, start, limit)
+}
+
 // EventsByCreationNumber Get events by creation number for an account.
 // Start is a sequence number. Nil for most recent events.
 // Limit is a number of events to return, 100 by default.
@@ -784,6 +1297,90 @@ func (client *Client) EventsByCreationNumber(account AccountAddress, creationNum
 	return client.nodeClient.EventsByCreationNumber(account, creationNumber, start, limit)
 }
 
+// EventsByCreationNumberCtx is [Client.EventsByCreationNumber] with its requests bound to ctx
+func (client *Client) EventsByCreationNumberCtx(ctx context.Context, account AccountAddress, creationNumber string, start *uint64, limit *uint64) ([]*api.Event, error) {
+	return client.nodeClient.EventsByCreationNumberCtx(ctx, account, creationNumber, start, limit)
+}
+
+// TransactionsIter iterates over committed transactions in ascending version order, starting at version start.
+// Pages are fetched lazily, and iteration stops cleanly on break.
+//
+//	for txn, err := range client.TransactionsIter(0) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(txn.Version())
+//	}
+func (client *Client) TransactionsIter(start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return client.nodeClient.TransactionsIter(start)
+}
+
+// TransactionsIterCtx is [Client.TransactionsIter] with its requests bound to ctx
+func (client *Client) TransactionsIterCtx(ctx context.Context, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return client.nodeClient.TransactionsIterCtx(ctx, start)
+}
+
+// AccountTransactionsIter iterates over the transactions sent by an account in ascending sequence number order,
+// starting at sequence number start.  Pages are fetched lazily, and iteration stops cleanly on break.
+//
+//	for txn, err := range client.AccountTransactionsIter(AccountOne, 0) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(txn.Hash())
+//	}
+func (client *Client) AccountTransactionsIter(address AccountAddress, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return client.nodeClient.AccountTransactionsIter(address, start)
+}
+
+// AccountTransactionsIterCtx is [Client.AccountTransactionsIter] with its requests bound to ctx
+func (client *Client) AccountTransactionsIterCtx(ctx context.Context, address AccountAddress, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return client.nodeClient.AccountTransactionsIterCtx(ctx, address, start)
+}
+
+// EventsByHandleIter iterates over the events of an event handle in ascending sequence number order, starting at
+// sequence number start
+func (client *Client) EventsByHandleIter(account AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error] {
+	return client.nodeClient.EventsByHandleIter(account, eventHandle, fieldName, start)
+}
+
+// EventsByHandleIterCtx is [Client.EventsByHandleIter] with its requests bound to ctx
+func (client *Client) EventsByHandleIterCtx(ctx context.Context, account AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error] {
+	return client.nodeClient.EventsByHandleIterCtx(ctx, account, eventHandle, fieldName, start)
+}
+
+// EventsByCreationNumberIter iterates over the events of an event stream in ascending sequence number order, starting
+// at sequence number start
+func (client *Client) EventsByCreationNumberIter(account AccountAddress, creationNumber string, start uint64) iter.Seq2[*api.Event, error] {
+	return client.nodeClient.EventsByCreationNumberIter(account, creationNumber, start)
+}
+
+// EventsByCreationNumberIterCtx is [Client.EventsByCreationNumberIter] with its requests bound to ctx
+func (client *Client) EventsByCreationNumberIterCtx(ctx context.Context, account AccountAddress, creationNumber string, start uint64) iter.Seq2[*api.Event, error] {
+	return client.nodeClient.EventsByCreationNumberIterCtx(ctx, account, creationNumber, start)
+}
+
+// AccountResourcesIter iterates over the resources of an account, following the node's cursor from page to page
+func (client *Client) AccountResourcesIter(address AccountAddress, ledgerVersion ...uint64) iter.Seq2[AccountResourceInfo, error] {
+	return client.nodeClient.AccountResourcesIter(address, ledgerVersion...)
+}
+
+// AccountResourcesIterCtx is [Client.AccountResourcesIter] with its requests bound to ctx
+func (client *Client) AccountResourcesIterCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) iter.Seq2[AccountResourceInfo, error] {
+	return client.nodeClient.AccountResourcesIterCtx(ctx, address, ledgerVersion...)
+}
+
+// AccountModulesIter iterates over the modules of an account with their ABIs, following the node's cursor from page to
+// page
+func (client *Client) AccountModulesIter(address AccountAddress, ledgerVersion ...uint64) iter.Seq2[*api.MoveBytecode, error] {
+	return client.nodeClient.AccountModulesIter(address, ledgerVersion...)
+}
+
+// AccountModulesIterCtx is [Client.AccountModulesIter] with its requests bound to ctx
+func (client *Client) AccountModulesIterCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) iter.Seq2[*api.MoveBytecode, error] {
+	return client.nodeClient.AccountModulesIterCtx(ctx, address, ledgerVersion...)
+}
+
 // SubmitTransaction Submits an already signed transaction to the blockchain
 //
 //	sender := NewEd25519Account()
@@ -808,6 +1405,11 @@ func (client *Client) SubmitTransaction(signedTransaction *SignedTransaction) (*
 	return client.nodeClient.SubmitTransaction(signedTransaction)
 }
 
+// SubmitTransactionCtx is [Client.SubmitTransaction] with its requests bound to ctx
+func (client *Client) SubmitTransactionCtx(ctx context.Context, signedTransaction *SignedTransaction) (*api.SubmitTransactionResponse, error) {
+	return client.nodeClient.SubmitTransactionCtx(ctx, signedTransaction)
+}
+
 // BatchSubmitTransaction submits a collection of signed transactions to the network in a single request
 //
 // It will return the responses in the same order as the input transactions that failed.  If the response is empty, then
@@ -835,6 +1437,11 @@ func (client *Client) BatchSubmitTransaction(signedTxns []*SignedTransaction) (*
 	return client.nodeClient.BatchSubmitTransaction(signedTxns)
 }
 
+// BatchSubmitTransactionCtx is [Client.BatchSubmitTransaction] with its requests bound to ctx
+func (client *Client) BatchSubmitTransactionCtx(ctx context.Context, signedTxns []*SignedTransaction) (*api.BatchSubmitTransactionResponse, error) {
+	return client.nodeClient.BatchSubmitTransactionCtx(ctx, signedTxns)
+}
+
 // SimulateTransaction Simulates a raw transaction without sending it to the blockchain
 //
 //	sender := NewEd25519Account()
@@ -858,22 +1465,42 @@ func (client *Client) SimulateTransaction(rawTxn *RawTransaction, sender Transac
 	return client.nodeClient.SimulateTransaction(rawTxn, sender, options...)
 }
 
+// SimulateTransactionCtx is [Client.SimulateTransaction] with its requests bound to ctx
+func (client *Client) SimulateTransactionCtx(ctx context.Context, rawTxn *RawTransaction, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
+	return client.nodeClient.SimulateTransactionCtx(ctx, rawTxn, sender, options...)
+}
+
 // SimulateTransactionMultiAgent simulates a transaction as fee payer or multi agent
 func (client *Client) SimulateTransactionMultiAgent(rawTxn *RawTransactionWithData, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
 	return client.nodeClient.SimulateTransactionMultiAgent(rawTxn, sender, options...)
 }
 
+// SimulateTransactionMultiAgentCtx is [Client.SimulateTransactionMultiAgent] with its requests bound to ctx
+func (client *Client) SimulateTransactionMultiAgentCtx(ctx context.Context, rawTxn *RawTransactionWithData, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
+	return client.nodeClient.SimulateTransactionMultiAgentCtx(ctx, rawTxn, sender, options...)
+}
+
 // GetChainId Retrieves the ChainId of the network
 // Note this will be cached forever, or taken directly from the config
 func (client *Client) GetChainId() (uint8, error) {
 	return client.nodeClient.GetChainId()
 }
 
+// GetChainIdCtx is [Client.GetChainId] with its requests bound to ctx
+func (client *Client) GetChainIdCtx(ctx context.Context) (uint8, error) {
+	return client.nodeClient.GetChainIdCtx(ctx)
+}
+
 // Fund Uses the faucet to fund an address, only applies to non-production networks
 func (client *Client) Fund(address AccountAddress, amount uint64) error {
 	return client.faucetClient.Fund(address, amount)
 }
 
+// FundCtx is [Client.Fund] with its requests bound to ctx
+func (client *Client) FundCtx(ctx context.Context, address AccountAddress, amount uint64) error {
+	return client.faucetClient.FundCtx(ctx, address, amount)
+}
+
 // BuildTransaction Builds a raw transaction from the payload and fetches any necessary information from on-chain
 //
 //	sender := NewEd25519Account()
@@ -896,6 +1523,11 @@ func (client *Client) BuildTransaction(sender AccountAddress, payload Transactio
 	return client.nodeClient.BuildTransaction(sender, payload, options...)
 }
 
+// BuildTransactionCtx is [Client.BuildTransaction] with its requests bound to ctx
+func (client *Client) BuildTransactionCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransaction, error) {
+	return client.nodeClient.BuildTransactionCtx(ctx, sender, payload, options...)
+}
+
 // BuildTransactionMultiAgent Builds a raw transaction for MultiAgent or FeePayer from the payload and fetches any necessary information from on-chain
 //
 //	sender := NewEd25519Account()
@@ -918,6 +1550,11 @@ func (client *Client) BuildTransactionMultiAgent(sender AccountAddress, payload
 	return client.nodeClient.BuildTransactionMultiAgent(sender, payload, options...)
 }
 
+// BuildTransactionMultiAgentCtx is [Client.BuildTransactionMultiAgent] with its requests bound to ctx
+func (client *Client) BuildTransactionMultiAgentCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransactionWithData, error) {
+	return client.nodeClient.BuildTransactionMultiAgentCtx(ctx, sender, payload, options...)
+}
+
 // BuildSignAndSubmitTransaction Convenience function to do all three in one
 // for more configuration, please use them separately
 //
@@ -941,6 +1578,11 @@ func (client *Client) BuildSignAndSubmitTransaction(sender TransactionSigner, pa
 	return client.nodeClient.BuildSignAndSubmitTransaction(sender, payload, options...)
 }
 
+// BuildSignAndSubmitTransactionCtx is [Client.BuildSignAndSubmitTransaction] with its requests bound to ctx
+func (client *Client) BuildSignAndSubmitTransactionCtx(ctx context.Context, sender TransactionSigner, payload TransactionPayload, options ...any) (*api.SubmitTransactionResponse, error) {
+	return client.nodeClient.BuildSignAndSubmitTransactionCtx(ctx, sender, payload, options...)
+}
+
 // View Runs a view function on chain returning a list of return values.
 //
 //	 address := AccountOne
@@ -959,6 +1601,62 @@ func (client *Client) View(payload *ViewPayload, ledgerVersion ...uint64) ([]any
 	return client.nodeClient.View(payload, ledgerVersion...)
 }
 
+// ViewCtx is [Client.View] with its requests bound to ctx
+func (client *Client) ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	return client.nodeClient.ViewCtx(ctx, payload, ledgerVersion...)
+}
+
+// ViewWithResponseInfo is [Client.View], additionally returning the ledger metadata of the response, including the gas
+// used by the view function
+func (client *Client) ViewWithResponseInfo(payload *ViewPayload, ledgerVersion ...uint64) ([]any, *ResponseInfo, error) {
+	return client.nodeClient.ViewWithResponseInfo(payload, ledgerVersion...)
+}
+
+// ViewWithResponseInfoCtx is [Client.ViewWithResponseInfo] with its requests bound to ctx
+func (client *Client) ViewWithResponseInfoCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, *ResponseInfo, error) {
+	return client.nodeClient.ViewWithResponseInfoCtx(ctx, payload, ledgerVersion...)
+}
+
+// ViewRaw calls a view function, returning each of its return values BCS encoded
+//
+// To decode the values, see [ViewBCS] for a single return value, or [Client.ViewTyped] to decode them by the ABI.
+func (client *Client) ViewRaw(payload *ViewPayload, ledgerVersion ...uint64) ([][]byte, error) {
+	return client.nodeClient.ViewRaw(payload, ledgerVersion...)
+}
+
+// ViewRawCtx is [Client.ViewRaw] with its requests bound to ctx
+func (client *Client) ViewRawCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([][]byte, error) {
+	return client.nodeClient.ViewRawCtx(ctx, payload, ledgerVersion...)
+}
+
+// ViewTyped calls a view function, and decodes its return values from BCS into Go types by the module's ABI.  See
+// [DecodeMoveValue] for the Go type of each Move type.
+//
+//	values, err := client.ViewTyped(payload)
+//	balance := values[0].(uint64)
+func (client *Client) ViewTyped(payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	return client.nodeClient.ViewTyped(payload, ledgerVersion...)
+}
+
+// ViewTypedCtx is [Client.ViewTyped] with its requests bound to ctx
+func (client *Client) ViewTypedCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	return client.nodeClient.ViewTypedCtx(ctx, payload, ledgerVersion...)
+}
+
+// ViewWithArgs calls a view function with simple inputs converted to BCS by the module's ABI, see
+// [ViewFunctionFromAbi], and decodes its return values like [Client.ViewTyped]
+//
+//	values, err := client.ViewWithArgs(AccountOne, "coin", "balance", []any{"0x1::aptos_coin::AptosCoin"}, []any{"0x1"})
+//	balance := values[0].(uint64)
+func (client *Client) ViewWithArgs(moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, ledgerVersion ...uint64) ([]any, error) {
+	return client.nodeClient.ViewWithArgs(moduleAddress, moduleName, functionName, typeArgs, args, ledgerVersion...)
+}
+
+// ViewWithArgsCtx is [Client.ViewWithArgs] with its requests bound to ctx
+func (client *Client) ViewWithArgsCtx(ctx context.Context, moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, ledgerVersion ...uint64) ([]any, error) {
+	return client.nodeClient.ViewWithArgsCtx(ctx, moduleAddress, moduleName, functionName, typeArgs, args, ledgerVersion...)
+}
+
 // ViewWithResponse runs a view function and unmarshals the JSON result into
 // the provided `response` destination.
 //
@@ -983,16 +1681,42 @@ func (client *Client) ViewWithResponse(response any, payload *ViewPayload, ledge
 	return client.nodeClient.ViewWithResponse(response, payload, ledgerVersion...)
 }
 
+// ViewWithResponseCtx is [Client.ViewWithResponse] with its requests bound to ctx
+func (client *Client) ViewWithResponseCtx(ctx context.Context, response any, payload *ViewPayload, ledgerVersion ...uint64) error {
+	return client.nodeClient.ViewWithResponseCtx(ctx, response, payload, ledgerVersion...)
+}
+
 // EstimateGasPrice Retrieves the gas estimate from the network.
 func (client *Client) EstimateGasPrice() (EstimateGasInfo, error) {
 	return client.nodeClient.EstimateGasPrice()
 }
 
+// EstimateGasPriceCtx is [Client.EstimateGasPrice] with its requests bound to ctx
+func (client *Client) EstimateGasPriceCtx(ctx context.Context) (EstimateGasInfo, error) {
+	return client.nodeClient.EstimateGasPriceCtx(ctx)
+}
+
 // AccountAPTBalance retrieves the APT balance in the account
 func (client *Client) AccountAPTBalance(address AccountAddress, ledgerVersion ...uint64) (uint64, error) {
 	return client.nodeClient.AccountAPTBalance(address, ledgerVersion...)
 }
 
+// AccountAPTBalanceCtx is [Client.AccountAPTBalance] with its requests bound to ctx
+func (client *Client) AccountAPTBalanceCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	return client.nodeClient.AccountAPTBalanceCtx(ctx, address, ledgerVersion...)
+}
+
+// AccountAPTBalanceWithResponseInfo is [Client.AccountAPTBalance], additionally returning the ledger metadata of the
+// response
+func (client *Client) AccountAPTBalanceWithResponseInfo(address AccountAddress, ledgerVersion ...uint64) (uint64, *ResponseInfo, error) {
+	return client.nodeClient.AccountAPTBalanceWithResponseInfo(address, ledgerVersion...)
+}
+
+// AccountAPTBalanceWithResponseInfoCtx is [Client.AccountAPTBalanceWithResponseInfo] with its requests bound to ctx
+func (client *Client) AccountAPTBalanceWithResponseInfoCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (uint64, *ResponseInfo, error) {
+	return client.nodeClient.AccountAPTBalanceWithResponseInfoCtx(ctx, address, ledgerVersion...)
+}
+
 // QueryIndexer queries the indexer using GraphQL to fill the `query` struct with data.  See examples in the indexer client on how to make queries
 //
 //	var out []CoinBalance
@@ -1023,25 +1747,80 @@ func (client *Client) QueryIndexer(query any, variables map[string]any, options
 	return client.indexerClient.Query(query, variables, options...)
 }
 
+// QueryIndexerCtx is [Client.QueryIndexer] with its requests bound to ctx
+func (client *Client) QueryIndexerCtx(ctx context.Context, query any, variables map[string]any, options ...graphql.Option) error {
+	return client.indexerClient.QueryIndexerCtx(ctx, query, variables, options...)
+}
+
 // GetProcessorStatus returns the ledger version up to which the processor has processed
 func (client *Client) GetProcessorStatus(processorName string) (uint64, error) {
 	return client.indexerClient.GetProcessorStatus(processorName)
 }
 
+// GetProcessorStatusCtx is [Client.GetProcessorStatus] with its requests bound to ctx
+func (client *Client) GetProcessorStatusCtx(ctx context.Context, processorName string) (uint64, error) {
+	return client.indexerClient.GetProcessorStatusCtx(ctx, processorName)
+}
+
 // GetCoinBalances gets the balances of all coins associated with a given address
 func (client *Client) GetCoinBalances(address AccountAddress) ([]CoinBalance, error) {
 	return client.indexerClient.GetCoinBalances(address)
 }
 
+// GetCoinBalancesCtx is [Client.GetCoinBalances] with its requests bound to ctx
+func (client *Client) GetCoinBalancesCtx(ctx context.Context, address AccountAddress) ([]CoinBalance, error) {
+	return client.indexerClient.GetCoinBalancesCtx(ctx, address)
+}
+
 // NodeAPIHealthCheck checks if the node is within durationSecs of the current time, if not provided the node default is used
 func (client *Client) NodeAPIHealthCheck(durationSecs ...uint64) (api.HealthCheckResponse, error) {
 	return client.nodeClient.NodeAPIHealthCheck(durationSecs...)
 }
 
+// NodeAPIHealthCheckCtx is [Client.NodeAPIHealthCheck] with its requests bound to ctx
+func (client *Client) NodeAPIHealthCheckCtx(ctx context.Context, durationSecs ...uint64) (api.HealthCheckResponse, error) {
+	return client.nodeClient.NodeAPIHealthCheckCtx(ctx, durationSecs...)
+}
+
 func (client *Client) AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (*api.MoveBytecode, error) {
 	return client.nodeClient.AccountModule(address, moduleName, ledgerVersion...)
 }
 
+// AccountModuleCtx is [Client.AccountModule] with its requests bound to ctx
+func (client *Client) AccountModuleCtx(ctx context.Context, address AccountAddress, moduleName string, ledgerVersion ...uint64) (*api.MoveBytecode, error) {
+	return client.nodeClient.AccountModuleCtx(ctx, address, moduleName, ledgerVersion...)
+}
+
+// AccountModules fetches all modules of an account with their bytecode and ABI from on-chain state
+//
+//	modules, _ := client.AccountModules(AccountOne)
+//	for _, module := range modules {
+//		fmt.Println(module.Abi.Name)
+//	}
+func (client *Client) AccountModules(address AccountAddress, ledgerVersion ...uint64) ([]api.MoveBytecode, error) {
+	return client.nodeClient.AccountModules(address, ledgerVersion...)
+}
+
+// AccountModulesCtx is [Client.AccountModules] with its requests bound to ctx
+func (client *Client) AccountModulesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]api.MoveBytecode, error) {
+	return client.nodeClient.AccountModulesCtx(ctx, address, ledgerVersion...)
+}
+
+// AccountModulesBCS fetches all modules of an account as raw bytecode in AccountModuleRecord.Bytecode, without ABIs
+func (client *Client) AccountModulesBCS(address AccountAddress, ledgerVersion ...uint64) ([]AccountModuleRecord, error) {
+	return client.nodeClient.AccountModulesBCS(address, ledgerVersion...)
+}
+
+// AccountModulesBCSCtx is [Client.AccountModulesBCS] with its requests bound to ctx
+func (client *Client) AccountModulesBCSCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountModuleRecord, error) {
+	return client.nodeClient.AccountModulesBCSCtx(ctx, address, ledgerVersion...)
+}
+
 func (client *Client) EntryFunctionWithArgs(address AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, options ...any) (*EntryFunction, error) {
 	return client.nodeClient.EntryFunctionWithArgs(address, moduleName, functionName, typeArgs, args, options...)
 }
+
+// EntryFunctionWithArgsCtx is [Client.EntryFunctionWithArgs] with its requests bound to ctx
+func (client *Client) EntryFunctionWithArgsCtx(ctx context.Context, address AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, options ...any) (*EntryFunction, error) {
+	return client.nodeClient.EntryFunctionWithArgsCtx(ctx, address, moduleName, functionName, typeArgs, args, options...)
+}
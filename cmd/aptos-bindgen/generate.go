@@ -0,0 +1,511 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// generator writes the Go bindings of a single Move module
+type generator struct {
+	abi     *api.MoveModule
+	address aptos.AccountAddress
+	structs map[string]*api.MoveStruct // Structs of the module with bindings, by name
+
+	out        bytes.Buffer
+	usesBig    bool // math/big is imported
+	usesBcs    bool // bcs is imported
+	usesEntry  bool // encodeArgs is needed
+	usesView   bool // decodeValues is needed
+	skipped    []string
+	structDefs bytes.Buffer
+	funcDefs   bytes.Buffer
+}
+
+// moveType is how a Move type is represented in Go
+type moveType struct {
+	goType string
+	encode func(ser string, value string) string  // Statement serializing the addressable value
+	decode func(des string, target string) string // Statement deserializing into the addressable target
+}
+
+// generate writes Go bindings for the module in package packageName, formatted with gofmt
+func generate(abi *api.MoveModule, packageName string) ([]byte, error) {
+	if abi.Address == nil {
+		return nil, fmt.Errorf("module %s ABI has no address", abi.Name)
+	}
+	g := &generator{
+		abi:     abi,
+		address: *abi.Address,
+		structs: map[string]*api.MoveStruct{},
+	}
+	g.bindStructs()
+	for _, moveStruct := range abi.Structs {
+		if g.structs[moveStruct.Name] != nil {
+			g.writeStruct(moveStruct)
+		}
+	}
+	for _, function := range abi.ExposedFunctions {
+		if function.IsEntry {
+			g.writeEntryFunction(function)
+		}
+		if function.IsView {
+			g.writeViewFunction(function)
+		}
+	}
+	g.writeFile(packageName)
+
+	source, err := format.Source(g.out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code for %s does not parse: %w", g.moduleName(), err)
+	}
+	return source, nil
+}
+
+// moduleName is the fully qualified name of the module e.g. 0x1::coin
+func (g *generator) moduleName() string {
+	return g.address.String() + "::" + g.abi.Name
+}
+
+// bindStructs decides which structs get bindings.  A struct is left out if any of its fields can't be represented,
+// which in turn leaves out the structs containing it.
+func (g *generator) bindStructs() {
+	for _, moveStruct := range g.abi.Structs {
+		if !moveStruct.IsNative {
+			g.structs[moveStruct.Name] = moveStruct
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, moveStruct := range g.abi.Structs {
+			if g.structs[moveStruct.Name] == nil {
+				continue
+			}
+			for _, field := range moveStruct.Fields {
+				_, err := g.resolveString(field.Type)
+				if err != nil {
+					delete(g.structs, moveStruct.Name)
+					g.skipped = append(g.skipped, fmt.Sprintf("struct %s: field %s: %s", moveStruct.Name, field.Name, err))
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// resolveString resolves a Move type in string form
+func (g *generator) resolveString(typeStr string) (*moveType, error) {
+	typeTag, err := aptos.ParseTypeTag(typeStr)
+	if err != nil {
+		return nil, err
+	}
+	return g.resolve(*typeTag)
+}
+
+// resolve maps a Move type to its Go representation
+func (g *generator) resolve(typeTag aptos.TypeTag) (*moveType, error) {
+	switch inner := typeTag.Value.(type) {
+	case *aptos.BoolTag:
+		return primitive("bool", "Bool"), nil
+	case *aptos.U8Tag:
+		return primitive("uint8", "U8"), nil
+	case *aptos.U16Tag:
+		return primitive("uint16", "U16"), nil
+	case *aptos.U32Tag:
+		return primitive("uint32", "U32"), nil
+	case *aptos.U64Tag:
+		return primitive("uint64", "U64"), nil
+	case *aptos.U128Tag:
+		g.usesBig = true
+		return primitive("big.Int", "U128"), nil
+	case *aptos.U256Tag:
+		g.usesBig = true
+		return primitive("big.Int", "U256"), nil
+	case *aptos.AddressTag:
+		return structType("aptos.AccountAddress"), nil
+	case *aptos.VectorTag:
+		if _, ok := inner.TypeParam.Value.(*aptos.U8Tag); ok {
+			return &moveType{
+				goType: "[]byte",
+				encode: func(ser string, value string) string { return fmt.Sprintf("%s.WriteBytes(%s)", ser, value) },
+				decode: func(des string, target string) string { return fmt.Sprintf("%s = %s.ReadBytes()", target, des) },
+			}, nil
+		}
+		elem, err := g.resolve(inner.TypeParam)
+		if err != nil {
+			return nil, err
+		}
+		return &moveType{
+			goType: "[]" + elem.goType,
+			encode: func(ser string, value string) string {
+				return fmt.Sprintf("bcs.SerializeSequenceWithFunction(%s, %s, %s)", value, ser, elemEncoder(elem))
+			},
+			decode: func(des string, target string) string {
+				return fmt.Sprintf("%s = bcs.DeserializeSequenceWithFunction(%s, %s)", target, des, elemDecoder(elem))
+			},
+		}, nil
+	case *aptos.StructTag:
+		return g.resolveStruct(inner)
+	case *aptos.GenericTag:
+		return nil, fmt.Errorf("generic type %s is not supported", inner.String())
+	default:
+		return nil, fmt.Errorf("type %s is not supported", typeTag.String())
+	}
+}
+
+// resolveStruct maps the framework's well known structs, and the bound structs of the module
+func (g *generator) resolveStruct(tag *aptos.StructTag) (*moveType, error) {
+	if tag.Address == aptos.AccountOne {
+		switch tag.Module + "::" + tag.Name {
+		case "string::String":
+			return &moveType{
+				goType: "string",
+				encode: func(ser string, value string) string { return fmt.Sprintf("%s.WriteString(%s)", ser, value) },
+				decode: func(des string, target string) string { return fmt.Sprintf("%s = %s.ReadString()", target, des) },
+			}, nil
+		case "object::Object":
+			return structType("aptos.AccountAddress"), nil
+		case "option::Option":
+			if len(tag.TypeParams) != 1 {
+				return nil, fmt.Errorf("option %s must have one type parameter", tag.String())
+			}
+			elem, err := g.resolve(tag.TypeParams[0])
+			if err != nil {
+				return nil, err
+			}
+			return &moveType{
+				goType: "*" + elem.goType,
+				encode: func(ser string, value string) string {
+					return fmt.Sprintf("bcs.SerializeOption(%s, %s, %s)", ser, value, elemEncoder(elem))
+				},
+				decode: func(des string, target string) string {
+					return fmt.Sprintf("%s = bcs.DeserializeOption(%s, %s)", target, des, elemDecoder(elem))
+				},
+			}, nil
+		}
+	}
+	if tag.Address == g.address && tag.Module == g.abi.Name {
+		if g.structs[tag.Name] == nil {
+			return nil, fmt.Errorf("struct %s has no binding", tag.Name)
+		}
+		return structType(goName(tag.Name)), nil
+	}
+	return nil, fmt.Errorf("struct %s from another module is not supported", tag.String())
+}
+
+// primitive is a type serialized by a method of the same name on the serializer and the deserializer
+func primitive(goType string, method string) *moveType {
+	return &moveType{
+		goType: goType,
+		encode: func(ser string, value string) string { return fmt.Sprintf("%s.%s(%s)", ser, method, value) },
+		decode: func(des string, target string) string { return fmt.Sprintf("%s = %s.%s()", target, des, method) },
+	}
+}
+
+// structType is a type implementing [bcs.Struct]
+func structType(goType string) *moveType {
+	return &moveType{
+		goType: goType,
+		encode: func(ser string, value string) string { return fmt.Sprintf("%s.Struct(%s)", ser, addressOf(value)) },
+		decode: func(des string, target string) string { return fmt.Sprintf("%s.Struct(%s)", des, addressOf(target)) },
+	}
+}
+
+// elemEncoder is a function literal serializing an element of a sequence or option
+func elemEncoder(elem *moveType) string {
+	return fmt.Sprintf("func(ser *bcs.Serializer, item %s) { %s }", elem.goType, elem.encode("ser", "item"))
+}
+
+// elemDecoder is a function literal deserializing an element of a sequence or option
+func elemDecoder(elem *moveType) string {
+	return fmt.Sprintf("func(des *bcs.Deserializer, item *%s) { %s }", elem.goType, elem.decode("des", "*item"))
+}
+
+// addressOf takes the address of an addressable expression
+func addressOf(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return expr[1:]
+	}
+	return "&" + expr
+}
+
+// goName converts a Move snake_case identifier to an exported Go CamelCase one
+func goName(name string) string {
+	out := strings.Builder{}
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		out.WriteString(strings.ToUpper(part[:1]))
+		out.WriteString(part[1:])
+	}
+	if out.Len() == 0 {
+		return "X" + name
+	}
+	return out.String()
+}
+
+// writeStruct writes a Go struct with BCS codecs for a bound Move struct
+func (g *generator) writeStruct(moveStruct *api.MoveStruct) {
+	g.usesBcs = true
+	name := goName(moveStruct.Name)
+	fields := make([]*moveType, len(moveStruct.Fields))
+	for i, field := range moveStruct.Fields {
+		// Bound structs only have fields that resolve
+		fields[i], _ = g.resolveString(field.Type)
+	}
+
+	out := &g.structDefs
+	fmt.Fprintf(out, "// %s is the Move struct %s::%s\n", name, g.moduleName(), moveStruct.Name)
+	fmt.Fprintf(out, "type %s struct {\n", name)
+	for i, field := range moveStruct.Fields {
+		fmt.Fprintf(out, "\t%s %s // Move type %s\n", goName(field.Name), fields[i].goType, field.Type)
+	}
+	fmt.Fprintf(out, "}\n\n")
+
+	fmt.Fprintf(out, "// MarshalBCS serializes the struct to BCS\n//\n// Implements:\n//   - [bcs.Marshaler]\n")
+	fmt.Fprintf(out, "func (s *%s) MarshalBCS(ser *bcs.Serializer) {\n", name)
+	for i, field := range moveStruct.Fields {
+		fmt.Fprintf(out, "\t%s\n", fields[i].encode("ser", "s."+goName(field.Name)))
+	}
+	fmt.Fprintf(out, "}\n\n")
+
+	fmt.Fprintf(out, "// UnmarshalBCS deserializes the struct from BCS\n//\n// Implements:\n//   - [bcs.Unmarshaler]\n")
+	fmt.Fprintf(out, "func (s *%s) UnmarshalBCS(des *bcs.Deserializer) {\n", name)
+	for i, field := range moveStruct.Fields {
+		fmt.Fprintf(out, "\t%s\n", fields[i].decode("des", "s."+goName(field.Name)))
+	}
+	fmt.Fprintf(out, "}\n\n")
+}
+
+// functionParams resolves the parameters of a function, skipping leading signers if skipSigners is set
+func (g *generator) functionParams(function *api.MoveFunction, skipSigners bool) ([]*moveType, error) {
+	params := function.Params
+	for skipSigners && len(params) > 0 && (params[0] == "&signer" || params[0] == "signer") {
+		params = params[1:]
+	}
+	types := make([]*moveType, len(params))
+	for i, param := range params {
+		paramType, err := g.resolveString(param)
+		if err != nil {
+			return nil, fmt.Errorf("param %d: %w", i, err)
+		}
+		types[i] = paramType
+	}
+	return types, nil
+}
+
+// writeCallArgs writes the Go parameters of a function call, and the statements encoding them into args
+func writeCallArgs(params []*moveType, numTypeArgs int, signature *strings.Builder, body *strings.Builder) {
+	for i := range numTypeArgs {
+		fmt.Fprintf(signature, ", typeArg%d aptos.TypeTag", i)
+	}
+	for i, param := range params {
+		fmt.Fprintf(signature, ", arg%d %s", i, param.goType)
+	}
+
+	body.WriteString("\targs, err := encodeArgs(\n")
+	for i, param := range params {
+		fmt.Fprintf(body, "\t\tfunc(ser *bcs.Serializer) { %s },\n", param.encode("ser", fmt.Sprintf("arg%d", i)))
+	}
+	body.WriteString("\t)\n")
+}
+
+// typeArgsList is the TypeTag slice literal of a function's type arguments
+func typeArgsList(numTypeArgs int) string {
+	typeArgs := make([]string, numTypeArgs)
+	for i := range numTypeArgs {
+		typeArgs[i] = fmt.Sprintf("typeArg%d", i)
+	}
+	return "[]aptos.TypeTag{" + strings.Join(typeArgs, ", ") + "}"
+}
+
+// moveSignature is the Move signature of a function, for doc comments
+func moveSignature(function *api.MoveFunction) string {
+	out := strings.Builder{}
+	out.WriteString(function.Name)
+	if len(function.GenericTypeParams) > 0 {
+		generics := make([]string, len(function.GenericTypeParams))
+		for i, param := range function.GenericTypeParams {
+			generics[i] = fmt.Sprintf("T%d", i)
+			if len(param.Constraints) > 0 {
+				constraints := make([]string, len(param.Constraints))
+				for j, constraint := range param.Constraints {
+					constraints[j] = string(constraint)
+				}
+				generics[i] += ": " + strings.Join(constraints, " + ")
+			}
+		}
+		fmt.Fprintf(&out, "<%s>", strings.Join(generics, ", "))
+	}
+	fmt.Fprintf(&out, "(%s)", strings.Join(function.Params, ", "))
+	switch len(function.Return) {
+	case 0:
+	case 1:
+		fmt.Fprintf(&out, ": %s", function.Return[0])
+	default:
+		fmt.Fprintf(&out, ": (%s)", strings.Join(function.Return, ", "))
+	}
+	return out.String()
+}
+
+// writeEntryFunction writes a constructor of the [aptos.EntryFunction] calling an entry function
+func (g *generator) writeEntryFunction(function *api.MoveFunction) {
+	params, err := g.functionParams(function, true)
+	if err != nil {
+		g.skipped = append(g.skipped, fmt.Sprintf("entry function %s: %s", function.Name, err))
+		return
+	}
+	g.usesBcs = true
+	g.usesEntry = true
+	numTypeArgs := len(function.GenericTypeParams)
+
+	signature := &strings.Builder{}
+	body := &strings.Builder{}
+	writeCallArgs(params, numTypeArgs, signature, body)
+	body.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	fmt.Fprintf(body, "\treturn &aptos.EntryFunction{\n\t\tModule: Module,\n\t\tFunction: %q,\n\t\tArgTypes: %s,\n\t\tArgs: args,\n\t}, nil\n",
+		function.Name, typeArgsList(numTypeArgs))
+
+	name := "Entry" + goName(function.Name)
+	out := &g.funcDefs
+	fmt.Fprintf(out, "// %s builds a call to the entry function %s::%s\n//\n//\t%s\n", name, g.moduleName(), function.Name, moveSignature(function))
+	fmt.Fprintf(out, "func %s(%s) (*aptos.EntryFunction, error) {\n%s}\n\n", name, strings.TrimPrefix(signature.String(), ", "), body.String())
+}
+
+// writeViewFunction writes a call to a view function, decoding its return values
+func (g *generator) writeViewFunction(function *api.MoveFunction) {
+	params, err := g.functionParams(function, false)
+	if err != nil {
+		g.skipped = append(g.skipped, fmt.Sprintf("view function %s: %s", function.Name, err))
+		return
+	}
+	returns := make([]*moveType, len(function.Return))
+	for i, returnType := range function.Return {
+		returns[i], err = g.resolveString(returnType)
+		if err != nil {
+			g.skipped = append(g.skipped, fmt.Sprintf("view function %s: return %d: %s", function.Name, i, err))
+			return
+		}
+	}
+	g.usesBcs = true
+	g.usesEntry = true
+	g.usesView = true
+	numTypeArgs := len(function.GenericTypeParams)
+
+	signature := &strings.Builder{}
+	signature.WriteString(", ctx context.Context, client aptos.AptosRpcClient")
+	body := &strings.Builder{}
+	results := make([]string, 0, len(returns)+1)
+	returnTypes := make([]string, 0, len(returns)+1)
+	for i, returnType := range returns {
+		fmt.Fprintf(body, "\tvar ret%d %s\n", i, returnType.goType)
+		results = append(results, fmt.Sprintf("ret%d", i))
+		returnTypes = append(returnTypes, returnType.goType)
+	}
+	results = append(results, "err")
+	returnTypes = append(returnTypes, "error")
+	writeCallArgs(params, numTypeArgs, signature, body)
+	signature.WriteString(", ledgerVersion ...uint64")
+	returnAll := "\t\treturn " + strings.Join(results, ", ") + "\n"
+
+	body.WriteString("\tif err != nil {\n" + returnAll + "\t}\n")
+	fmt.Fprintf(body, "\tvalues, err := client.ViewRawCtx(ctx, &aptos.ViewPayload{\n\t\tModule: Module,\n\t\tFunction: %q,\n\t\tArgTypes: %s,\n\t\tArgs: args,\n\t}, ledgerVersion...)\n",
+		function.Name, typeArgsList(numTypeArgs))
+	body.WriteString("\tif err != nil {\n" + returnAll + "\t}\n")
+	body.WriteString("\terr = decodeValues(values,\n")
+	for i, returnType := range returns {
+		fmt.Fprintf(body, "\t\tfunc(des *bcs.Deserializer) { %s },\n", returnType.decode("des", fmt.Sprintf("ret%d", i)))
+	}
+	body.WriteString("\t)\n" + strings.TrimPrefix(returnAll, "\t"))
+
+	name := "View" + goName(function.Name)
+	returnList := strings.Join(returnTypes, ", ")
+	if len(returnTypes) > 1 {
+		returnList = "(" + returnList + ")"
+	}
+	out := &g.funcDefs
+	fmt.Fprintf(out, "// %s calls the view function %s::%s\n//\n//\t%s\n", name, g.moduleName(), function.Name, moveSignature(function))
+	fmt.Fprintf(out, "func %s(%s) %s {\n%s}\n\n", name, strings.TrimPrefix(signature.String(), ", "), returnList, body.String())
+}
+
+// writeFile puts the file together, with only the imports and helpers its bindings use
+func (g *generator) writeFile(packageName string) {
+	out := &g.out
+	fmt.Fprintf(out, "// Code generated by aptos-bindgen from %s. DO NOT EDIT.\n\n", g.moduleName())
+	fmt.Fprintf(out, "// Package %s has Go bindings for the Move module %s\n", packageName, g.moduleName())
+	if len(g.skipped) > 0 {
+		fmt.Fprintf(out, "//\n// Not bound:\n")
+		for _, skipped := range g.skipped {
+			fmt.Fprintf(out, "//   - %s\n", skipped)
+		}
+	}
+	fmt.Fprintf(out, "package %s\n\n", packageName)
+
+	out.WriteString("import (\n")
+	if g.usesView {
+		out.WriteString("\t\"context\"\n")
+	}
+	if g.usesEntry || g.usesView {
+		out.WriteString("\t\"fmt\"\n")
+	}
+	if g.usesBig {
+		out.WriteString("\t\"math/big\"\n")
+	}
+	out.WriteString("\n\t\"github.com/aptos-labs/aptos-go-sdk\"\n")
+	if g.usesBcs {
+		out.WriteString("\t\"github.com/aptos-labs/aptos-go-sdk/bcs\"\n")
+	}
+	out.WriteString(")\n\n")
+
+	fmt.Fprintf(out, "// ModuleAddress is the address the module is published at\n")
+	fmt.Fprintf(out, "var ModuleAddress = func() aptos.AccountAddress {\n\taddress := aptos.AccountAddress{}\n")
+	fmt.Fprintf(out, "\terr := address.ParseStringRelaxed(%q)\n\tif err != nil {\n\t\tpanic(err)\n\t}\n\treturn address\n}()\n\n", g.address.String())
+	fmt.Fprintf(out, "// Module is the id of the module\n")
+	fmt.Fprintf(out, "var Module = aptos.ModuleId{Address: ModuleAddress, Name: %q}\n\n", g.abi.Name)
+
+	out.Write(g.structDefs.Bytes())
+	out.Write(g.funcDefs.Bytes())
+
+	if g.usesEntry {
+		out.WriteString(`// encodeArgs serializes each argument of a function call
+func encodeArgs(encoders ...func(ser *bcs.Serializer)) ([][]byte, error) {
+	args := make([][]byte, len(encoders))
+	for i, encode := range encoders {
+		arg, err := bcs.SerializeSingle(encode)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+`)
+	}
+	if g.usesView {
+		out.WriteString(`// decodeValues deserializes each return value of a view function
+func decodeValues(values [][]byte, decoders ...func(des *bcs.Deserializer)) error {
+	if len(values) != len(decoders) {
+		return fmt.Errorf("expected %d return values, got %d", len(decoders), len(values))
+	}
+	for i, decode := range decoders {
+		des := bcs.NewDeserializer(values[i])
+		decode(des)
+		if des.Error() != nil {
+			return fmt.Errorf("return value %d: %w", i, des.Error())
+		}
+		if des.Remaining() > 0 {
+			return fmt.Errorf("return value %d: remaining %d byte(s)", i, des.Remaining())
+		}
+	}
+	return nil
+}
+`)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestGenerate_Golden(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"coin", "fungible_asset"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			abi, err := readAbi(filepath.Join("testdata", name+".json"))
+			require.NoError(t, err)
+			source, err := generate(abi, defaultPackageName(abi))
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", name+".go.golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, source, 0o600))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(source))
+		})
+	}
+}
+
+func TestGenerate_Unsupported(t *testing.T) {
+	t.Parallel()
+	abi := &api.MoveModule{
+		Address: &aptos.AccountOne,
+		Name:    "example",
+		Structs: []*api.MoveStruct{
+			{Name: "Handle", Fields: []*api.MoveStructField{{Name: "table", Type: "0x1::table::Table<u64, u64>"}}},
+			{Name: "Wrapper", Fields: []*api.MoveStructField{{Name: "handle", Type: "0x1::example::Handle"}}},
+		},
+		ExposedFunctions: []*api.MoveFunction{
+			{Name: "get", IsView: true, Params: []string{"0x1::example::Handle"}, Return: []string{"u64"}},
+			{Name: "set", IsEntry: true, Params: []string{"&signer", "u64"}},
+		},
+	}
+	source, err := generate(abi, "example")
+	require.NoError(t, err)
+	text := string(source)
+	assert.Contains(t, text, "//   - struct Handle:")
+	assert.Contains(t, text, "//   - struct Wrapper:")
+	assert.Contains(t, text, "//   - view function get:")
+	assert.Contains(t, text, "func EntrySet(arg0 uint64)")
+	assert.NotContains(t, text, "type Wrapper struct")
+
+	_, err = readAbi(filepath.Join("testdata", "missing.json"))
+	require.Error(t, err)
+}
@@ -0,0 +1,122 @@
+// aptos-bindgen generates typed Go bindings for a Move module from its ABI.
+//
+// The ABI is read from a JSON file, either the module ABI itself or the node's response for the module with the ABI
+// in "abi", or fetched from a node:
+//
+//	aptos-bindgen -abi coin.json -out coin/coin.go
+//	aptos-bindgen -node https://api.mainnet.aptoslabs.com/v1 -module 0x1::coin -out coin/coin.go
+//
+// The bindings are a Go struct with BCS codecs for each Move struct, a constructor of an [aptos.EntryFunction] for each
+// entry function, and a typed wrapper for each view function.  Structs from other modules, other than the framework's
+// String, Option and Object, can't be represented, and whatever uses them is listed as not bound in the package doc.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+func main() {
+	abiFile := flag.String("abi", "", "JSON file with the module ABI")
+	nodeUrl := flag.String("node", "", "node API URL to fetch the module ABI from, with -module")
+	module := flag.String("module", "", "module to fetch from -node e.g. 0x1::coin")
+	packageName := flag.String("package", "", "Go package name, defaults to the module name")
+	out := flag.String("out", "", "file to write the bindings to, defaults to stdout")
+	flag.Parse()
+
+	err := run(*abiFile, *nodeUrl, *module, *packageName, *out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "aptos-bindgen:", err)
+		os.Exit(1)
+	}
+}
+
+// run loads the ABI, and writes its bindings
+func run(abiFile string, nodeUrl string, module string, packageName string, out string) error {
+	var abi *api.MoveModule
+	var err error
+	switch {
+	case abiFile != "" && nodeUrl == "":
+		abi, err = readAbi(abiFile)
+	case abiFile == "" && nodeUrl != "" && module != "":
+		abi, err = fetchAbi(nodeUrl, module)
+	default:
+		return errors.New("either -abi, or -node and -module are required")
+	}
+	if err != nil {
+		return err
+	}
+
+	if packageName == "" {
+		packageName = defaultPackageName(abi)
+	}
+	source, err := generate(abi, packageName)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	//nolint:gosec // Generated source is meant to be readable like any other
+	return os.WriteFile(out, source, 0o644)
+}
+
+// defaultPackageName is the module name without underscores, as Go package names conventionally have none
+func defaultPackageName(abi *api.MoveModule) string {
+	return strings.ReplaceAll(abi.Name, "_", "")
+}
+
+// readAbi reads a module ABI, or a module with its ABI, from a JSON file
+func readAbi(path string) (*api.MoveModule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	module := &api.MoveBytecode{}
+	err = json.Unmarshal(data, module)
+	if err == nil && module.Abi != nil {
+		return module.Abi, nil
+	}
+	abi := &api.MoveModule{}
+	err = json.Unmarshal(data, abi)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI %s: %w", path, err)
+	}
+	if abi.Name == "" {
+		return nil, fmt.Errorf("ABI %s has no module name", path)
+	}
+	return abi, nil
+}
+
+// fetchAbi fetches a module's ABI from a node, module is of the form address::name
+func fetchAbi(nodeUrl string, module string) (*api.MoveModule, error) {
+	addressStr, name, ok := strings.Cut(module, "::")
+	if !ok {
+		return nil, fmt.Errorf("module %s must be of the form address::name", module)
+	}
+	address := aptos.AccountAddress{}
+	err := address.ParseStringRelaxed(addressStr)
+	if err != nil {
+		return nil, err
+	}
+	client, err := aptos.NewNodeClient(nodeUrl, 0)
+	if err != nil {
+		return nil, err
+	}
+	bytecode, err := client.AccountModule(address, name)
+	if err != nil {
+		return nil, err
+	}
+	if bytecode.Abi == nil {
+		return nil, fmt.Errorf("module %s has no ABI", module)
+	}
+	return bytecode.Abi, nil
+}
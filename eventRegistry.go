@@ -0,0 +1,581 @@
+package aptos
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// ErrEventNotRegistered is returned by [EventRegistry.Decode] for an event type without a registered Go type
+var ErrEventNotRegistered = errors.New("event type not registered")
+
+// EventRegistry decodes the JSON data of [api.Event]s into Go types registered by Move type with [RegisterEvent].
+//
+// A registered type string may have generics, e.g. 0x1::example::Event<T0>, which match any type, with the same
+// generic matching the same type everywhere.  Types without generics take precedence, then generic types are tried in
+// the order they were registered.
+//
+// Each Go struct field is decoded from the Move field with its snake case name, e.g. StorageFeeOctas from
+// storage_fee_octas, or the name of its json tag.  Fields tagged with `json:"-"` are skipped.  The Move JSON values
+// are converted to the Go field types:
+//   - u8 to u256 as any integer type or *big.Int
+//   - address, and 0x1::object::Object<T>, as [AccountAddress]
+//   - vector<u8> as []byte, and other vectors as slices
+//   - 0x1::option::Option<T> as a pointer, nil for none
+//   - structs as Go structs, or as map[string]any
+//   - anything as any, or a type implementing [json.Unmarshaler]
+//
+// A new registry has the common framework events registered, see [NewEventRegistry].  It's safe for concurrent use.
+//
+//	registry := NewEventRegistry()
+//	events, _ := registry.DecodeEvents(userTxn.Events)
+//	for _, event := range events {
+//		if deposit, ok := event.(*FungibleAssetDeposit); ok {
+//			fmt.Println(deposit.Store.String(), deposit.Amount)
+//		}
+//	}
+type EventRegistry struct {
+	mutex    sync.RWMutex
+	exact    map[string]reflect.Type // Go types by canonical Move type string, for types without generics
+	patterns []eventPattern          // Types with generics, in registration order
+}
+
+// eventPattern is a registered Move type with generics
+type eventPattern struct {
+	typeTag TypeTag
+	goType  reflect.Type
+}
+
+// NewEventRegistry creates an [EventRegistry] with the common framework events registered:
+//   - 0x1::coin::DepositEvent as [CoinDepositEvent]
+//   - 0x1::coin::WithdrawEvent as [CoinWithdrawEvent]
+//   - 0x1::coin::CoinDeposit as [CoinDeposit]
+//   - 0x1::coin::CoinWithdraw as [CoinWithdraw]
+//   - 0x1::fungible_asset::Deposit as [FungibleAssetDeposit]
+//   - 0x1::fungible_asset::Withdraw as [FungibleAssetWithdraw]
+//   - 0x1::fungible_asset::Frozen as [FungibleAssetFrozen]
+//   - 0x1::transaction_fee::FeeStatement as [FeeStatement]
+//   - 0x1::object::Transfer and 0x1::object::TransferEvent as [ObjectTransfer]
+//   - 0x1::account::KeyRotation as [KeyRotation]
+func NewEventRegistry() *EventRegistry {
+	registry := &EventRegistry{exact: make(map[string]reflect.Type)}
+	for moveType, goType := range map[string]reflect.Type{
+		"0x1::coin::DepositEvent":            reflect.TypeFor[CoinDepositEvent](),
+		"0x1::coin::WithdrawEvent":           reflect.TypeFor[CoinWithdrawEvent](),
+		"0x1::coin::CoinDeposit":             reflect.TypeFor[CoinDeposit](),
+		"0x1::coin::CoinWithdraw":            reflect.TypeFor[CoinWithdraw](),
+		"0x1::fungible_asset::Deposit":       reflect.TypeFor[FungibleAssetDeposit](),
+		"0x1::fungible_asset::Withdraw":      reflect.TypeFor[FungibleAssetWithdraw](),
+		"0x1::fungible_asset::Frozen":        reflect.TypeFor[FungibleAssetFrozen](),
+		"0x1::transaction_fee::FeeStatement": reflect.TypeFor[FeeStatement](),
+		"0x1::object::Transfer":              reflect.TypeFor[ObjectTransfer](),
+		"0x1::object::TransferEvent":         reflect.TypeFor[ObjectTransfer](),
+		"0x1::account::KeyRotation":          reflect.TypeFor[KeyRotation](),
+	} {
+		err := registry.register(moveType, goType)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// RegisterEvent registers T as the Go type of events of moveType, replacing any previous registration of moveType
+//
+//	type Minted struct {
+//		Recipient AccountAddress
+//		Amount    uint64
+//	}
+//	err := RegisterEvent[Minted](registry, "0xcafe::token::Minted")
+func RegisterEvent[T any](registry *EventRegistry, moveType string) error {
+	return registry.register(moveType, reflect.TypeFor[T]())
+}
+
+// register registers goType as the Go type of events of moveType
+func (registry *EventRegistry) register(moveType string, goType reflect.Type) error {
+	typeTag, err := ParseTypeTag(moveType)
+	if err != nil {
+		return fmt.Errorf("failed to parse event type %s: %w", moveType, err)
+	}
+	if _, ok := typeTag.Value.(*StructTag); !ok {
+		return fmt.Errorf("event type %s is not a struct", moveType)
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if !hasGenerics(*typeTag) {
+		registry.exact[typeTag.String()] = goType
+		return nil
+	}
+	for i, pattern := range registry.patterns {
+		if pattern.typeTag.String() == typeTag.String() {
+			registry.patterns[i].goType = goType
+			return nil
+		}
+	}
+	registry.patterns = append(registry.patterns, eventPattern{typeTag: *typeTag, goType: goType})
+	return nil
+}
+
+// lookup finds the Go type registered for moveType
+func (registry *EventRegistry) lookup(moveType string) (reflect.Type, error) {
+	// A type that can't be parsed can't have been registered either
+	typeTag, err := ParseTypeTag(moveType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrEventNotRegistered, moveType, err)
+	}
+
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	if goType, ok := registry.exact[typeTag.String()]; ok {
+		return goType, nil
+	}
+	for _, pattern := range registry.patterns {
+		if matchTypeTag(pattern.typeTag, *typeTag, make(map[uint64]string)) {
+			return pattern.goType, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrEventNotRegistered, moveType)
+}
+
+// Decode decodes an event into a pointer to its registered Go type, e.g. a *[FungibleAssetDeposit] for a
+// 0x1::fungible_asset::Deposit event.  Returns [ErrEventNotRegistered] if its type isn't registered.
+func (registry *EventRegistry) Decode(event *api.Event) (any, error) {
+	goType, err := registry.lookup(event.Type)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.New(goType)
+	err = decodeEventData(event.Data, value.Elem())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode event %s: %w", event.Type, err)
+	}
+	return value.Interface(), nil
+}
+
+// DecodeEvents decodes events, e.g. the Events of an [api.UserTransaction], like [EventRegistry.Decode].  The result
+// has the same length as events, with nil for events whose type isn't registered.
+func (registry *EventRegistry) DecodeEvents(events []*api.Event) ([]any, error) {
+	decoded := make([]any, len(events))
+	for i, event := range events {
+		value, err := registry.Decode(event)
+		if errors.Is(err, ErrEventNotRegistered) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		decoded[i] = value
+	}
+	return decoded, nil
+}
+
+// EventsOfType decodes the events whose type is registered as T, skipping all others
+//
+//	deposits, err := EventsOfType[FungibleAssetDeposit](registry, userTxn.Events)
+func EventsOfType[T any](registry *EventRegistry, events []*api.Event) ([]*T, error) {
+	goType := reflect.TypeFor[T]()
+	decoded := make([]*T, 0)
+	for _, event := range events {
+		eventType, err := registry.lookup(event.Type)
+		if err != nil || eventType != goType {
+			continue
+		}
+		value := new(T)
+		err = decodeEventData(event.Data, reflect.ValueOf(value).Elem())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode event %s: %w", event.Type, err)
+		}
+		decoded = append(decoded, value)
+	}
+	return decoded, nil
+}
+
+// hasGenerics tells if typeTag has any generic e.g. T0
+func hasGenerics(typeTag TypeTag) bool {
+	switch inner := typeTag.Value.(type) {
+	case *GenericTag:
+		return true
+	case *VectorTag:
+		return hasGenerics(inner.TypeParam)
+	case *StructTag:
+		for _, typeParam := range inner.TypeParams {
+			if hasGenerics(typeParam) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchTypeTag tells if actual is an instance of pattern, with the types each generic of pattern is bound to
+func matchTypeTag(pattern TypeTag, actual TypeTag, bound map[uint64]string) bool {
+	switch inner := pattern.Value.(type) {
+	case *GenericTag:
+		if boundType, ok := bound[inner.Num]; ok {
+			return boundType == actual.String()
+		}
+		bound[inner.Num] = actual.String()
+		return true
+	case *VectorTag:
+		actualVector, ok := actual.Value.(*VectorTag)
+		return ok && matchTypeTag(inner.TypeParam, actualVector.TypeParam, bound)
+	case *StructTag:
+		actualStruct, ok := actual.Value.(*StructTag)
+		if !ok || actualStruct.Address != inner.Address || actualStruct.Module != inner.Module ||
+			actualStruct.Name != inner.Name || len(actualStruct.TypeParams) != len(inner.TypeParams) {
+			return false
+		}
+		for i := range inner.TypeParams {
+			if !matchTypeTag(inner.TypeParams[i], actualStruct.TypeParams[i], bound) {
+				return false
+			}
+		}
+		return true
+	default:
+		return pattern.String() == actual.String()
+	}
+}
+
+// decodeEventData decodes an event's data into target, unwrapping data that isn't a struct
+func decodeEventData(data map[string]any, target reflect.Value) error {
+	if value, ok := data[api.AnyDataName]; ok && len(data) == 1 {
+		return decodeMoveJson(value, target)
+	}
+	return decodeMoveJson(data, target)
+}
+
+var (
+	bigIntType      = reflect.TypeFor[big.Int]()
+	addressType     = reflect.TypeFor[AccountAddress]()
+	unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// decodeMoveJson decodes a Move value, as the node represents it in JSON, into target
+func decodeMoveJson(value any, target reflect.Value) error {
+	targetType := target.Type()
+
+	// Objects are addresses wrapped in {"inner": address}
+	if targetType == addressType {
+		if object, ok := value.(map[string]any); ok {
+			value = object["inner"]
+		}
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected address, got %T", value)
+		}
+		address, ok := target.Addr().Interface().(*AccountAddress)
+		if !ok {
+			return fmt.Errorf("unexpected address type %s", targetType)
+		}
+		return address.ParseStringRelaxed(str)
+	}
+	if targetType == bigIntType {
+		integer, ok := target.Addr().Interface().(*big.Int)
+		if !ok {
+			return fmt.Errorf("unexpected integer type %s", targetType)
+		}
+		return decodeMoveBigInt(value, integer)
+	}
+	if reflect.PointerTo(targetType).Implements(unmarshalerType) {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		unmarshaler, ok := target.Addr().Interface().(json.Unmarshaler)
+		if !ok {
+			return fmt.Errorf("unexpected unmarshaler type %s", targetType)
+		}
+		return unmarshaler.UnmarshalJSON(data)
+	}
+
+	switch targetType.Kind() {
+	case reflect.Interface:
+		if value == nil {
+			return nil
+		}
+		if !reflect.TypeOf(value).AssignableTo(targetType) {
+			return fmt.Errorf("can't assign %T to %s", value, targetType)
+		}
+		target.Set(reflect.ValueOf(value))
+		return nil
+	case reflect.Bool:
+		boolean, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", value)
+		}
+		target.SetBool(boolean)
+		return nil
+	case reflect.String:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", value)
+		}
+		target.SetString(str)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		integer, err := decodeMoveUint(value, targetType.Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(integer)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		integer, err := decodeMoveInt(value, targetType.Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(integer)
+		return nil
+	case reflect.Pointer:
+		// Options are {"vec": []} or {"vec": [value]}
+		if object, ok := value.(map[string]any); ok && len(object) == 1 {
+			if vec, ok := object["vec"].([]any); ok {
+				switch len(vec) {
+				case 0:
+					value = nil
+				case 1:
+					value = vec[0]
+				default:
+					return fmt.Errorf("expected option, got vector of length %d", len(vec))
+				}
+			}
+		}
+		if value == nil {
+			target.SetZero()
+			return nil
+		}
+		elem := reflect.New(targetType.Elem())
+		err := decodeMoveJson(value, elem.Elem())
+		if err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	case reflect.Slice:
+		// vector<u8> is hex
+		if targetType.Elem().Kind() == reflect.Uint8 {
+			str, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("expected hex bytes, got %T", value)
+			}
+			bytes, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+			if err != nil {
+				return err
+			}
+			target.SetBytes(bytes)
+			return nil
+		}
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("expected vector, got %T", value)
+		}
+		slice := reflect.MakeSlice(targetType, len(items), len(items))
+		for i, item := range items {
+			err := decodeMoveJson(item, slice.Index(i))
+			if err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		target.Set(slice)
+		return nil
+	case reflect.Map:
+		if targetType.Key().Kind() != reflect.String || targetType.Elem().Kind() != reflect.Interface {
+			return fmt.Errorf("unsupported map type %s", targetType)
+		}
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected struct, got %T", value)
+		}
+		target.Set(reflect.ValueOf(object))
+		return nil
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected struct, got %T", value)
+		}
+		for i := range targetType.NumField() {
+			field := targetType.Field(i)
+			name, ok := moveFieldName(field)
+			if !ok {
+				continue
+			}
+			fieldValue, ok := object[name]
+			if !ok {
+				return fmt.Errorf("missing field %s", name)
+			}
+			err := decodeMoveJson(fieldValue, target.Field(i))
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %s", targetType)
+	}
+}
+
+// moveFieldName is the Move field a Go struct field is decoded from, false if it's skipped
+func moveFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return toSnakeCase(field.Name), true
+	default:
+		return name, true
+	}
+}
+
+// toSnakeCase converts a Go name to snake case e.g. StorageFeeOctas to storage_fee_octas and IOGas to io_gas
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	out := strings.Builder{}
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			startsWord := i > 0 && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+			if startsWord {
+				out.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+// decodeMoveUint decodes an unsigned integer, u64 and larger are strings and smaller ones are numbers
+func decodeMoveUint(value any, bits int) (uint64, error) {
+	switch value := value.(type) {
+	case string:
+		return strconv.ParseUint(value, 10, bits)
+	case float64:
+		integer := uint64(value)
+		if float64(integer) != value || (bits < 64 && integer >= 1<<bits) {
+			return 0, fmt.Errorf("%v out of range for uint%d", value, bits)
+		}
+		return integer, nil
+	default:
+		return 0, fmt.Errorf("expected integer, got %T", value)
+	}
+}
+
+// decodeMoveInt decodes an integer into a signed Go integer
+func decodeMoveInt(value any, bits int) (int64, error) {
+	switch value := value.(type) {
+	case string:
+		return strconv.ParseInt(value, 10, bits)
+	case float64:
+		integer := int64(value)
+		if float64(integer) != value || (bits < 64 && (integer >= 1<<(bits-1) || integer < -(1<<(bits-1)))) {
+			return 0, fmt.Errorf("%v out of range for int%d", value, bits)
+		}
+		return integer, nil
+	default:
+		return 0, fmt.Errorf("expected integer, got %T", value)
+	}
+}
+
+// decodeMoveBigInt decodes an integer of any size
+func decodeMoveBigInt(value any, integer *big.Int) error {
+	switch value := value.(type) {
+	case string:
+		if _, ok := integer.SetString(value, 10); !ok {
+			return fmt.Errorf("invalid integer %s", value)
+		}
+		return nil
+	case float64:
+		uintValue, err := decodeMoveUint(value, 64)
+		if err != nil {
+			return err
+		}
+		integer.SetUint64(uintValue)
+		return nil
+	default:
+		return fmt.Errorf("expected integer, got %T", value)
+	}
+}
+
+// region Framework events
+
+// CoinDepositEvent is the 0x1::coin::DepositEvent handle event, emitted when coins are deposited into a CoinStore
+type CoinDepositEvent struct {
+	Amount uint64 // Amount of coins deposited
+}
+
+// CoinWithdrawEvent is the 0x1::coin::WithdrawEvent handle event, emitted when coins are withdrawn from a CoinStore
+type CoinWithdrawEvent struct {
+	Amount uint64 // Amount of coins withdrawn
+}
+
+// CoinDeposit is the 0x1::coin::CoinDeposit module event, emitted when coins are deposited into a CoinStore
+type CoinDeposit struct {
+	CoinType string         // CoinType is the coin's type e.g. 0x1::aptos_coin::AptosCoin
+	Account  AccountAddress // Account deposited to
+	Amount   uint64         // Amount of coins deposited
+}
+
+// CoinWithdraw is the 0x1::coin::CoinWithdraw module event, emitted when coins are withdrawn from a CoinStore
+type CoinWithdraw struct {
+	CoinType string         // CoinType is the coin's type e.g. 0x1::aptos_coin::AptosCoin
+	Account  AccountAddress // Account withdrawn from
+	Amount   uint64         // Amount of coins withdrawn
+}
+
+// FungibleAssetDeposit is the 0x1::fungible_asset::Deposit module event, emitted when a fungible asset is deposited
+// into a store
+type FungibleAssetDeposit struct {
+	Store  AccountAddress // Store is the address of the FungibleStore object
+	Amount uint64         // Amount deposited
+}
+
+// FungibleAssetWithdraw is the 0x1::fungible_asset::Withdraw module event, emitted when a fungible asset is
+// withdrawn from a store
+type FungibleAssetWithdraw struct {
+	Store  AccountAddress // Store is the address of the FungibleStore object
+	Amount uint64         // Amount withdrawn
+}
+
+// FungibleAssetFrozen is the 0x1::fungible_asset::Frozen module event, emitted when a store is frozen or unfrozen
+type FungibleAssetFrozen struct {
+	Store  AccountAddress // Store is the address of the FungibleStore object
+	Frozen bool           // Frozen is true if the store is now frozen
+}
+
+// FeeStatement is the 0x1::transaction_fee::FeeStatement module event, the breakdown of a transaction's gas fees
+type FeeStatement struct {
+	TotalChargeGasUnits   uint64 // TotalChargeGasUnits is the total gas units charged
+	ExecutionGasUnits     uint64 // ExecutionGasUnits is the gas units charged for execution
+	IoGasUnits            uint64 // IoGasUnits is the gas units charged for IO
+	StorageFeeOctas       uint64 // StorageFeeOctas is the storage fee charged in octas
+	StorageFeeRefundOctas uint64 // StorageFeeRefundOctas is the storage fee refunded in octas
+}
+
+// ObjectTransfer is the 0x1::object::Transfer module event, and 0x1::object::TransferEvent handle event, emitted when
+// an object's owner changes
+type ObjectTransfer struct {
+	Object AccountAddress // Object is the address of the object
+	From   AccountAddress // From is the previous owner
+	To     AccountAddress // To is the new owner
+}
+
+// KeyRotation is the 0x1::account::KeyRotation module event, emitted when an account's authentication key is rotated
+type KeyRotation struct {
+	Account              AccountAddress // Account whose key was rotated
+	OldAuthenticationKey []byte         // OldAuthenticationKey is the previous authentication key
+	NewAuthenticationKey []byte         // NewAuthenticationKey is the new authentication key
+}
+
+// endregion
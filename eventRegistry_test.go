@@ -0,0 +1,161 @@
+package aptos
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEventsJson = `[
+  {"type": "0x1::coin::WithdrawEvent", "guid": {"addr": "0x810026ca8291dd88b5b30a1d3ca2edd683d33d06c4a7f7c451d96f6d47bc5e8b", "creation_num": "3"}, "sequence_number": "7", "data": {"amount": "1000"}},
+  {"type": "0x1::fungible_asset::Deposit", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {"store": "0x1234123412341234123412341234123412341234123412341234123412341234", "amount": "1000"}},
+  {"type": "0x1::transaction_fee::FeeStatement", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {"execution_gas_units": "4", "io_gas_units": "3", "storage_fee_octas": "0", "storage_fee_refund_octas": "0", "total_charge_gas_units": "6"}},
+  {"type": "0xcafe::pool::Swapped<0x1::aptos_coin::AptosCoin, 0xcafe::usd::USD>", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {"pool": {"inner": "0xbeef"}, "amount_in": "100", "amount_out": "340282366920938463463374607431768211455", "fee_bps": 30, "memo": {"vec": ["0x6869"]}, "path": ["0x1", "0xcafe"]}},
+  {"type": "0xcafe::pool::Swapped<0x1::aptos_coin::AptosCoin, 0x1::aptos_coin::AptosCoin>", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {"pool": {"inner": "0xbeef"}, "amount_in": "1", "amount_out": "1", "fee_bps": 0, "memo": {"vec": []}, "path": []}},
+  {"type": "0xcafe::pool::Unknown", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {"anything": true}},
+  {"type": "0xcafe::pool::Counter", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": "42"}
+]`
+
+type testSwapped struct {
+	Pool      AccountAddress
+	AmountIn  uint64
+	AmountOut *big.Int
+	FeeBps    uint16
+	Memo      *[]byte
+	Path      []AccountAddress
+	Ignored   string `json:"-"`
+}
+
+type testSameCoinSwapped struct {
+	AmountIn uint64
+}
+
+func testEvents(t *testing.T) []*api.Event {
+	t.Helper()
+	var events []*api.Event
+	require.NoError(t, json.Unmarshal([]byte(testEventsJson), &events))
+	return events
+}
+
+func TestEventRegistry_Decode(t *testing.T) {
+	t.Parallel()
+	registry := NewEventRegistry()
+	require.NoError(t, RegisterEvent[testSwapped](registry, "0xcafe::pool::Swapped<T0, T1>"))
+	require.NoError(t, RegisterEvent[uint64](registry, "0xcafe::pool::Counter"))
+
+	events := testEvents(t)
+	decoded, err := registry.DecodeEvents(events)
+	require.NoError(t, err)
+	require.Len(t, decoded, len(events))
+
+	assert.Equal(t, &CoinWithdrawEvent{Amount: 1000}, decoded[0])
+	deposit, ok := decoded[1].(*FungibleAssetDeposit)
+	require.True(t, ok)
+	assert.Equal(t, "0x1234123412341234123412341234123412341234123412341234123412341234", deposit.Store.String())
+	assert.Equal(t, uint64(1000), deposit.Amount)
+	assert.Equal(t, &FeeStatement{TotalChargeGasUnits: 6, ExecutionGasUnits: 4, IoGasUnits: 3}, decoded[2])
+
+	swapped, ok := decoded[3].(*testSwapped)
+	require.True(t, ok)
+	assert.Equal(t, "0xbeef", swapped.Pool.String())
+	assert.Equal(t, uint64(100), swapped.AmountIn)
+	expectedOut, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	assert.Equal(t, expectedOut, swapped.AmountOut)
+	assert.Equal(t, uint16(30), swapped.FeeBps)
+	require.NotNil(t, swapped.Memo)
+	assert.Equal(t, []byte("hi"), *swapped.Memo)
+	require.Len(t, swapped.Path, 2)
+	assert.Equal(t, AccountOne, swapped.Path[0])
+	assert.Equal(t, "0xcafe", swapped.Path[1].String())
+
+	empty, ok := decoded[4].(*testSwapped)
+	require.True(t, ok)
+	assert.Nil(t, empty.Memo)
+	assert.Empty(t, empty.Path)
+
+	assert.Nil(t, decoded[5])
+	_, err = registry.Decode(events[5])
+	require.ErrorIs(t, err, ErrEventNotRegistered)
+
+	counter, ok := decoded[6].(*uint64)
+	require.True(t, ok)
+	assert.Equal(t, uint64(42), *counter)
+}
+
+func TestEventRegistry_Generics(t *testing.T) {
+	t.Parallel()
+	events := testEvents(t)
+
+	// The same generic must match the same type, patterns are tried in order
+	registry := NewEventRegistry()
+	require.NoError(t, RegisterEvent[testSameCoinSwapped](registry, "0xcafe::pool::Swapped<T0, T0>"))
+	require.NoError(t, RegisterEvent[testSwapped](registry, "0xcafe::pool::Swapped<T0, T1>"))
+	decoded, err := registry.DecodeEvents(events)
+	require.NoError(t, err)
+	assert.IsType(t, &testSwapped{}, decoded[3])
+	assert.Equal(t, &testSameCoinSwapped{AmountIn: 1}, decoded[4])
+
+	// Types without generics take precedence, whatever the address format
+	require.NoError(t, RegisterEvent[testSwapped](registry,
+		"0x000000000000000000000000000000000000000000000000000000000000cafe::pool::Swapped<0x1::aptos_coin::AptosCoin, 0x1::aptos_coin::AptosCoin>"))
+	decoded, err = registry.DecodeEvents(events)
+	require.NoError(t, err)
+	assert.IsType(t, &testSwapped{}, decoded[4])
+
+	swaps, err := EventsOfType[testSwapped](registry, events)
+	require.NoError(t, err)
+	assert.Len(t, swaps, 2)
+	withdrawals, err := EventsOfType[CoinWithdrawEvent](registry, events)
+	require.NoError(t, err)
+	assert.Equal(t, []*CoinWithdrawEvent{{Amount: 1000}}, withdrawals)
+
+	assert.True(t, matchTypeTag(mustParseTypeTag(t, "0xcafe::pool::Swapped<T0, T0>"),
+		mustParseTypeTag(t, "0xcafe::pool::Swapped<u8, u8>"), make(map[uint64]string)))
+	assert.False(t, matchTypeTag(mustParseTypeTag(t, "0xcafe::pool::Swapped<T0, T0>"),
+		mustParseTypeTag(t, "0xcafe::pool::Swapped<u8, u16>"), make(map[uint64]string)))
+	assert.True(t, matchTypeTag(mustParseTypeTag(t, "0xcafe::pool::Swapped<vector<T0>>"),
+		mustParseTypeTag(t, "0xcafe::pool::Swapped<vector<0x1::string::String>>"), make(map[uint64]string)))
+	assert.False(t, matchTypeTag(mustParseTypeTag(t, "0xcafe::pool::Swapped<vector<T0>>"),
+		mustParseTypeTag(t, "0xcafe::pool::Swapped<u8>"), make(map[uint64]string)))
+}
+
+func TestEventRegistry_Errors(t *testing.T) {
+	t.Parallel()
+	registry := NewEventRegistry()
+	require.Error(t, RegisterEvent[testSwapped](registry, "not a type"))
+	require.Error(t, RegisterEvent[testSwapped](registry, "u64"))
+
+	// Mismatched fields fail to decode
+	require.NoError(t, RegisterEvent[testSwapped](registry, "0x1::coin::WithdrawEvent"))
+	events := testEvents(t)
+	_, err := registry.Decode(events[0])
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrEventNotRegistered)
+	_, err = registry.DecodeEvents(events)
+	require.Error(t, err)
+
+	type tooSmall struct{ Amount uint8 }
+	require.NoError(t, RegisterEvent[tooSmall](registry, "0x1::coin::WithdrawEvent"))
+	_, err = registry.Decode(events[0])
+	require.Error(t, err)
+}
+
+func TestToSnakeCase(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "storage_fee_octas", toSnakeCase("StorageFeeOctas"))
+	assert.Equal(t, "io_gas", toSnakeCase("IOGas"))
+	assert.Equal(t, "amount", toSnakeCase("Amount"))
+	assert.Equal(t, "account_id", toSnakeCase("AccountID"))
+	assert.Equal(t, "value2", toSnakeCase("Value2"))
+}
+
+func mustParseTypeTag(t *testing.T, typeStr string) TypeTag {
+	t.Helper()
+	typeTag, err := ParseTypeTag(typeStr)
+	require.NoError(t, err)
+	return *typeTag
+}
@@ -0,0 +1,348 @@
+package aptos
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// EventHandleSource is an event handle followed by an [EventTailer], given either by EventHandle and FieldName as for
+// [NodeClient.EventsByHandle], or by CreationNumber as for [NodeClient.EventsByCreationNumber]
+type EventHandleSource struct {
+	Account        AccountAddress // Account the event handle is stored under
+	EventHandle    string         // EventHandle is the resource type holding the handle e.g. 0x1::account::Account
+	FieldName      string         // FieldName is the handle's field in the resource e.g. coin_register_events
+	CreationNumber string         // CreationNumber of the handle, instead of EventHandle and FieldName
+}
+
+// key identifies the source in an [EventCursor], <account>/<event handle>/<field name> or <account>/<creation number>
+func (source *EventHandleSource) key() string {
+	if source.CreationNumber != "" {
+		return source.Account.String() + "/" + source.CreationNumber
+	}
+	return source.Account.String() + "/" + source.EventHandle + "/" + source.FieldName
+}
+
+// EventTailerConfig configures an [EventTailer]
+type EventTailerConfig struct {
+	Handles          []EventHandleSource // Handles to follow by sequence number
+	ModuleEventTypes []string            // ModuleEventTypes to find in committed transactions, generics e.g. T0 match any type
+	PageSize         uint64              // Events or transactions fetched per request, at most 100, defaults to 100
+	PollPeriod       time.Duration       // How long to wait for new events once caught up, defaults to 1s
+	MaxBackoff       time.Duration       // Cap on the wait after consecutive failed requests, defaults to 30s
+}
+
+// EventCursor is the position of an [EventTailer], a new tailer given the cursor of the last event processed
+// continues right after it.  It's JSON serializable for persisting.
+type EventCursor struct {
+	// SequenceNumbers is the next sequence number of each handle, keyed by <account>/<event handle>/<field name>, or
+	// <account>/<creation number>.  Missing handles start at 0.
+	SequenceNumbers map[string]uint64 `json:"sequence_numbers"`
+	// Version is the next transaction version to scan for module events
+	Version uint64 `json:"version"`
+	// EventIndex is the number of events of the transaction at Version already scanned
+	EventIndex uint64 `json:"event_index"`
+}
+
+// clone copies the cursor, so it can be handed out while the tailer moves on
+func (cursor *EventCursor) clone() EventCursor {
+	return EventCursor{
+		SequenceNumbers: maps.Clone(cursor.SequenceNumbers),
+		Version:         cursor.Version,
+		EventIndex:      cursor.EventIndex,
+	}
+}
+
+// TailedEvent is an event delivered by an [EventTailer]
+type TailedEvent struct {
+	Event   *api.Event  // Event as returned by the node
+	Version uint64      // Version of the transaction that emitted the event
+	Cursor  EventCursor // Cursor to resume from right after this event
+}
+
+// EventTailer follows V1 events of a set of event handles by sequence number, and V2 module events matching a set of
+// types by scanning committed transactions, delivering each event once.  Once caught up it polls every PollPeriod.
+// Failed requests that may succeed later, see [RetryPolicy], are retried with a backoff doubling up to MaxBackoff,
+// any other error stops the tailer.
+//
+// Events are delivered in transaction version order within each poll, and each carries the [EventCursor] to resume
+// from.  A handle whose resource doesn't exist yet has no events until it does.
+//
+//	tailer, err := NewEventTailer(client, EventTailerConfig{
+//		ModuleEventTypes: []string{"0x1::fungible_asset::Deposit"},
+//	}, EventCursor{Version: startVersion})
+//	for event, err := range tailer.Events(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		process(event.Event)
+//		saveCursor(event.Cursor)
+//	}
+type EventTailer struct {
+	client     AptosRpcClient
+	config     EventTailerConfig
+	eventTypes []TypeTag
+	scanner    *TransactionFollower // Pages through transactions for module events
+
+	streamMutex sync.Mutex // Only one stream at a time
+	cursorMutex sync.Mutex // Guards cursor, which only the stream changes
+	cursor      EventCursor
+}
+
+// tailedEventUpdate is an event found by a poll, with how delivering it moves the cursor
+type tailedEventUpdate struct {
+	event   *api.Event
+	version uint64
+	advance func(cursor *EventCursor)
+}
+
+// NewEventTailer creates an [EventTailer] starting at cursor, a zero cursor starts handles at sequence number 0 and
+// module events at version 0
+func NewEventTailer(client AptosRpcClient, config EventTailerConfig, cursor EventCursor) (*EventTailer, error) {
+	followerConfig := DefaultTransactionFollowerConfig()
+	if config.PageSize != 0 {
+		followerConfig.PageSize = min(config.PageSize, 100)
+	}
+	if config.PollPeriod != 0 {
+		followerConfig.PollPeriod = config.PollPeriod
+	}
+	if config.MaxBackoff != 0 {
+		followerConfig.MaxBackoff = config.MaxBackoff
+	}
+	config.PageSize = followerConfig.PageSize
+	config.PollPeriod = followerConfig.PollPeriod
+	config.MaxBackoff = followerConfig.MaxBackoff
+
+	eventTypes := make([]TypeTag, len(config.ModuleEventTypes))
+	for i, eventType := range config.ModuleEventTypes {
+		typeTag, err := ParseTypeTag(eventType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse event type %s: %w", eventType, err)
+		}
+		eventTypes[i] = *typeTag
+	}
+	if len(config.Handles) == 0 && len(eventTypes) == 0 {
+		return nil, errors.New("event tailer needs handles or module event types to follow")
+	}
+
+	cursor = cursor.clone()
+	if cursor.SequenceNumbers == nil {
+		cursor.SequenceNumbers = make(map[string]uint64)
+	}
+	return &EventTailer{
+		client:     client,
+		config:     config,
+		eventTypes: eventTypes,
+		scanner:    NewTransactionFollower(client, cursor.Version, nil, followerConfig),
+		cursor:     cursor,
+	}, nil
+}
+
+// Cursor returns the position of the tailer, after the last event delivered, or scanned past without a match
+func (et *EventTailer) Cursor() EventCursor {
+	et.cursorMutex.Lock()
+	defer et.cursorMutex.Unlock()
+	return et.cursor.clone()
+}
+
+// Events iterates over events from the tailer's cursor, indefinitely.  The cursor moves past each event as it's
+// yielded.
+//
+// The iteration ends when ctx is done, or after yielding an error that stopped the tailer.
+func (et *EventTailer) Events(ctx context.Context) iter.Seq2[*TailedEvent, error] {
+	return func(yield func(*TailedEvent, error) bool) {
+		et.streamMutex.Lock()
+		defer et.streamMutex.Unlock()
+
+		failures := 0
+		for ctx.Err() == nil {
+			updates, scannedTo, full, err := et.poll(ctx)
+			if err != nil {
+				if !isRetryableError(ctx, err) {
+					yield(nil, err)
+					return
+				}
+				failures++
+				if !sleepCtx(ctx, et.scanner.backoff(failures)) {
+					return
+				}
+				continue
+			}
+			failures = 0
+
+			for _, update := range updates {
+				et.cursorMutex.Lock()
+				update.advance(&et.cursor)
+				cursor := et.cursor.clone()
+				et.cursorMutex.Unlock()
+				if !yield(&TailedEvent{Event: update.event, Version: update.version, Cursor: cursor}, nil) {
+					return
+				}
+			}
+			et.cursorMutex.Lock()
+			if scannedTo > et.cursor.Version {
+				et.cursor.Version = scannedTo
+				et.cursor.EventIndex = 0
+			}
+			et.cursorMutex.Unlock()
+
+			if !full && !sleepCtx(ctx, et.config.PollPeriod) {
+				return
+			}
+		}
+	}
+}
+
+// poll fetches a page of events from each handle and a page of transactions, returning the events found in version
+// order, the version scanned up to, and whether any page was full so there may be more right away.
+//
+// Sources cover different ranges of versions, so only events below the lowest version every source covered are
+// returned, the rest are fetched again by the next poll.
+func (et *EventTailer) poll(ctx context.Context) ([]tailedEventUpdate, uint64, bool, error) {
+	updates := make([]tailedEventUpdate, 0)
+	full := false
+	horizon := uint64(math.MaxUint64)
+	for _, source := range et.config.Handles {
+		handleUpdates, handleFull, err := et.pollHandle(ctx, source)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		updates = append(updates, handleUpdates...)
+		if handleFull {
+			full = true
+			// Later events of the handle may be at any version after the last one
+			horizon = min(horizon, handleUpdates[len(handleUpdates)-1].version+1)
+		}
+	}
+
+	if len(et.eventTypes) > 0 {
+		et.scanner.next = et.cursor.Version
+		txns, err := et.scanner.fetch(ctx)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		scannedTo := et.cursor.Version
+		for _, txn := range txns {
+			version := txn.Version()
+			for i, event := range transactionEvents(txn) {
+				index := uint64(i)
+				if version == et.cursor.Version && index < et.cursor.EventIndex {
+					continue
+				}
+				if !isModuleEvent(event) || !et.matchesEventType(event.Type) {
+					continue
+				}
+				updates = append(updates, tailedEventUpdate{
+					event:   event,
+					version: version,
+					advance: func(cursor *EventCursor) {
+						cursor.Version = version
+						cursor.EventIndex = index + 1
+					},
+				})
+			}
+			scannedTo = version + 1
+		}
+		full = full || uint64(len(txns)) == et.config.PageSize
+		horizon = min(horizon, scannedTo)
+	}
+
+	updates = slices.DeleteFunc(updates, func(update tailedEventUpdate) bool {
+		return update.version >= horizon
+	})
+	slices.SortStableFunc(updates, func(a, b tailedEventUpdate) int {
+		return cmp.Compare(a.version, b.version)
+	})
+	if len(et.eventTypes) == 0 {
+		horizon = et.cursor.Version
+	}
+	return updates, horizon, full, nil
+}
+
+// pollHandle fetches the next page of events of a handle, dropping any already seen, and any after a gap.  It's full if
+// there may be more events right away.
+func (et *EventTailer) pollHandle(ctx context.Context, source EventHandleSource) ([]tailedEventUpdate, bool, error) {
+	key := source.key()
+	start := et.cursor.SequenceNumbers[key]
+	limit := et.config.PageSize
+	var events []*api.Event
+	var err error
+	if source.CreationNumber != "" {
+		events, err = et.client.EventsByCreationNumberCtx(ctx, source.Account, source.CreationNumber, &start, &limit)
+	} else {
+		events, err = et.client.EventsByHandleCtx(ctx, source.Account, source.EventHandle, source.FieldName, &start, &limit)
+	}
+	if errors.Is(err, ErrAccountNotFound) || errors.Is(err, ErrResourceNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	updates := make([]tailedEventUpdate, 0, len(events))
+	expected := start
+	for _, event := range events {
+		if event.SequenceNumber < expected {
+			continue
+		}
+		if event.SequenceNumber > expected {
+			break
+		}
+		sequenceNumber := event.SequenceNumber
+		updates = append(updates, tailedEventUpdate{
+			event:   event,
+			version: event.Version,
+			advance: func(cursor *EventCursor) {
+				cursor.SequenceNumbers[key] = sequenceNumber + 1
+			},
+		})
+		expected++
+	}
+	return updates, len(updates) > 0 && uint64(len(events)) == limit, nil
+}
+
+// matchesEventType tells if an event type matches any of the module event types
+func (et *EventTailer) matchesEventType(eventType string) bool {
+	typeTag, err := ParseTypeTag(eventType)
+	if err != nil {
+		return false
+	}
+	for _, pattern := range et.eventTypes {
+		if matchTypeTag(pattern, *typeTag, make(map[uint64]string)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isModuleEvent tells if an event is a V2 module event, which have no handle
+func isModuleEvent(event *api.Event) bool {
+	return event.Guid == nil || event.Guid.AccountAddress == nil ||
+		(event.Guid.CreationNumber == 0 && *event.Guid.AccountAddress == AccountZero)
+}
+
+// transactionEvents returns the events emitted by a transaction, if its type has any
+func transactionEvents(txn *api.CommittedTransaction) []*api.Event {
+	switch inner := txn.Inner.(type) {
+	case *api.UserTransaction:
+		return inner.Events
+	case *api.GenesisTransaction:
+		return inner.Events
+	case *api.BlockMetadataTransaction:
+		return inner.Events
+	case *api.BlockEpilogueTransaction:
+		return inner.Events
+	case *api.ValidatorTransaction:
+		return inner.Events
+	default:
+		return nil
+	}
+}
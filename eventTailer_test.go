@@ -0,0 +1,163 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEventTailerNode serves transactions up to, but not including, version head, and the events of a handle emitted at
+// every odd version.  Every third transaction has a deposit module event, every fifth a swap module event, and every
+// transaction has a deposit handle event which isn't a module event.
+func newEventTailerNode(t *testing.T, head uint64) (*testNode, *httptest.Server) {
+	t.Helper()
+	node := &testNode{}
+	node.head.Store(head)
+	node.events = func(version uint64) []map[string]any {
+		events := []map[string]any{{
+			"type":            "0x1::fungible_asset::Deposit",
+			"guid":            map[string]any{"creation_number": "3", "account_address": "0x1"},
+			"sequence_number": strconv.FormatUint(version, 10),
+			"data":            map[string]any{"store": "0x1", "amount": "1"},
+		}}
+		if version%3 == 0 {
+			events = append(events, map[string]any{
+				"type":            "0x1::fungible_asset::Deposit",
+				"guid":            map[string]any{"creation_number": "0", "account_address": "0x0"},
+				"sequence_number": "0",
+				"data":            map[string]any{"store": "0x1", "amount": strconv.FormatUint(version, 10)},
+			})
+		}
+		if version%5 == 0 {
+			events = append(events, map[string]any{
+				"type":            "0xcafe::pool::Swapped<0x1::aptos_coin::AptosCoin>",
+				"guid":            map[string]any{"creation_number": "0", "account_address": "0x0"},
+				"sequence_number": "0",
+				"data":            map[string]any{"amount": strconv.FormatUint(version, 10)},
+			})
+		}
+		return events
+	}
+	node.routes = map[string]http.HandlerFunc{
+		"/accounts/0x1/events/0x1::account::Account/coin_register_events": func(w http.ResponseWriter, r *http.Request) {
+			start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+			limit, _ := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+			events := make([]map[string]any, 0, limit)
+			for sequenceNumber := start; sequenceNumber < start+limit && 2*sequenceNumber+1 < node.head.Load(); sequenceNumber++ {
+				events = append(events, map[string]any{
+					"version":         strconv.FormatUint(2*sequenceNumber+1, 10),
+					"type":            "0x1::account::CoinRegisterEvent",
+					"guid":            map[string]any{"creation_number": "0", "account_address": "0x1"},
+					"sequence_number": strconv.FormatUint(sequenceNumber, 10),
+					"data":            map[string]any{},
+				})
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(events))
+		},
+	}
+	return node, newTestNode(t, node)
+}
+
+func TestEventTailer_Events(t *testing.T) {
+	t.Parallel()
+	node, server := newEventTailerNode(t, 20)
+	defer server.Close()
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	config := EventTailerConfig{
+		Handles: []EventHandleSource{
+			{Account: AccountOne, EventHandle: "0x1::account::Account", FieldName: "coin_register_events"},
+			{Account: AccountTwo, CreationNumber: "3"},
+		},
+		ModuleEventTypes: []string{"0x1::fungible_asset::Deposit", "0xcafe::pool::Swapped<T0>"},
+		PageSize:         7,
+		PollPeriod:       time.Millisecond,
+	}
+	tailer, err := NewEventTailer(client, config, EventCursor{})
+	require.NoError(t, err)
+
+	// Events up to version 20 from both sources, then new ones as the chain grows
+	type seen struct {
+		version   uint64
+		eventType string
+	}
+	events := make([]seen, 0)
+	versions := make([]uint64, 0)
+	var last *TailedEvent
+	for event, err := range tailer.Events(context.Background()) {
+		require.NoError(t, err)
+		events = append(events, seen{event.Version, event.Event.Type})
+		versions = append(versions, event.Version)
+		last = event
+		if event.Version == 19 {
+			node.head.Store(31)
+		}
+		if event.Version == 30 {
+			break
+		}
+	}
+	expected := make([]seen, 0)
+	for version := range uint64(31) {
+		if version%3 == 0 {
+			expected = append(expected, seen{version, "0x1::fungible_asset::Deposit"})
+		}
+		if version%5 == 0 {
+			expected = append(expected, seen{version, "0xcafe::pool::Swapped<0x1::aptos_coin::AptosCoin>"})
+		}
+		if version%2 == 1 {
+			expected = append(expected, seen{version, "0x1::account::CoinRegisterEvent"})
+		}
+	}
+	// The iteration stopped before the swap at version 30
+	expected = expected[:len(expected)-1]
+	assert.ElementsMatch(t, expected, events)
+	assert.IsNonDecreasing(t, versions)
+
+	// The cursor of the last event resumes right after it, within the same transaction
+	assert.Equal(t, uint64(30), last.Cursor.Version)
+	assert.Equal(t, uint64(2), last.Cursor.EventIndex)
+	assert.Equal(t, tailer.Cursor(), last.Cursor)
+	data, err := json.Marshal(last.Cursor)
+	require.NoError(t, err)
+	cursor := EventCursor{}
+	require.NoError(t, json.Unmarshal(data, &cursor))
+
+	node.head.Store(32)
+	tailer, err = NewEventTailer(client, config, cursor)
+	require.NoError(t, err)
+	for event, err := range tailer.Events(context.Background()) {
+		require.NoError(t, err)
+		assert.Equal(t, uint64(30), event.Version)
+		assert.Equal(t, "0xcafe::pool::Swapped<0x1::aptos_coin::AptosCoin>", event.Event.Type)
+		break
+	}
+}
+
+func TestEventTailer_Errors(t *testing.T) {
+	t.Parallel()
+	_, err := NewEventTailer(nil, EventTailerConfig{}, EventCursor{})
+	require.Error(t, err)
+	_, err = NewEventTailer(nil, EventTailerConfig{ModuleEventTypes: []string{"not a type"}}, EventCursor{})
+	require.Error(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"bad","error_code":"invalid_input"}`))
+	}))
+	defer server.Close()
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	tailer, err := NewEventTailer(client, EventTailerConfig{ModuleEventTypes: []string{"0x1::coin::CoinDeposit"}}, EventCursor{})
+	require.NoError(t, err)
+	for _, err := range tailer.Events(context.Background()) {
+		require.ErrorIs(t, err, ErrInvalidInput)
+	}
+}
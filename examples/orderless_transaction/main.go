@@ -63,35 +63,24 @@ func example(networkConfig aptos.NetworkConfig) {
 	if err != nil {
 		panic("Failed to serialize transfer amount:" + err.Error())
 	}
-	replayNonce := uint64(100)
-	rawTxn, err := client.BuildTransaction(alice.AccountAddress(), aptos.TransactionPayload{
-		Payload: &aptos.TransactionInnerPayload{
-			Payload: &aptos.TransactionInnerPayloadV1{
-				Executable: aptos.TransactionExecutable{
-					Inner: &aptos.EntryFunction{
-						Module: aptos.ModuleId{
-							Address: aptos.AccountOne,
-							Name:    "aptos_account",
-						},
-						Function: "transfer",
-						ArgTypes: []aptos.TypeTag{},
-						Args: [][]byte{
-							accountBytes,
-							amountBytes,
-						},
-					},
-				},
-				ExtraConfig: aptos.TransactionExtraConfig{
-					Inner: &aptos.TransactionExtraConfigV1{
-						MultisigAddress:       nil,
-						ReplayProtectionNonce: &replayNonce,
-					},
-				},
+	payload := aptos.TransactionPayload{
+		Payload: &aptos.EntryFunction{
+			Module: aptos.ModuleId{
+				Address: aptos.AccountOne,
+				Name:    "aptos_account",
+			},
+			Function: "transfer",
+			ArgTypes: []aptos.TypeTag{},
+			Args: [][]byte{
+				accountBytes,
+				amountBytes,
 			},
 		},
-	},
-		aptos.ExpirationSeconds(60),
-	)
+	}
+
+	// Orderless wraps the payload with a random replay nonce, in place of a sequence number, and expires it within
+	// aptos.MaxOrderlessExpirationSeconds
+	rawTxn, err := client.BuildTransaction(alice.AccountAddress(), payload, aptos.Orderless(true))
 	if err != nil {
 		panic("Failed to build transaction:" + err.Error())
 	}
@@ -144,36 +133,12 @@ func example(networkConfig aptos.NetworkConfig) {
 	fmt.Printf("Alice: %d\n", aliceBalance)
 	fmt.Printf("Bob:%d\n", bobBalance)
 
-	// Now do it again, but with a different method
-	replayNonce2 := uint64(256)
-	resp, err := client.BuildSignAndSubmitTransaction(alice, aptos.TransactionPayload{
-		Payload: &aptos.TransactionInnerPayload{
-			Payload: &aptos.TransactionInnerPayloadV1{
-				Executable: aptos.TransactionExecutable{
-					Inner: &aptos.EntryFunction{
-						Module: aptos.ModuleId{
-							Address: aptos.AccountOne,
-							Name:    "aptos_account",
-						},
-						Function: "transfer",
-						ArgTypes: []aptos.TypeTag{},
-						Args: [][]byte{
-							accountBytes,
-							amountBytes,
-						},
-					},
-				},
-				ExtraConfig: aptos.TransactionExtraConfig{
-					Inner: &aptos.TransactionExtraConfigV1{
-						MultisigAddress:       nil,
-						ReplayProtectionNonce: &replayNonce2,
-					},
-				},
-			},
-		},
-	},
-		aptos.ExpirationSeconds(60),
-	)
+	// Now do it again, but with a different method, and a replay nonce of our own
+	replayNonce, err := aptos.NewReplayNonce()
+	if err != nil {
+		panic("Failed to generate replay nonce:" + err.Error())
+	}
+	resp, err := client.BuildSignAndSubmitTransaction(alice, payload, aptos.ReplayNonce(replayNonce))
 	if err != nil {
 		panic("Failed to sign transaction:" + err.Error())
 	}
@@ -67,12 +67,14 @@ type AptosFungibleAssetClient interface {
 
 // FungibleAssetClient This is an example client around a single fungible asset
 type FungibleAssetClient struct {
-	aptosClient     AptosClient     // Aptos client
+	aptosClient     AptosRpcClient  // Aptos client
 	metadataAddress *AccountAddress // Metadata address of the fungible asset
 }
 
 // NewFungibleAssetClient verifies the [AccountAddress] of the metadata exists when creating the client
-func NewFungibleAssetClient(client AptosClient, metadataAddress *AccountAddress) (*FungibleAssetClient, error) {
+//
+// Passing a [SnapshotClient] pins all of the fungible asset client's reads to its ledger version.
+func NewFungibleAssetClient(client AptosRpcClient, metadataAddress *AccountAddress) (*FungibleAssetClient, error) {
 	// Retrieve the Metadata resource to ensure the fungible asset actually exists
 	_, err := client.AccountResource(*metadataAddress, "0x1::fungible_asset::Metadata")
 	if err != nil {
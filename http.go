@@ -1,15 +1,72 @@
 package aptos
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
 )
 
 // HttpErrSummaryLength is the maximum length of the body to include in the error message
 const HttpErrSummaryLength = 1000
 
+// Sentinel errors for well known node API failures.  They match any [HttpError] whose body decodes to the
+// corresponding [api.Error], so callers can check them with errors.Is
+//
+//	_, err := client.Account(address)
+//	if errors.Is(err, aptos.ErrAccountNotFound) {
+//		// account has not been created on-chain yet
+//	}
+var (
+	ErrAccountNotFound          = errors.New("account not found")
+	ErrResourceNotFound         = errors.New("resource not found")
+	ErrModuleNotFound           = errors.New("module not found")
+	ErrTableItemNotFound        = errors.New("table item not found")
+	ErrTransactionNotFound      = errors.New("transaction not found")
+	ErrBlockNotFound            = errors.New("block not found")
+	ErrVersionNotFound          = errors.New("version not found")
+	ErrVersionPruned            = errors.New("version pruned")
+	ErrBlockPruned              = errors.New("block pruned")
+	ErrInvalidInput             = errors.New("invalid input")
+	ErrSequenceNumberTooOld     = errors.New("sequence number too old")
+	ErrSequenceNumberTooNew     = errors.New("sequence number too new")
+	ErrTransactionExpired       = errors.New("transaction expired")
+	ErrInvalidSignature         = errors.New("invalid signature")
+	ErrInsufficientBalance      = errors.New("insufficient balance for transaction fee")
+	ErrMempoolFull              = errors.New("mempool is full")
+	ErrInvalidTransactionUpdate = errors.New("invalid transaction update")
+)
+
+// errorCodeSentinels maps [api.Error.ErrorCode] to the matching sentinel error
+var errorCodeSentinels = map[string]error{
+	api.ErrorCodeAccountNotFound:          ErrAccountNotFound,
+	api.ErrorCodeResourceNotFound:         ErrResourceNotFound,
+	api.ErrorCodeModuleNotFound:           ErrModuleNotFound,
+	api.ErrorCodeTableItemNotFound:        ErrTableItemNotFound,
+	api.ErrorCodeTransactionNotFound:      ErrTransactionNotFound,
+	api.ErrorCodeBlockNotFound:            ErrBlockNotFound,
+	api.ErrorCodeVersionNotFound:          ErrVersionNotFound,
+	api.ErrorCodeVersionPruned:            ErrVersionPruned,
+	api.ErrorCodeBlockPruned:              ErrBlockPruned,
+	api.ErrorCodeInvalidInput:             ErrInvalidInput,
+	api.ErrorCodeSequenceNumberTooOld:     ErrSequenceNumberTooOld,
+	api.ErrorCodeMempoolIsFull:            ErrMempoolFull,
+	api.ErrorCodeInvalidTransactionUpdate: ErrInvalidTransactionUpdate,
+}
+
+// vmErrorCodeSentinels maps [api.Error.VmErrorCode] of a vm_error to the matching sentinel error
+var vmErrorCodeSentinels = map[uint64]error{
+	api.VmErrorCodeInvalidSignature:                     ErrInvalidSignature,
+	api.VmErrorCodeSequenceNumberTooOld:                 ErrSequenceNumberTooOld,
+	api.VmErrorCodeSequenceNumberTooNew:                 ErrSequenceNumberTooNew,
+	api.VmErrorCodeInsufficientBalanceForTransactionFee: ErrInsufficientBalance,
+	api.VmErrorCodeTransactionExpired:                   ErrTransactionExpired,
+}
+
 // HttpError is an error type that represents an error from a http request
 type HttpError struct {
 	Status     string      // HTTP status e.g. "200 OK"
@@ -18,6 +75,7 @@ type HttpError struct {
 	Method     string      // HTTP method e.g. "GET"
 	RequestUrl url.URL     // URL of the request
 	Body       []byte      // Body of the response
+	ApiError   *api.Error  // Decoded node API error from the Body, nil if the body isn't an API error
 }
 
 // NewHttpError creates a new HttpError from a http.Response
@@ -31,7 +89,17 @@ func NewHttpError(response *http.Response) *HttpError {
 		Body:       body,
 		Method:     response.Request.Method,
 		RequestUrl: *response.Request.URL,
+		ApiError:   decodeApiError(body),
+	}
+}
+
+// decodeApiError decodes a node API error, returning nil if the body is not one
+func decodeApiError(body []byte) *api.Error {
+	apiErr := &api.Error{}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.ErrorCode == "" {
+		return nil
 	}
+	return apiErr
 }
 
 // Error returns a string representation of the HttpError
@@ -52,3 +120,25 @@ func (he *HttpError) Error() string {
 		string(he.Body)[:HttpErrSummaryLength-10], len(he.Body)-(HttpErrSummaryLength-10),
 	)
 }
+
+// Unwrap returns the decoded [api.Error], so it can be retrieved with errors.As
+func (he *HttpError) Unwrap() error {
+	if he.ApiError == nil {
+		return nil
+	}
+	return he.ApiError
+}
+
+// Is matches the sentinel errors e.g. [ErrAccountNotFound] against the decoded [api.Error]
+func (he *HttpError) Is(target error) bool {
+	if he.ApiError == nil {
+		return false
+	}
+	if he.ApiError.ErrorCode == api.ErrorCodeVmError {
+		if sentinel, ok := vmErrorCodeSentinels[he.ApiError.VmErrorCode]; ok && sentinel == target {
+			return true
+		}
+	}
+	sentinel, ok := errorCodeSentinels[he.ApiError.ErrorCode]
+	return ok && sentinel == target
+}
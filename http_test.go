@@ -0,0 +1,79 @@
+package aptos
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpError_ApiErrorSentinels(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		sentinel error
+	}{
+		{"account not found", http.StatusNotFound, `{"message":"Account not found by Address(0x1234) and Ledger version(1)","error_code":"account_not_found","vm_error_code":null}`, ErrAccountNotFound},
+		{"resource not found", http.StatusNotFound, `{"message":"Resource not found","error_code":"resource_not_found"}`, ErrResourceNotFound},
+		{"module not found", http.StatusNotFound, `{"message":"Module not found","error_code":"module_not_found"}`, ErrModuleNotFound},
+		{"transaction not found", http.StatusNotFound, `{"message":"Transaction not found by Transaction hash(0x1)","error_code":"transaction_not_found"}`, ErrTransactionNotFound},
+		{"mempool full", http.StatusInsufficientStorage, `{"message":"Mempool is full","error_code":"mempool_is_full"}`, ErrMempoolFull},
+		{"sequence number too old", http.StatusBadRequest, `{"message":"Invalid transaction: Type: Validation Code: SEQUENCE_NUMBER_TOO_OLD","error_code":"vm_error","vm_error_code":3}`, ErrSequenceNumberTooOld},
+		{"sequence number too old mempool", http.StatusBadRequest, `{"message":"Sequence number too old","error_code":"sequence_number_too_old"}`, ErrSequenceNumberTooOld},
+		{"transaction expired", http.StatusBadRequest, `{"message":"Invalid transaction: Type: Validation Code: TRANSACTION_EXPIRED","error_code":"vm_error","vm_error_code":6}`, ErrTransactionExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewNodeClient(server.URL, 4)
+			require.NoError(t, err)
+
+			_, err = client.Account(AccountOne)
+			require.Error(t, err)
+			require.ErrorIs(t, err, tt.sentinel)
+			assert.NotErrorIs(t, err, ErrBlockNotFound)
+
+			var apiErr *api.Error
+			require.ErrorAs(t, err, &apiErr)
+			assert.NotEmpty(t, apiErr.Message)
+
+			var httpErr *HttpError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, tt.status, httpErr.StatusCode)
+		})
+	}
+}
+
+func TestHttpError_NotApiError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html>bad gateway</html>"))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	_, err = client.Account(AccountOne)
+	require.Error(t, err)
+	var httpErr *HttpError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Nil(t, httpErr.ApiError)
+	assert.Nil(t, errors.Unwrap(httpErr))
+	assert.NotErrorIs(t, err, ErrAccountNotFound)
+}
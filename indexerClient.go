@@ -27,7 +27,12 @@ func NewIndexerClient(httpClient *http.Client, url string) *IndexerClient {
 
 // QueryIndexer is a generic function for making any GraphQL query against the indexer
 func (ic *IndexerClient) QueryIndexer(query any, variables map[string]any, options ...graphql.Option) error {
-	return ic.inner.Query(context.Background(), query, variables, options...)
+	return ic.QueryIndexerCtx(context.Background(), query, variables, options...)
+}
+
+// QueryIndexerCtx is a generic function for making any GraphQL query against the indexer, the request is bound to ctx
+func (ic *IndexerClient) QueryIndexerCtx(ctx context.Context, query any, variables map[string]any, options ...graphql.Option) error {
+	return ic.inner.Query(ctx, query, variables, options...)
 }
 
 // Query is a generic function for making any GraphQL query against the indexer
@@ -37,6 +42,13 @@ func (ic *IndexerClient) Query(query any, variables map[string]any, options ...g
 	return ic.QueryIndexer(query, variables, options...)
 }
 
+// QueryCtx is a generic function for making any GraphQL query against the indexer, the request is bound to ctx
+//
+// Deprecated: please use QueryIndexerCtx as it matches the interface
+func (ic *IndexerClient) QueryCtx(ctx context.Context, query any, variables map[string]any, options ...graphql.Option) error {
+	return ic.QueryIndexerCtx(ctx, query, variables, options...)
+}
+
 type CoinBalance struct {
 	CoinType string
 	Amount   uint64
@@ -44,6 +56,11 @@ type CoinBalance struct {
 
 // GetCoinBalances retrieve the coin balances for all coins owned by the address
 func (ic *IndexerClient) GetCoinBalances(address AccountAddress) ([]CoinBalance, error) {
+	return ic.GetCoinBalancesCtx(context.Background(), address)
+}
+
+// GetCoinBalancesCtx retrieve the coin balances for all coins owned by the address, the request is bound to ctx
+func (ic *IndexerClient) GetCoinBalancesCtx(ctx context.Context, address AccountAddress) ([]CoinBalance, error) {
 	var q struct {
 		CurrentCoinBalances []struct {
 			CoinType     string `graphql:"coin_type"`
@@ -55,7 +72,7 @@ func (ic *IndexerClient) GetCoinBalances(address AccountAddress) ([]CoinBalance,
 	variables := map[string]any{
 		"address": address.StringLong(),
 	}
-	err := ic.Query(&q, variables)
+	err := ic.QueryIndexerCtx(ctx, &q, variables)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +90,11 @@ func (ic *IndexerClient) GetCoinBalances(address AccountAddress) ([]CoinBalance,
 
 // GetProcessorStatus tells the most updated version of the transaction processor.  This helps to determine freshness of data.
 func (ic *IndexerClient) GetProcessorStatus(processorName string) (uint64, error) {
+	return ic.GetProcessorStatusCtx(context.Background(), processorName)
+}
+
+// GetProcessorStatusCtx tells the most updated version of the transaction processor, the request is bound to ctx
+func (ic *IndexerClient) GetProcessorStatusCtx(ctx context.Context, processorName string) (uint64, error) {
 	var q struct {
 		ProcessorStatus []struct {
 			LastSuccessVersion uint64 `graphql:"last_success_version"`
@@ -81,7 +103,7 @@ func (ic *IndexerClient) GetProcessorStatus(processorName string) (uint64, error
 	variables := map[string]any{
 		"processor_name": processorName,
 	}
-	err := ic.Query(&q, variables)
+	err := ic.QueryIndexerCtx(ctx, &q, variables)
 	if err != nil {
 		return 0, err
 	}
@@ -91,12 +113,19 @@ func (ic *IndexerClient) GetProcessorStatus(processorName string) (uint64, error
 
 // WaitOnIndexer waits for the indexer processorName specified to catch up to the requestedVersion
 func (ic *IndexerClient) WaitOnIndexer(processorName string, requestedVersion uint64) error {
+	return ic.WaitOnIndexerCtx(context.Background(), processorName, requestedVersion)
+}
+
+// WaitOnIndexerCtx waits for the indexer processorName specified to catch up to the requestedVersion
+//
+// Waiting stops as soon as ctx is done, and the context's error is returned.
+func (ic *IndexerClient) WaitOnIndexerCtx(ctx context.Context, processorName string, requestedVersion uint64) error {
 	// TODO: add customizable timeout and sleep time
 	const sleepTime = 100 * time.Millisecond
 	const timeout = 5 * time.Second
 	startTime := time.Now()
 	for {
-		version, err := ic.GetProcessorStatus(processorName)
+		version, err := ic.GetProcessorStatusCtx(ctx, processorName)
 		if err != nil {
 			// TODO: This should probably just retry, depending on the error
 			return err
@@ -109,8 +138,12 @@ func (ic *IndexerClient) WaitOnIndexer(processorName string, requestedVersion ui
 			return fmt.Errorf("timeout waiting on requested version.  last version seen: %d requested: %d", version, requestedVersion)
 		}
 
-		// Sleep and try again later
-		time.Sleep(sleepTime)
+		// Sleep and try again later, unless cancelled
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait on indexer cancelled: %w", ctx.Err())
+		case <-time.After(sleepTime):
+		}
 	}
 	return nil
 }
@@ -8,11 +8,14 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"slices"
 	"sort"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/aptos-labs/aptos-go-sdk/api"
@@ -25,6 +28,8 @@ const (
 	DefaultMaxGasAmount      = uint64(100_000) // Default to 0.001 APT max gas amount
 	DefaultGasUnitPrice      = uint64(100)     // Default to min gas price
 	DefaultExpirationSeconds = uint64(300)     // Default to 5 minutes
+
+	DefaultSimulationGasMultiplier = 1.5 // Default to 50% headroom over simulated gas used, see [SimulateMaxGas]
 )
 
 // For Content-Type header when POST-ing a Transaction
@@ -35,16 +40,27 @@ const ContentTypeAptosSignedTxnBcs = "application/x.aptos.signed_transaction+bcs
 // ContentTypeAptosViewFunctionBcs header for sending BCS view function payloads
 const ContentTypeAptosViewFunctionBcs = "application/x.aptos.view_function+bcs"
 
+// ContentTypeJson header for sending JSON request bodies
+const ContentTypeJson = "application/json"
+
 // NodeClient is a client for interacting with an Aptos node API
 type NodeClient struct {
 	client  *http.Client      // HTTP client to use for requests
 	baseUrl *url.URL          // Base URL of the node e.g. https://fullnode.testnet.aptoslabs.com/v1
 	chainId uint8             // Chain ID of the network e.g. 2 for Testnet
 	headers map[string]string // Headers to be added to every transaction
+
+	retryPolicy atomic.Pointer[RetryPolicy] // Retry policy for requests that are safe to repeat, nil for no retries
+	pool        *nodePool                   // Endpoints to route requests across, nil for a single endpoint client
+	abiCache    atomic.Pointer[AbiCache]    // Cache of module ABIs for the ABI driven builders, nil for no caching
 }
 
 // NewNodeClient creates a new client for interacting with an Aptos node API
-func NewNodeClient(rpcUrl string, chainId uint8) (*NodeClient, error) {
+//
+// Accepts options:
+//   - [RetryPolicy]
+//   - [*AbiCache]
+func NewNodeClient(rpcUrl string, chainId uint8, options ...any) (*NodeClient, error) {
 	// Set cookie jar so cookie stickiness applies to connections
 	// TODO Add appropriate suffix list
 	jar, err := cookiejar.New(nil)
@@ -56,21 +72,41 @@ func NewNodeClient(rpcUrl string, chainId uint8) (*NodeClient, error) {
 		Timeout: 60 * time.Second,
 	}
 
-	return NewNodeClientWithHttpClient(rpcUrl, chainId, defaultClient)
+	return NewNodeClientWithHttpClient(rpcUrl, chainId, defaultClient, options...)
 }
 
 // NewNodeClientWithHttpClient creates a new client for interacting with an Aptos node API with a custom http.Client
-func NewNodeClientWithHttpClient(rpcUrl string, chainId uint8, client *http.Client) (*NodeClient, error) {
+//
+// Accepts options:
+//   - [RetryPolicy]
+//   - [*AbiCache]
+func NewNodeClientWithHttpClient(rpcUrl string, chainId uint8, client *http.Client, options ...any) (*NodeClient, error) {
+	var retryPolicy *RetryPolicy
+	var abiCache *AbiCache
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case RetryPolicy:
+			retryPolicy = &value
+		case *AbiCache:
+			abiCache = value
+		default:
+			return nil, fmt.Errorf("NewNodeClient arg %d bad type %T", i+1, arg)
+		}
+	}
+
 	baseUrl, err := url.Parse(rpcUrl)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse RPC url '%s': %w", rpcUrl, err)
 	}
-	return &NodeClient{
+	rc := &NodeClient{
 		client:  client,
 		baseUrl: baseUrl,
 		chainId: chainId,
 		headers: make(map[string]string),
-	}, nil
+	}
+	rc.retryPolicy.Store(retryPolicy)
+	rc.abiCache.Store(abiCache)
+	return rc, nil
 }
 
 // SetTimeout adjusts the HTTP client timeout
@@ -96,7 +132,12 @@ func (rc *NodeClient) RemoveHeader(key string) {
 
 // Info gets general information about the blockchain
 func (rc *NodeClient) Info() (NodeInfo, error) {
-	info, err := Get[NodeInfo](rc, rc.baseUrl.String())
+	return rc.InfoCtx(context.Background())
+}
+
+// InfoCtx gets general information about the blockchain, the request is bound to ctx
+func (rc *NodeClient) InfoCtx(ctx context.Context) (NodeInfo, error) {
+	info, err := GetCtx[NodeInfo](ctx, rc, rc.baseUrl.String())
 	if err != nil {
 		return info, fmt.Errorf("get node info api err: %w", err)
 	}
@@ -110,17 +151,48 @@ func (rc *NodeClient) Info() (NodeInfo, error) {
 //
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
 func (rc *NodeClient) Account(address AccountAddress, ledgerVersion ...uint64) (AccountInfo, error) {
+	return rc.AccountCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountCtx gets information about an account for a given address, the request is bound to ctx
+//
+// Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+func (rc *NodeClient) AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (AccountInfo, error) {
+	info, _, err := rc.accountInner(ctx, address, ledgerVersion)
+	return info, err
+}
+
+// AccountWithResponseInfo is [NodeClient.Account], additionally returning the ledger metadata of the response
+func (rc *NodeClient) AccountWithResponseInfo(address AccountAddress, ledgerVersion ...uint64) (AccountInfo, *ResponseInfo, error) {
+	return rc.AccountWithResponseInfoCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountWithResponseInfoCtx is [NodeClient.AccountCtx], additionally returning the ledger metadata of the response
+func (rc *NodeClient) AccountWithResponseInfoCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (AccountInfo, *ResponseInfo, error) {
+	info, header, err := rc.accountInner(ctx, address, ledgerVersion)
+	if err != nil {
+		return info, nil, err
+	}
+	responseInfo, err := ParseResponseInfo(header)
+	if err != nil {
+		return info, nil, fmt.Errorf("get account info api err: %w", err)
+	}
+	return info, responseInfo, nil
+}
+
+// accountInner fetches the account from the node, returning the response headers for the ledger metadata
+func (rc *NodeClient) accountInner(ctx context.Context, address AccountAddress, ledgerVersion []uint64) (AccountInfo, http.Header, error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String())
 	if len(ledgerVersion) > 0 {
 		params := url.Values{}
 		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
 		au.RawQuery = params.Encode()
 	}
-	info, err := Get[AccountInfo](rc, au.String())
+	info, header, err := getWithHeader[AccountInfo](ctx, rc, au.String())
 	if err != nil {
-		return info, fmt.Errorf("get account info api err: %w", err)
+		return info, nil, fmt.Errorf("get account info api err: %w", err)
 	}
-	return info, nil
+	return info, header, nil
 }
 
 // AccountResource fetches a resource for an account into a JSON-like map[string]any.
@@ -128,6 +200,38 @@ func (rc *NodeClient) Account(address AccountAddress, ledgerVersion ...uint64) (
 //
 // For fetching raw Move structs as BCS, See #AccountResourceBCS
 func (rc *NodeClient) AccountResource(address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, error) {
+	return rc.AccountResourceCtx(context.Background(), address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceCtx fetches a resource for an account into a JSON-like map[string]any, the request is bound to ctx
+// Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+func (rc *NodeClient) AccountResourceCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, error) {
+	data, _, err := rc.accountResourceInner(ctx, address, resourceType, ledgerVersion)
+	return data, err
+}
+
+// AccountResourceWithResponseInfo is [NodeClient.AccountResource], additionally returning the ledger metadata of the
+// response
+func (rc *NodeClient) AccountResourceWithResponseInfo(address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, *ResponseInfo, error) {
+	return rc.AccountResourceWithResponseInfoCtx(context.Background(), address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceWithResponseInfoCtx is [NodeClient.AccountResourceCtx], additionally returning the ledger metadata of
+// the response
+func (rc *NodeClient) AccountResourceWithResponseInfoCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, *ResponseInfo, error) {
+	data, header, err := rc.accountResourceInner(ctx, address, resourceType, ledgerVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	responseInfo, err := ParseResponseInfo(header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get resource api err: %w", err)
+	}
+	return data, responseInfo, nil
+}
+
+// accountResourceInner fetches the resource from the node, returning the response headers for the ledger metadata
+func (rc *NodeClient) accountResourceInner(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion []uint64) (map[string]any, http.Header, error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resource", resourceType)
 	// TODO: offer a list of known-good resourceType string constants
 	if len(ledgerVersion) > 0 {
@@ -135,78 +239,119 @@ func (rc *NodeClient) AccountResource(address AccountAddress, resourceType strin
 		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
 		au.RawQuery = params.Encode()
 	}
-	data, err := Get[map[string]any](rc, au.String())
+	data, header, err := getWithHeader[map[string]any](ctx, rc, au.String())
 	if err != nil {
-		return nil, fmt.Errorf("get resource api err: %w", err)
+		return nil, nil, fmt.Errorf("get resource api err: %w", err)
 	}
-	return data, nil
+	return data, header, nil
 }
 
 // AccountResources fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
 // For fetching raw Move structs as BCS, See #AccountResourcesBCS
 func (rc *NodeClient) AccountResources(address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceInfo, error) {
-	au := rc.baseUrl.JoinPath("accounts", address.String(), "resources")
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
-	resources, err := Get[[]AccountResourceInfo](rc, au.String())
-	if err != nil {
-		return nil, fmt.Errorf("get resources api err: %w", err)
-	}
-	return resources, err
+	return rc.AccountResourcesCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesCtx fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data,
+// the requests are bound to ctx
+// Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+//
+// All pages are fetched, following the node's [CursorHeader], see [NodeClient.AccountResourcesIter] to fetch them lazily.
+func (rc *NodeClient) AccountResourcesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceInfo, error) {
+	return collect(rc.AccountResourcesIterCtx(ctx, address, ledgerVersion...))
 }
 
 // AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
 func (rc *NodeClient) AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceRecord, error) {
+	return rc.AccountResourcesBCSCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesBCSCtx fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte,
+// the requests are bound to ctx
+// Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+//
+// All pages are fetched, following the node's [CursorHeader].
+func (rc *NodeClient) AccountResourcesBCSCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceRecord, error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resources")
+	return getAllBCS[AccountResourceRecord](ctx, rc, au, ledgerVersion...)
+}
+
+// AccountModule fetches a single account module's bytecode and ABI from on-chain state.
+func (rc *NodeClient) AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (*api.MoveBytecode, error) {
+	return rc.AccountModuleCtx(context.Background(), address, moduleName, ledgerVersion...)
+}
+
+// AccountModuleCtx fetches a single account module's bytecode and ABI from on-chain state, the request is bound to ctx
+func (rc *NodeClient) AccountModuleCtx(ctx context.Context, address AccountAddress, moduleName string, ledgerVersion ...uint64) (*api.MoveBytecode, error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "module", moduleName)
 	if len(ledgerVersion) > 0 {
 		params := url.Values{}
 		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
 		au.RawQuery = params.Encode()
 	}
-	blob, err := rc.GetBCS(au.String())
+	data, err := GetCtx[*api.MoveBytecode](ctx, rc, au.String())
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("get module api err: %w", err)
 	}
+	return data, nil
+}
+
+// AccountModules fetches all modules of an account with their bytecode and ABI from on-chain state.
+// Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+//
+// All pages are fetched, following the node's [CursorHeader], see [NodeClient.AccountModulesIter] to fetch them lazily.
+// For fetching the modules as BCS, See [NodeClient.AccountModulesBCS]
+func (rc *NodeClient) AccountModules(address AccountAddress, ledgerVersion ...uint64) ([]api.MoveBytecode, error) {
+	return rc.AccountModulesCtx(context.Background(), address, ledgerVersion...)
+}
 
-	deserializer := bcs.NewDeserializer(blob)
-	// See resource_test.go TestMoveResourceBCS
-	resources := bcs.DeserializeSequence[AccountResourceRecord](deserializer)
-	err = deserializer.Error()
+// AccountModulesCtx is [NodeClient.AccountModules] with its requests bound to ctx
+func (rc *NodeClient) AccountModulesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]api.MoveBytecode, error) {
+	modules, err := collect(rc.AccountModulesIterCtx(ctx, address, ledgerVersion...))
 	if err != nil {
 		return nil, err
 	}
-	return resources, nil
+	out := make([]api.MoveBytecode, len(modules))
+	for i, module := range modules {
+		out[i] = *module
+	}
+	return out, nil
 }
 
-// AccountModule fetches a single account module's bytecode and ABI from on-chain state.
-func (rc *NodeClient) AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (*api.MoveBytecode, error) {
-	au := rc.baseUrl.JoinPath("accounts", address.String(), "module", moduleName)
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
-	data, err := Get[*api.MoveBytecode](rc, au.String())
+// AccountModulesBCS fetches all modules of an account as raw bytecode in AccountModuleRecord.Bytecode, without ABIs
+// Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+func (rc *NodeClient) AccountModulesBCS(address AccountAddress, ledgerVersion ...uint64) ([]AccountModuleRecord, error) {
+	return rc.AccountModulesBCSCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountModulesBCSCtx is [NodeClient.AccountModulesBCS] with its requests bound to ctx
+func (rc *NodeClient) AccountModulesBCSCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountModuleRecord, error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "modules")
+	modules, err := getAllBCS[AccountModuleRecord](ctx, rc, au, ledgerVersion...)
 	if err != nil {
-		return nil, fmt.Errorf("get module api err: %w", err)
+		return nil, fmt.Errorf("get modules api err: %w", err)
 	}
-	return data, nil
+	return modules, nil
 }
 
 // EntryFunctionWithArgs generates an EntryFunction from on-chain Module ABI, and converts simple inputs to BCS encoded ones.
 func (rc *NodeClient) EntryFunctionWithArgs(moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, options ...any) (*EntryFunction, error) {
-	// TODO: This should be cached / we should be able to take in an ABI
-	module, err := rc.AccountModule(moduleAddress, moduleName)
+	return rc.EntryFunctionWithArgsCtx(context.Background(), moduleAddress, moduleName, functionName, typeArgs, args, options...)
+}
+
+// EntryFunctionWithArgsCtx generates an EntryFunction from on-chain Module ABI, and converts simple inputs to BCS encoded ones.
+// The ABI request is bound to ctx
+//
+// The ABI comes from the client's [AbiCache] if it has one.
+func (rc *NodeClient) EntryFunctionWithArgsCtx(ctx context.Context, moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, options ...any) (*EntryFunction, error) {
+	abi, err := rc.moduleAbis(ctx)(ModuleId{Address: moduleAddress, Name: moduleName})
 	if err != nil {
 		return nil, err
 	}
 
-	return EntryFunctionFromAbi(module.Abi, moduleAddress, moduleName, functionName, typeArgs, args, options...)
+	return EntryFunctionFromAbi(abi, moduleAddress, moduleName, functionName, typeArgs, args, options...)
 }
 
 // BlockByVersion gets a block by a transaction's version number
@@ -215,16 +360,26 @@ func (rc *NodeClient) EntryFunctionWithArgs(moduleAddress AccountAddress, module
 //
 // The function will fetch all transactions in the block if withTransactions is true.
 func (rc *NodeClient) BlockByVersion(ledgerVersion uint64, withTransactions bool) (*api.Block, error) {
+	return rc.BlockByVersionCtx(context.Background(), ledgerVersion, withTransactions)
+}
+
+// BlockByVersionCtx gets a block by a transaction's version number, the requests are bound to ctx
+func (rc *NodeClient) BlockByVersionCtx(ctx context.Context, ledgerVersion uint64, withTransactions bool) (*api.Block, error) {
 	restUrl := rc.baseUrl.JoinPath("blocks/by_version", strconv.FormatUint(ledgerVersion, 10))
-	return rc.getBlockCommon(restUrl, withTransactions)
+	return rc.getBlockCommon(ctx, restUrl, withTransactions)
 }
 
 // BlockByHeight gets a block by block height
 //
 // The function will fetch all transactions in the block if withTransactions is true.
 func (rc *NodeClient) BlockByHeight(blockHeight uint64, withTransactions bool) (*api.Block, error) {
+	return rc.BlockByHeightCtx(context.Background(), blockHeight, withTransactions)
+}
+
+// BlockByHeightCtx gets a block by block height, the requests are bound to ctx
+func (rc *NodeClient) BlockByHeightCtx(ctx context.Context, blockHeight uint64, withTransactions bool) (*api.Block, error) {
 	restUrl := rc.baseUrl.JoinPath("blocks/by_height", strconv.FormatUint(blockHeight, 10))
-	return rc.getBlockCommon(restUrl, withTransactions)
+	return rc.getBlockCommon(ctx, restUrl, withTransactions)
 }
 
 // TransactionByHash gets info on a transaction
@@ -233,10 +388,8 @@ func (rc *NodeClient) BlockByHeight(blockHeight uint64, withTransactions bool) (
 //
 //	data, err := c.TransactionByHash("0xabcd")
 //	if err != nil {
-//		if httpErr, ok := err.(aptos.HttpError) {
-//			if httpErr.StatusCode == 404 {
-//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-//			}
+//		if errors.Is(err, aptos.ErrTransactionNotFound) {
+//			// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
 //		}
 //	} else {
 //		if data["type"] == "pending_transaction" {
@@ -244,8 +397,13 @@ func (rc *NodeClient) BlockByHeight(blockHeight uint64, withTransactions bool) (
 //		}
 //	}
 func (rc *NodeClient) TransactionByHash(txnHash string) (*api.Transaction, error) {
+	return rc.TransactionByHashCtx(context.Background(), txnHash)
+}
+
+// TransactionByHashCtx gets info on a transaction, the request is bound to ctx
+func (rc *NodeClient) TransactionByHashCtx(ctx context.Context, txnHash string) (*api.Transaction, error) {
 	restUrl := rc.baseUrl.JoinPath("transactions/by_hash", txnHash)
-	data, err := Get[*api.Transaction](rc, restUrl.String())
+	data, err := GetCtx[*api.Transaction](ctx, rc, restUrl.String())
 	if err != nil {
 		return data, fmt.Errorf("get transaction api err: %w", err)
 	}
@@ -255,8 +413,13 @@ func (rc *NodeClient) TransactionByHash(txnHash string) (*api.Transaction, error
 // WaitTransactionByHash waits for a transaction to be confirmed by its hash.
 // This function allows you to monitor the status of a transaction until it is finalized.
 func (rc *NodeClient) WaitTransactionByHash(txnHash string) (*api.Transaction, error) {
+	return rc.WaitTransactionByHashCtx(context.Background(), txnHash)
+}
+
+// WaitTransactionByHashCtx waits for a transaction to be confirmed by its hash, the long-GET is bound to ctx
+func (rc *NodeClient) WaitTransactionByHashCtx(ctx context.Context, txnHash string) (*api.Transaction, error) {
 	restUrl := rc.baseUrl.JoinPath("transactions/wait_by_hash", txnHash)
-	data, err := Get[*api.Transaction](rc, restUrl.String())
+	data, err := GetCtx[*api.Transaction](ctx, rc, restUrl.String())
 	if err != nil {
 		return data, fmt.Errorf("get transaction api err: %w", err)
 	}
@@ -266,8 +429,13 @@ func (rc *NodeClient) WaitTransactionByHash(txnHash string) (*api.Transaction, e
 // TransactionByVersion gets info on a transaction by version number
 // The transaction will have been committed.  The response will not be of the type [api.PendingTransaction].
 func (rc *NodeClient) TransactionByVersion(version uint64) (*api.CommittedTransaction, error) {
+	return rc.TransactionByVersionCtx(context.Background(), version)
+}
+
+// TransactionByVersionCtx gets info on a transaction by version number, the request is bound to ctx
+func (rc *NodeClient) TransactionByVersionCtx(ctx context.Context, version uint64) (*api.CommittedTransaction, error) {
 	restUrl := rc.baseUrl.JoinPath("transactions/by_version", strconv.FormatUint(version, 10))
-	data, err := Get[*api.CommittedTransaction](rc, restUrl.String())
+	data, err := GetCtx[*api.CommittedTransaction](ctx, rc, restUrl.String())
 	if err != nil {
 		return data, fmt.Errorf("get transaction api err: %w", err)
 	}
@@ -277,13 +445,13 @@ func (rc *NodeClient) TransactionByVersion(version uint64) (*api.CommittedTransa
 // getBlockCommon is a helper function for fetching a block by version or height
 //
 // It will fetch all the transactions associated with the block if withTransactions is true.
-func (rc *NodeClient) getBlockCommon(restUrl *url.URL, withTransactions bool) (*api.Block, error) {
+func (rc *NodeClient) getBlockCommon(ctx context.Context, restUrl *url.URL, withTransactions bool) (*api.Block, error) {
 	params := url.Values{}
 	params.Set("with_transactions", strconv.FormatBool(withTransactions))
 	restUrl.RawQuery = params.Encode()
 
 	// Fetch block
-	block, err := Get[*api.Block](rc, restUrl.String())
+	block, err := GetCtx[*api.Block](ctx, rc, restUrl.String())
 	if err != nil {
 		return block, fmt.Errorf("get block api err: %w", err)
 	}
@@ -308,7 +476,7 @@ func (rc *NodeClient) getBlockCommon(restUrl *url.URL, withTransactions bool) (*
 	// TODO: I maybe should pull these concurrently, but not for now
 	for retrievedTransactions < numTransactions {
 		numToPull := numTransactions - retrievedTransactions
-		transactions, innerError := rc.Transactions(&cursor, &numToPull)
+		transactions, innerError := rc.TransactionsCtx(ctx, &cursor, &numToPull)
 		if innerError != nil {
 			// We will still return the block, since we did so much work for it
 			return block, innerError
@@ -342,19 +510,28 @@ func getTransactionPollOptions(defaultPeriod, defaultTimeout time.Duration, opti
 // Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
 // Not just a degenerate case of PollForTransactions, it may return additional information for the single transaction polled.
 func (rc *NodeClient) PollForTransaction(hash string, options ...any) (*api.UserTransaction, error) {
-	// Wait for the transaction to be done
-	txn, err := rc.WaitTransactionByHash(hash)
-	if err == nil && txn.Type == api.TransactionVariantUser {
-		return txn.UserTransaction()
-	}
+	return rc.PollForTransactionCtx(context.Background(), hash, options...)
+}
 
-	// Poll for the transaction to be done
+// PollForTransactionCtx waits up to 10 seconds for a transaction to be done, polling at 10Hz
+// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
+//
+// Polling stops as soon as ctx is done, and the context's error is returned.
+func (rc *NodeClient) PollForTransactionCtx(ctx context.Context, hash string, options ...any) (*api.UserTransaction, error) {
+	// Poll options are checked first, so a bad option doesn't wait on the long-GET
 	period, timeout, err := getTransactionPollOptions(100*time.Millisecond, 10*time.Second, options...)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	// Wait for the transaction to be done
+	txn, err := rc.WaitTransactionByHashCtx(ctx, hash)
+	if err == nil && txn.Type == api.TransactionVariantUser {
+		return txn.UserTransaction()
+	}
+
+	// Poll for the transaction to be done
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	ticker := time.NewTicker(period)
@@ -362,10 +539,13 @@ func (rc *NodeClient) PollForTransaction(hash string, options ...any) (*api.User
 
 	for {
 		select {
-		case <-ctx.Done():
+		case <-timeoutCtx.Done():
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("PollForTransaction cancelled: %w", ctx.Err())
+			}
 			return nil, errors.New("PollForTransaction timeout")
 		case <-ticker.C:
-			txn, err := rc.TransactionByHash(hash)
+			txn, err := rc.TransactionByHashCtx(timeoutCtx, hash)
 			if err != nil {
 				continue
 			}
@@ -385,6 +565,14 @@ func (rc *NodeClient) PollForTransaction(hash string, options ...any) (*api.User
 // PollForTransactions waits up to 10 seconds for transactions to be done, polling at 10Hz
 // Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
 func (rc *NodeClient) PollForTransactions(txnHashes []string, options ...any) error {
+	return rc.PollForTransactionsCtx(context.Background(), txnHashes, options...)
+}
+
+// PollForTransactionsCtx waits up to 10 seconds for transactions to be done, polling at 10Hz
+// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
+//
+// Polling stops as soon as ctx is done, and the context's error is returned.
+func (rc *NodeClient) PollForTransactionsCtx(ctx context.Context, txnHashes []string, options ...any) error {
 	period, timeout, err := getTransactionPollOptions(100*time.Millisecond, 10*time.Second, options...)
 	if err != nil {
 		return err
@@ -393,19 +581,28 @@ func (rc *NodeClient) PollForTransactions(txnHashes []string, options ...any) er
 	for _, hash := range txnHashes {
 		hashSet[hash] = true
 	}
-	start := time.Now()
-	deadline := start.Add(timeout)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
 	for len(hashSet) > 0 {
-		if time.Now().After(deadline) {
+		select {
+		case <-timeoutCtx.Done():
+			if ctx.Err() != nil {
+				return fmt.Errorf("PollForTransactions cancelled: %w", ctx.Err())
+			}
 			return errors.New("PollForTransactions timeout")
+		case <-ticker.C:
 		}
-		time.Sleep(period)
 		for _, hash := range txnHashes {
 			if !hashSet[hash] {
 				// already done
 				continue
 			}
-			txn, err := rc.TransactionByHash(hash)
+			txn, err := rc.TransactionByHashCtx(timeoutCtx, hash)
 			if err == nil {
 				if txn.Type != api.TransactionVariantPending {
 					// done!
@@ -428,17 +625,33 @@ func (rc *NodeClient) WaitForTransaction(txnHash string, options ...any) (*api.U
 	return rc.PollForTransaction(txnHash, options...)
 }
 
+// WaitForTransactionCtx does a long-GET for one transaction and wait for it to complete, stopping early if ctx is done.
+//
+// Accepts the same options as [NodeClient.WaitForTransaction].
+func (rc *NodeClient) WaitForTransactionCtx(ctx context.Context, txnHash string, options ...any) (*api.UserTransaction, error) {
+	return rc.PollForTransactionCtx(ctx, txnHash, options...)
+}
+
 // Transactions Get recent transactions.
 //
 // Arguments:
 //   - start is a version number. Nil for most recent transactions.
 //   - limit is a number of transactions to return. 'about a hundred' by default.
 func (rc *NodeClient) Transactions(start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	return rc.TransactionsCtx(context.Background(), start, limit)
+}
+
+// TransactionsCtx Get recent transactions, all page requests are bound to ctx
+//
+// Arguments:
+//   - start is a version number. Nil for most recent transactions.
+//   - limit is a number of transactions to return. 'about a hundred' by default.
+func (rc *NodeClient) TransactionsCtx(ctx context.Context, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
 	return rc.handleTransactions(start, limit, func(txns *[]*api.CommittedTransaction) uint64 {
 		txn := (*txns)[len(*txns)-1]
 		return txn.Version()
 	}, func(start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
-		return rc.transactionsInner(start, limit)
+		return rc.transactionsInner(ctx, start, limit)
 	})
 }
 
@@ -448,12 +661,21 @@ func (rc *NodeClient) Transactions(start *uint64, limit *uint64) ([]*api.Committ
 //   - start is a version number. Nil for most recent transactions.
 //   - limit is a number of transactions to return. 'about a hundred' by default.
 func (rc *NodeClient) AccountTransactions(account AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	return rc.AccountTransactionsCtx(context.Background(), account, start, limit)
+}
+
+// AccountTransactionsCtx Get recent transactions for an account, all page requests are bound to ctx
+//
+// Arguments:
+//   - start is a version number. Nil for most recent transactions.
+//   - limit is a number of transactions to return. 'about a hundred' by default.
+func (rc *NodeClient) AccountTransactionsCtx(ctx context.Context, account AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
 	return rc.handleTransactions(start, limit, func(txns *[]*api.CommittedTransaction) uint64 {
 		// It will always be a UserTransaction, no other type will come from the API
 		userTxn, _ := ((*txns)[0]).UserTransaction()
 		return userTxn.SequenceNumber - 1
 	}, func(start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
-		return rc.accountTransactionsInner(account, start, limit)
+		return rc.accountTransactionsInner(ctx, account, start, limit)
 	})
 }
 
@@ -471,84 +693,28 @@ func (rc *NodeClient) EventsByHandle(
 	fieldName string,
 	start *uint64,
 	limit *uint64,
+) ([]*api.Event, error) {
+	return rc.EventsByHandleCtx(context.Background(), account, eventHandle, fieldName, start, limit)
+}
+
+// EventsByHandleCtx retrieves events by event handle and field name for a given account, all page requests are bound
+// to ctx.
+//
+// Arguments are the same as [NodeClient.EventsByHandle]
+func (rc *NodeClient) EventsByHandleCtx(
+	ctx context.Context,
+	account AccountAddress,
+	eventHandle string,
+	fieldName string,
+	start *uint64,
+	limit *uint64,
 ) ([]*api.Event, error) {
 	basePath := fmt.Sprintf("accounts/%s/events/%s/%s",
 		account.String(),
 		eventHandle,
 		fieldName)
 
-	baseUrl := rc.baseUrl.JoinPath(basePath)
-
-	const eventsPageSize = 100
-	var effectiveLimit uint64
-	if limit == nil {
-		effectiveLimit = eventsPageSize
-	} else {
-		effectiveLimit = *limit
-	}
-
-	if effectiveLimit <= eventsPageSize {
-		params := url.Values{}
-		if start != nil {
-			params.Set("start", strconv.FormatUint(*start, 10))
-		}
-		params.Set("limit", strconv.FormatUint(effectiveLimit, 10))
-
-		requestUrl := *baseUrl
-		requestUrl.RawQuery = params.Encode()
-
-		data, err := Get[[]*api.Event](rc, requestUrl.String())
-		if err != nil {
-			return nil, fmt.Errorf("get events api err: %w", err)
-		}
-		return data, nil
-	}
-
-	pages := (effectiveLimit + eventsPageSize - 1) / eventsPageSize
-	channels := make([]chan ConcResponse[[]*api.Event], pages)
-
-	for i := range pages {
-		channels[i] = make(chan ConcResponse[[]*api.Event], 1)
-		var pageStart *uint64
-		if start != nil {
-			value := *start + (i * eventsPageSize)
-			pageStart = &value
-		}
-		pageLimit := min(eventsPageSize, effectiveLimit-(i*eventsPageSize))
-
-		go fetch(func() ([]*api.Event, error) {
-			params := url.Values{}
-			if pageStart != nil {
-				params.Set("start", strconv.FormatUint(*pageStart, 10))
-			}
-			params.Set("limit", strconv.FormatUint(pageLimit, 10))
-
-			requestUrl := *baseUrl
-			requestUrl.RawQuery = params.Encode()
-
-			events, err := Get[[]*api.Event](rc, requestUrl.String())
-			if err != nil {
-				return nil, fmt.Errorf("get events api err: %w", err)
-			}
-			return events, nil
-		}, channels[i])
-	}
-
-	events := make([]*api.Event, 0, effectiveLimit)
-	for i, ch := range channels {
-		response := <-ch
-		if response.Err != nil {
-			return nil, response.Err
-		}
-		events = append(events, response.Result...)
-		close(channels[i])
-	}
-
-	sort.Slice(events, func(i, j int) bool {
-		return events[i].SequenceNumber < events[j].SequenceNumber
-	})
-
-	return events, nil
+	return rc.eventsCommon(ctx, rc.baseUrl.JoinPath(basePath), start, limit)
 }
 
 // EventsByCreationNumber retrieves events by creation number for a given account.
@@ -563,13 +729,32 @@ func (rc *NodeClient) EventsByCreationNumber(
 	creationNumber string,
 	start *uint64,
 	limit *uint64,
+) ([]*api.Event, error) {
+	return rc.EventsByCreationNumberCtx(context.Background(), account, creationNumber, start, limit)
+}
+
+// EventsByCreationNumberCtx retrieves events by creation number for a given account, all page requests are bound to
+// ctx.
+//
+// Arguments are the same as [NodeClient.EventsByCreationNumber]
+func (rc *NodeClient) EventsByCreationNumberCtx(
+	ctx context.Context,
+	account AccountAddress,
+	creationNumber string,
+	start *uint64,
+	limit *uint64,
 ) ([]*api.Event, error) {
 	basePath := fmt.Sprintf("accounts/%s/events/%s",
 		account.String(),
 		creationNumber)
 
-	baseUrl := rc.baseUrl.JoinPath(basePath)
+	return rc.eventsCommon(ctx, rc.baseUrl.JoinPath(basePath), start, limit)
+}
 
+// eventsCommon is a helper function for fetching events from an events endpoint
+//
+// It will fetch the events in a single request if possible, otherwise it will fetch pages concurrently.
+func (rc *NodeClient) eventsCommon(ctx context.Context, baseUrl *url.URL, start *uint64, limit *uint64) ([]*api.Event, error) {
 	const eventsPageSize = 100
 	var effectiveLimit uint64
 	if limit == nil {
@@ -588,7 +773,7 @@ func (rc *NodeClient) EventsByCreationNumber(
 		requestUrl := *baseUrl
 		requestUrl.RawQuery = params.Encode()
 
-		data, err := Get[[]*api.Event](rc, requestUrl.String())
+		data, err := GetCtx[[]*api.Event](ctx, rc, requestUrl.String())
 		if err != nil {
 			return nil, fmt.Errorf("get events api err: %w", err)
 		}
@@ -617,7 +802,7 @@ func (rc *NodeClient) EventsByCreationNumber(
 			requestUrl := *baseUrl
 			requestUrl.RawQuery = params.Encode()
 
-			events, err := Get[[]*api.Event](rc, requestUrl.String())
+			events, err := GetCtx[[]*api.Event](ctx, rc, requestUrl.String())
 			if err != nil {
 				return nil, fmt.Errorf("get events api err: %w", err)
 			}
@@ -737,7 +922,7 @@ func (rc *NodeClient) transactionsConcurrent(
 }
 
 // transactionsInner fetches the transactions from the node in a single request
-func (rc *NodeClient) transactionsInner(start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+func (rc *NodeClient) transactionsInner(ctx context.Context, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
 	au := rc.baseUrl.JoinPath("transactions")
 	params := url.Values{}
 	if start != nil {
@@ -749,7 +934,7 @@ func (rc *NodeClient) transactionsInner(start *uint64, limit *uint64) ([]*api.Co
 	if len(params) != 0 {
 		au.RawQuery = params.Encode()
 	}
-	data, err := Get[[]*api.CommittedTransaction](rc, au.String())
+	data, err := GetCtx[[]*api.CommittedTransaction](ctx, rc, au.String())
 	if err != nil {
 		return data, fmt.Errorf("get transactions api err: %w", err)
 	}
@@ -757,7 +942,7 @@ func (rc *NodeClient) transactionsInner(start *uint64, limit *uint64) ([]*api.Co
 }
 
 // accountTransactionsInner fetches the transactions from the node in a single request for a single account
-func (rc *NodeClient) accountTransactionsInner(account AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+func (rc *NodeClient) accountTransactionsInner(ctx context.Context, account AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
 	au := rc.baseUrl.JoinPath(fmt.Sprintf("accounts/%s/transactions", account.String()))
 	params := url.Values{}
 	if start != nil {
@@ -770,7 +955,7 @@ func (rc *NodeClient) accountTransactionsInner(account AccountAddress, start *ui
 		au.RawQuery = params.Encode()
 	}
 
-	data, err := Get[[]*api.CommittedTransaction](rc, au.String())
+	data, err := GetCtx[[]*api.CommittedTransaction](ctx, rc, au.String())
 	if err != nil {
 		return data, fmt.Errorf("get account transactions api err: %w", err)
 	}
@@ -779,13 +964,20 @@ func (rc *NodeClient) accountTransactionsInner(account AccountAddress, start *ui
 
 // SubmitTransaction submits a signed transaction to the network
 func (rc *NodeClient) SubmitTransaction(signedTxn *SignedTransaction) (*api.SubmitTransactionResponse, error) {
+	return rc.SubmitTransactionCtx(context.Background(), signedTxn)
+}
+
+// SubmitTransactionCtx submits a signed transaction to the network, the request is bound to ctx
+//
+// Note that a cancelled submission may still have reached the mempool, check with [NodeClient.TransactionByHashCtx]
+// before assuming it was dropped.
+func (rc *NodeClient) SubmitTransactionCtx(ctx context.Context, signedTxn *SignedTransaction) (*api.SubmitTransactionResponse, error) {
 	sblob, err := bcs.Serialize(signedTxn)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader := bytes.NewReader(sblob)
 	au := rc.baseUrl.JoinPath("transactions")
-	data, err := Post[*api.SubmitTransactionResponse](rc, au.String(), ContentTypeAptosSignedTxnBcs, bodyReader)
+	data, err := postIdempotent[*api.SubmitTransactionResponse](ctx, rc, au.String(), ContentTypeAptosSignedTxnBcs, sblob)
 	if err != nil {
 		return nil, fmt.Errorf("submit transaction api err: %w", err)
 	}
@@ -797,13 +989,18 @@ func (rc *NodeClient) SubmitTransaction(signedTxn *SignedTransaction) (*api.Subm
 // It will return the responses in the same order as the input transactions that failed.  If the response is empty, then
 // all transactions succeeded.
 func (rc *NodeClient) BatchSubmitTransaction(signedTxns []*SignedTransaction) (*api.BatchSubmitTransactionResponse, error) {
+	return rc.BatchSubmitTransactionCtx(context.Background(), signedTxns)
+}
+
+// BatchSubmitTransactionCtx submits a collection of signed transactions to the network in a single request, the
+// request is bound to ctx
+func (rc *NodeClient) BatchSubmitTransactionCtx(ctx context.Context, signedTxns []*SignedTransaction) (*api.BatchSubmitTransactionResponse, error) {
 	sblob, err := bcs.SerializeSequenceOnly(signedTxns)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader := bytes.NewReader(sblob)
 	au := rc.baseUrl.JoinPath("transactions/batch")
-	response, err := Post[*api.BatchSubmitTransactionResponse](rc, au.String(), ContentTypeAptosSignedTxnBcs, bodyReader)
+	response, err := postIdempotent[*api.BatchSubmitTransactionResponse](ctx, rc, au.String(), ContentTypeAptosSignedTxnBcs, sblob)
 	if err != nil {
 		return nil, fmt.Errorf("submit transaction api err: %w", err)
 	}
@@ -812,6 +1009,11 @@ func (rc *NodeClient) BatchSubmitTransaction(signedTxns []*SignedTransaction) (*
 
 // SimulateTransaction simulates a transaction
 func (rc *NodeClient) SimulateTransaction(rawTxn *RawTransaction, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
+	return rc.SimulateTransactionCtx(context.Background(), rawTxn, sender, options...)
+}
+
+// SimulateTransactionCtx simulates a transaction, the request is bound to ctx
+func (rc *NodeClient) SimulateTransactionCtx(ctx context.Context, rawTxn *RawTransaction, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
 	// build authenticator for simulation
 	auth := sender.SimulationAuthenticator()
 
@@ -821,11 +1023,16 @@ func (rc *NodeClient) SimulateTransaction(rawTxn *RawTransaction, sender Transac
 		return nil, err
 	}
 
-	return rc.simulateTransactionInner(signedTxn, options...)
+	return rc.simulateTransactionInner(ctx, signedTxn, options...)
 }
 
 // SimulateTransactionMultiAgent simulates a transaction as fee payer or multi agent
 func (rc *NodeClient) SimulateTransactionMultiAgent(rawTxn *RawTransactionWithData, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
+	return rc.SimulateTransactionMultiAgentCtx(context.Background(), rawTxn, sender, options...)
+}
+
+// SimulateTransactionMultiAgentCtx simulates a transaction as fee payer or multi agent, the request is bound to ctx
+func (rc *NodeClient) SimulateTransactionMultiAgentCtx(ctx context.Context, rawTxn *RawTransactionWithData, sender TransactionSigner, options ...any) ([]*api.UserTransaction, error) {
 	var feePayer *AccountAddress
 	var additionalSigners []AccountAddress
 
@@ -865,15 +1072,14 @@ func (rc *NodeClient) SimulateTransactionMultiAgent(rawTxn *RawTransactionWithDa
 		}
 	}
 
-	return rc.simulateTransactionInner(signedTxn, options...)
+	return rc.simulateTransactionInner(ctx, signedTxn, options...)
 }
 
-func (rc *NodeClient) simulateTransactionInner(signedTxn *SignedTransaction, options ...any) ([]*api.UserTransaction, error) {
+func (rc *NodeClient) simulateTransactionInner(ctx context.Context, signedTxn *SignedTransaction, options ...any) ([]*api.UserTransaction, error) {
 	sblob, err := bcs.Serialize(signedTxn)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader := bytes.NewReader(sblob)
 	au := rc.baseUrl.JoinPath("transactions/simulate")
 
 	// parse simulate tx options
@@ -894,7 +1100,7 @@ func (rc *NodeClient) simulateTransactionInner(signedTxn *SignedTransaction, opt
 		au.RawQuery = params.Encode()
 	}
 
-	data, err := Post[[]*api.UserTransaction](rc, au.String(), ContentTypeAptosSignedTxnBcs, bodyReader)
+	data, err := postIdempotent[[]*api.UserTransaction](ctx, rc, au.String(), ContentTypeAptosSignedTxnBcs, sblob)
 	if err != nil {
 		return nil, fmt.Errorf("simulate transaction api err: %w", err)
 	}
@@ -902,11 +1108,68 @@ func (rc *NodeClient) simulateTransactionInner(signedTxn *SignedTransaction, opt
 	return data, nil
 }
 
+// SimulationError is returned when a transaction simulated by [SimulateMaxGas] does not succeed
+type SimulationError struct {
+	VmStatus string // VmStatus of the simulated transaction e.g. "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006)"
+	GasUsed  uint64 // GasUsed by the simulated transaction, in gas units
+}
+
+// Error returns a string representation of the SimulationError
+//
+// Implements:
+//   - [Error]
+func (se *SimulationError) Error() string {
+	return fmt.Sprintf("transaction simulation failed after %d gas units: %s", se.GasUsed, se.VmStatus)
+}
+
+// ApplySimulatedMaxGas simulates rawTxn with simulate and sets its MaxGasAmount to the gas used times the multiplier, as
+// the builders do for a [SimulateMaxGas] option.  The simulation may use as much gas as the sender can afford, which
+// caps the estimate, as does the existing MaxGasAmount when capped.  It lets other [AptosClient] implementations build
+// transactions the same way.
+func ApplySimulatedMaxGas(rawTxn *RawTransaction, options SimulateMaxGas, capped bool, simulate func(signer TransactionSigner) ([]*api.UserTransaction, error)) error {
+	if options.Signer == nil {
+		return errors.New("SimulateMaxGas requires a Signer")
+	}
+	multiplier := options.GasMultiplier
+	if multiplier == 0 {
+		multiplier = DefaultSimulationGasMultiplier
+	} else if multiplier < 1 {
+		return fmt.Errorf("SimulateMaxGas GasMultiplier %f must be at least 1", multiplier)
+	}
+
+	simulated, err := simulate(options.Signer)
+	if err != nil {
+		return err
+	}
+	if len(simulated) != 1 {
+		return fmt.Errorf("simulate transaction returned %d transactions, expected 1", len(simulated))
+	}
+	result := simulated[0]
+	if !result.Success {
+		return &SimulationError{VmStatus: result.VmStatus, GasUsed: result.GasUsed}
+	}
+
+	maxGasAmount := uint64(math.Ceil(float64(result.GasUsed) * multiplier))
+	if result.MaxGasAmount != 0 {
+		maxGasAmount = min(maxGasAmount, result.MaxGasAmount)
+	}
+	if capped {
+		maxGasAmount = min(maxGasAmount, rawTxn.MaxGasAmount)
+	}
+	rawTxn.MaxGasAmount = maxGasAmount
+	return nil
+}
+
 // GetChainId gets the chain ID of the network
 func (rc *NodeClient) GetChainId() (uint8, error) {
+	return rc.GetChainIdCtx(context.Background())
+}
+
+// GetChainIdCtx gets the chain ID of the network, if it isn't cached the request is bound to ctx
+func (rc *NodeClient) GetChainIdCtx(ctx context.Context) (uint8, error) {
 	if rc.chainId == 0 {
 		// Calling Info will cache the ChainId
-		info, err := rc.Info()
+		info, err := rc.InfoCtx(ctx)
 		if err != nil {
 			return 0, err
 		}
@@ -925,7 +1188,17 @@ func (rc *NodeClient) GetChainId() (uint8, error) {
 //   - [ExpirationSeconds]
 //   - [SequenceNumber]
 //   - [ChainIdOption]
+//   - [SimulateMaxGas]
+//   - [Orderless]
+//   - [ReplayNonce]
 func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransaction, error) {
+	return rc.BuildTransactionCtx(context.Background(), sender, payload, options...)
+}
+
+// BuildTransactionCtx builds a raw transaction for signing for a single signer, any on-chain lookups are bound to ctx
+//
+// Accepts the same options as [NodeClient.BuildTransaction]
+func (rc *NodeClient) BuildTransactionCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransaction, error) {
 	maxGasAmount := DefaultMaxGasAmount
 	gasUnitPrice := DefaultGasUnitPrice
 	expirationSeconds := DefaultExpirationSeconds
@@ -934,28 +1207,70 @@ func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload Transactio
 	chainId := uint8(0)
 	haveChainId := false
 	haveGasUnitPrice := false
+	haveMaxGasAmount := false
+	haveExpirationSeconds := false
+	orderless := false
+	var replayNonce *uint64
+	var simulateMaxGas *SimulateMaxGas
 
 	for opti, option := range options {
 		switch ovalue := option.(type) {
 		case MaxGasAmount:
 			maxGasAmount = uint64(ovalue)
+			haveMaxGasAmount = true
 		case GasUnitPrice:
 			gasUnitPrice = uint64(ovalue)
 			haveGasUnitPrice = true
 		case ExpirationSeconds:
 			expirationSeconds = uint64(ovalue)
+			haveExpirationSeconds = true
+		case Orderless:
+			orderless = bool(ovalue)
+		case ReplayNonce:
+			nonce := uint64(ovalue)
+			replayNonce = &nonce
 		case SequenceNumber:
 			sequenceNumber = uint64(ovalue)
 			haveSequenceNumber = true
 		case ChainIdOption:
 			chainId = uint8(ovalue)
 			haveChainId = true
+		case SimulateMaxGas:
+			simulateMaxGas = &ovalue
 		default:
 			return nil, fmt.Errorf("BuildTransaction arg [%d] unknown option type %T", opti+4, option)
 		}
 	}
 
-	return rc.buildTransactionInner(sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, expirationSeconds, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
+	payload, orderless, err := orderlessPayload(payload, orderless, replayNonce)
+	if err != nil {
+		return nil, err
+	}
+	if orderless {
+		expirationSeconds, err = orderlessExpirationSeconds(expirationSeconds, haveExpirationSeconds)
+		if err != nil {
+			return nil, err
+		}
+		if !haveSequenceNumber {
+			sequenceNumber = OrderlessSequenceNumber
+			haveSequenceNumber = true
+		}
+	}
+
+	rawTxn, err := rc.buildTransactionInner(ctx, sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, expirationSeconds, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	if simulateMaxGas != nil {
+		err = ApplySimulatedMaxGas(rawTxn, *simulateMaxGas, haveMaxGasAmount, func(signer TransactionSigner) ([]*api.UserTransaction, error) {
+			return rc.SimulateTransactionCtx(ctx, rawTxn, signer, EstimateMaxGasAmount(true))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rawTxn, nil
 }
 
 // BuildTransactionMultiAgent builds a raw transaction for signing with fee payer or multi-agent
@@ -970,7 +1285,21 @@ func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload Transactio
 //   - [ChainIdOption]
 //   - [FeePayer]
 //   - [AdditionalSigners]
+//   - [SimulateMaxGas]
+//   - [Orderless]
+//   - [ReplayNonce]
+//
+// [SimulateMaxGas] simulates as the sender with [NodeClient.SimulateTransactionMultiAgent], the fee payer and additional
+// signers aren't signed for.
 func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransactionWithData, error) {
+	return rc.BuildTransactionMultiAgentCtx(context.Background(), sender, payload, options...)
+}
+
+// BuildTransactionMultiAgentCtx builds a raw transaction for signing with fee payer or multi-agent, any on-chain
+// lookups are bound to ctx
+//
+// Accepts the same options as [NodeClient.BuildTransactionMultiAgent]
+func (rc *NodeClient) BuildTransactionMultiAgentCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransactionWithData, error) {
 	maxGasAmount := DefaultMaxGasAmount
 	gasUnitPrice := DefaultGasUnitPrice
 	expirationSeconds := DefaultExpirationSeconds
@@ -979,6 +1308,11 @@ func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload
 	chainId := uint8(0)
 	haveChainId := false
 	haveGasUnitPrice := false
+	haveMaxGasAmount := false
+	haveExpirationSeconds := false
+	orderless := false
+	var replayNonce *uint64
+	var simulateMaxGas *SimulateMaxGas
 
 	var feePayer *AccountAddress
 	var additionalSigners []AccountAddress
@@ -987,11 +1321,18 @@ func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload
 		switch ovalue := option.(type) {
 		case MaxGasAmount:
 			maxGasAmount = uint64(ovalue)
+			haveMaxGasAmount = true
 		case GasUnitPrice:
 			gasUnitPrice = uint64(ovalue)
 			haveGasUnitPrice = true
 		case ExpirationSeconds:
 			expirationSeconds = uint64(ovalue)
+			haveExpirationSeconds = true
+		case Orderless:
+			orderless = bool(ovalue)
+		case ReplayNonce:
+			nonce := uint64(ovalue)
+			replayNonce = &nonce
 		case SequenceNumber:
 			sequenceNumber = uint64(ovalue)
 			haveSequenceNumber = true
@@ -1002,39 +1343,69 @@ func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload
 			feePayer = ovalue
 		case AdditionalSigners:
 			additionalSigners = ovalue
+		case SimulateMaxGas:
+			simulateMaxGas = &ovalue
 		default:
 			return nil, fmt.Errorf("APTTransferTransaction arg [%d] unknown option type %T", opti+4, option)
 		}
 	}
 
+	payload, orderless, err := orderlessPayload(payload, orderless, replayNonce)
+	if err != nil {
+		return nil, err
+	}
+	if orderless {
+		expirationSeconds, err = orderlessExpirationSeconds(expirationSeconds, haveExpirationSeconds)
+		if err != nil {
+			return nil, err
+		}
+		if !haveSequenceNumber {
+			sequenceNumber = OrderlessSequenceNumber
+			haveSequenceNumber = true
+		}
+	}
+
 	// Build the base raw transaction
-	rawTxn, err := rc.buildTransactionInner(sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, expirationSeconds, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
+	rawTxn, err := rc.buildTransactionInner(ctx, sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, expirationSeconds, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
 	if err != nil {
 		return nil, err
 	}
 
 	// Based on the options, choose which to use
+	var rawTxnWithData *RawTransactionWithData
 	if feePayer != nil {
-		return &RawTransactionWithData{
+		rawTxnWithData = &RawTransactionWithData{
 			Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
 			Inner: &MultiAgentWithFeePayerRawTransactionWithData{
 				RawTxn:           rawTxn,
 				FeePayer:         feePayer,
 				SecondarySigners: additionalSigners,
 			},
-		}, nil
+		}
+	} else {
+		rawTxnWithData = &RawTransactionWithData{
+			Variant: MultiAgentRawTransactionWithDataVariant,
+			Inner: &MultiAgentRawTransactionWithData{
+				RawTxn:           rawTxn,
+				SecondarySigners: additionalSigners,
+			},
+		}
 	}
 
-	return &RawTransactionWithData{
-		Variant: MultiAgentRawTransactionWithDataVariant,
-		Inner: &MultiAgentRawTransactionWithData{
-			RawTxn:           rawTxn,
-			SecondarySigners: additionalSigners,
-		},
-	}, nil
+	// The inner raw transaction is shared, so simulating sets its max gas amount
+	if simulateMaxGas != nil {
+		err = ApplySimulatedMaxGas(rawTxn, *simulateMaxGas, haveMaxGasAmount, func(signer TransactionSigner) ([]*api.UserTransaction, error) {
+			return rc.SimulateTransactionMultiAgentCtx(ctx, rawTxnWithData, signer, FeePayer(feePayer), AdditionalSigners(additionalSigners), EstimateMaxGasAmount(true))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rawTxnWithData, nil
 }
 
 func (rc *NodeClient) buildTransactionInner(
+	ctx context.Context,
 	sender AccountAddress,
 	payload TransactionPayload,
 	maxGasAmount uint64,
@@ -1053,7 +1424,7 @@ func (rc *NodeClient) buildTransactionInner(
 	if !haveGasUnitPrice {
 		gasPriceErrChannel = make(chan error, 1)
 		go func() {
-			gasPriceEstimation, innerErr := rc.EstimateGasPrice()
+			gasPriceEstimation, innerErr := rc.EstimateGasPriceCtx(ctx)
 			if innerErr != nil {
 				gasPriceErrChannel <- innerErr
 			} else {
@@ -1070,7 +1441,7 @@ func (rc *NodeClient) buildTransactionInner(
 		if rc.chainId == 0 {
 			chainIdErrChannel = make(chan error, 1)
 			go func() {
-				chain, innerErr := rc.GetChainId()
+				chain, innerErr := rc.GetChainIdCtx(ctx)
 				if innerErr != nil {
 					chainIdErrChannel <- innerErr
 				} else {
@@ -1089,7 +1460,7 @@ func (rc *NodeClient) buildTransactionInner(
 	if !haveSequenceNumber {
 		accountErrChannel = make(chan error, 1)
 		go func() {
-			account, innerErr := rc.Account(sender)
+			account, innerErr := rc.AccountCtx(ctx, sender)
 			if innerErr != nil {
 				accountErrChannel <- innerErr
 				close(accountErrChannel)
@@ -1107,7 +1478,6 @@ func (rc *NodeClient) buildTransactionInner(
 		}()
 	}
 
-	// TODO: optionally simulate for max gas
 	// Wait on the errors
 	if chainIdErrChannel != nil {
 		chainIdErr := <-chainIdErrChannel
@@ -1148,14 +1518,45 @@ func (rc *NodeClient) buildTransactionInner(
 
 // View calls a view function on the blockchain and returns the return value of the function
 func (rc *NodeClient) View(payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	return rc.ViewCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewCtx calls a view function on the blockchain and returns the return value of the function, the request is bound
+// to ctx
+func (rc *NodeClient) ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	data, _, err := rc.viewInner(ctx, payload, ledgerVersion)
+	return data, err
+}
+
+// ViewWithResponseInfo is [NodeClient.View], additionally returning the ledger metadata of the response, including the
+// gas used by the view function
+func (rc *NodeClient) ViewWithResponseInfo(payload *ViewPayload, ledgerVersion ...uint64) ([]any, *ResponseInfo, error) {
+	return rc.ViewWithResponseInfoCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewWithResponseInfoCtx is [NodeClient.ViewCtx], additionally returning the ledger metadata of the response,
+// including the gas used by the view function
+func (rc *NodeClient) ViewWithResponseInfoCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, *ResponseInfo, error) {
+	data, header, err := rc.viewInner(ctx, payload, ledgerVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	responseInfo, err := ParseResponseInfo(header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("view function api err: %w", err)
+	}
+	return data, responseInfo, nil
+}
+
+// viewInner calls the view function on the node, returning the response headers for the ledger metadata
+func (rc *NodeClient) viewInner(ctx context.Context, payload *ViewPayload, ledgerVersion []uint64) ([]any, http.Header, error) {
 	serializer := bcs.Serializer{}
 	payload.MarshalBCS(&serializer)
 	err := serializer.Error()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	sblob := serializer.ToBytes()
-	bodyReader := bytes.NewReader(sblob)
 	au := rc.baseUrl.JoinPath("view")
 	if len(ledgerVersion) > 0 {
 		params := url.Values{}
@@ -1163,14 +1564,19 @@ func (rc *NodeClient) View(payload *ViewPayload, ledgerVersion ...uint64) ([]any
 		au.RawQuery = params.Encode()
 	}
 
-	data, err := Post[[]any](rc, au.String(), ContentTypeAptosViewFunctionBcs, bodyReader)
+	data, header, err := postIdempotentWithHeader[[]any](ctx, rc, au.String(), ContentTypeAptosViewFunctionBcs, sblob)
 	if err != nil {
-		return nil, fmt.Errorf("view function api err: %w", err)
+		return nil, nil, fmt.Errorf("view function api err: %w", err)
 	}
-	return data, nil
+	return data, header, nil
 }
 
 func (rc *NodeClient) ViewWithResponse(response any, payload *ViewPayload, ledgerVersion ...uint64) error {
+	return rc.ViewWithResponseCtx(context.Background(), response, payload, ledgerVersion...)
+}
+
+// ViewWithResponseCtx runs a view function and unmarshals the JSON result into response, the request is bound to ctx
+func (rc *NodeClient) ViewWithResponseCtx(ctx context.Context, response any, payload *ViewPayload, ledgerVersion ...uint64) error {
 	serializer := bcs.Serializer{}
 	payload.MarshalBCS(&serializer)
 	err := serializer.Error()
@@ -1178,7 +1584,6 @@ func (rc *NodeClient) ViewWithResponse(response any, payload *ViewPayload, ledge
 		return err
 	}
 	sblob := serializer.ToBytes()
-	bodyReader := bytes.NewReader(sblob)
 	au := rc.baseUrl.JoinPath("view")
 	if len(ledgerVersion) > 0 {
 		params := url.Values{}
@@ -1186,7 +1591,7 @@ func (rc *NodeClient) ViewWithResponse(response any, payload *ViewPayload, ledge
 		au.RawQuery = params.Encode()
 	}
 
-	blob, err := Post[json.RawMessage](rc, au.String(), ContentTypeAptosViewFunctionBcs, bodyReader)
+	blob, err := postIdempotent[json.RawMessage](ctx, rc, au.String(), ContentTypeAptosViewFunctionBcs, sblob)
 	if err != nil {
 		return fmt.Errorf("view function api err: %w", err)
 	}
@@ -1200,8 +1605,13 @@ func (rc *NodeClient) ViewWithResponse(response any, payload *ViewPayload, ledge
 // EstimateGasPrice estimates the gas price given on-chain data
 // TODO: add caching for some period of time
 func (rc *NodeClient) EstimateGasPrice() (EstimateGasInfo, error) {
+	return rc.EstimateGasPriceCtx(context.Background())
+}
+
+// EstimateGasPriceCtx estimates the gas price given on-chain data, the request is bound to ctx
+func (rc *NodeClient) EstimateGasPriceCtx(ctx context.Context) (EstimateGasInfo, error) {
 	au := rc.baseUrl.JoinPath("estimate_gas_price")
-	info, err := Get[EstimateGasInfo](rc, au.String())
+	info, err := GetCtx[EstimateGasInfo](ctx, rc, au.String())
 	if err != nil {
 		return info, fmt.Errorf("estimate gas price err: %w", err)
 	}
@@ -1210,11 +1620,44 @@ func (rc *NodeClient) EstimateGasPrice() (EstimateGasInfo, error) {
 
 // AccountAPTBalance fetches the balance of an account of APT.  Response is in octas or 1/10^8 APT.
 func (rc *NodeClient) AccountAPTBalance(account AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	return rc.AccountAPTBalanceCtx(context.Background(), account, ledgerVersion...)
+}
+
+// AccountAPTBalanceCtx fetches the balance of an account of APT, the request is bound to ctx.  Response is in octas
+// or 1/10^8 APT.
+func (rc *NodeClient) AccountAPTBalanceCtx(ctx context.Context, account AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	balance, _, err := rc.accountAPTBalanceInner(ctx, account, ledgerVersion)
+	return balance, err
+}
+
+// AccountAPTBalanceWithResponseInfo is [NodeClient.AccountAPTBalance], additionally returning the ledger metadata of
+// the response, which tells the ledger version the balance was read at
+func (rc *NodeClient) AccountAPTBalanceWithResponseInfo(account AccountAddress, ledgerVersion ...uint64) (uint64, *ResponseInfo, error) {
+	return rc.AccountAPTBalanceWithResponseInfoCtx(context.Background(), account, ledgerVersion...)
+}
+
+// AccountAPTBalanceWithResponseInfoCtx is [NodeClient.AccountAPTBalanceCtx], additionally returning the ledger metadata
+// of the response, which tells the ledger version the balance was read at
+func (rc *NodeClient) AccountAPTBalanceWithResponseInfoCtx(ctx context.Context, account AccountAddress, ledgerVersion ...uint64) (uint64, *ResponseInfo, error) {
+	balance, header, err := rc.accountAPTBalanceInner(ctx, account, ledgerVersion)
+	if err != nil {
+		return 0, nil, err
+	}
+	responseInfo, err := ParseResponseInfo(header)
+	if err != nil {
+		return 0, nil, fmt.Errorf("view function api err: %w", err)
+	}
+	return balance, responseInfo, nil
+}
+
+// accountAPTBalanceInner fetches the APT balance with a view function, returning the response headers for the ledger
+// metadata
+func (rc *NodeClient) accountAPTBalanceInner(ctx context.Context, account AccountAddress, ledgerVersion []uint64) (uint64, http.Header, error) {
 	accountBytes, err := bcs.Serialize(&account)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
-	values, err := rc.View(&ViewPayload{
+	values, header, err := rc.viewInner(ctx, &ViewPayload{
 		Module: ModuleId{
 			Address: AccountOne,
 			Name:    "coin",
@@ -1222,28 +1665,39 @@ func (rc *NodeClient) AccountAPTBalance(account AccountAddress, ledgerVersion ..
 		Function: "balance",
 		ArgTypes: []TypeTag{AptosCoinTypeTag},
 		Args:     [][]byte{accountBytes},
-	}, ledgerVersion...)
+	}, ledgerVersion)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 	str, ok := values[0].(string)
 	if !ok {
-		return 0, errors.New("account balance err: could not convert account bytes")
+		return 0, nil, errors.New("account balance err: could not convert account bytes")
+	}
+	balance, err := StrToUint64(str)
+	if err != nil {
+		return 0, nil, err
 	}
-	return StrToUint64(str)
+	return balance, header, nil
 }
 
 // NodeAPIHealthCheck performs a health check on the node
 //
 // Returns a HealthCheckResponse if successful, returns error if not.
 func (rc *NodeClient) NodeAPIHealthCheck(durationSecs ...uint64) (api.HealthCheckResponse, error) {
+	return rc.NodeAPIHealthCheckCtx(context.Background(), durationSecs...)
+}
+
+// NodeAPIHealthCheckCtx performs a health check on the node, the request is bound to ctx
+//
+// Returns a HealthCheckResponse if successful, returns error if not.
+func (rc *NodeClient) NodeAPIHealthCheckCtx(ctx context.Context, durationSecs ...uint64) (api.HealthCheckResponse, error) {
 	au := rc.baseUrl.JoinPath("-/healthy")
 	if len(durationSecs) > 0 {
 		params := url.Values{}
 		params.Set("duration_secs", strconv.FormatUint(durationSecs[0], 10))
 		au.RawQuery = params.Encode()
 	}
-	return Get[api.HealthCheckResponse](rc, au.String())
+	return GetCtx[api.HealthCheckResponse](ctx, rc, au.String())
 }
 
 // NodeHealthCheck performs a health check on the node
@@ -1255,9 +1709,24 @@ func (rc *NodeClient) NodeHealthCheck(durationSecs ...uint64) (api.HealthCheckRe
 	return rc.NodeAPIHealthCheck(durationSecs...)
 }
 
+// NodeHealthCheckCtx performs a health check on the node, the request is bound to ctx
+//
+// Deprecated: Use NodeAPIHealthCheckCtx instead
+func (rc *NodeClient) NodeHealthCheckCtx(ctx context.Context, durationSecs ...uint64) (api.HealthCheckResponse, error) {
+	return rc.NodeAPIHealthCheckCtx(ctx, durationSecs...)
+}
+
 // BuildSignAndSubmitTransaction builds, signs, and submits a transaction to the network
+//
+// Accepts the same options as [NodeClient.BuildTransaction], a [SimulateMaxGas] without a Signer simulates as sender
 func (rc *NodeClient) BuildSignAndSubmitTransaction(sender TransactionSigner, payload TransactionPayload, options ...any) (*api.SubmitTransactionResponse, error) {
-	rawTxn, err := rc.BuildTransaction(sender.AccountAddress(), payload, options...)
+	return rc.BuildSignAndSubmitTransactionCtx(context.Background(), sender, payload, options...)
+}
+
+// BuildSignAndSubmitTransactionCtx builds, signs, and submits a transaction to the network, all requests are bound to ctx
+func (rc *NodeClient) BuildSignAndSubmitTransactionCtx(ctx context.Context, sender TransactionSigner, payload TransactionPayload, options ...any) (*api.SubmitTransactionResponse, error) {
+	options = withSimulationSigner(sender, options)
+	rawTxn, err := rc.BuildTransactionCtx(ctx, sender.AccountAddress(), payload, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -1265,85 +1734,167 @@ func (rc *NodeClient) BuildSignAndSubmitTransaction(sender TransactionSigner, pa
 	if err != nil {
 		return nil, err
 	}
-	return rc.SubmitTransaction(signedTxn)
+	return rc.SubmitTransactionCtx(ctx, signedTxn)
 }
 
-// Get makes a GET request to the endpoint and parses the response into the given type with JSON
-func Get[T any](rc *NodeClient, getUrl string) (T, error) {
-	var out T
-	req, err := http.NewRequest(http.MethodGet, getUrl, nil)
-	if err != nil {
-		return out, err
+// withSimulationSigner fills in sender as the Signer of a [SimulateMaxGas] option without one, without modifying options
+func withSimulationSigner(sender TransactionSigner, options []any) []any {
+	for i, option := range options {
+		simulate, ok := option.(SimulateMaxGas)
+		if !ok || simulate.Signer != nil {
+			continue
+		}
+		simulate.Signer = sender
+		options = slices.Clone(options)
+		options[i] = simulate
+		return options
 	}
-	req.Header.Set(ClientHeader, ClientHeaderValue)
+	return options
+}
 
-	// Set all preset headers
-	for key, value := range rc.headers {
-		req.Header.Set(key, value)
-	}
+// Get makes a GET request to the endpoint and parses the response into the given type with JSON
+func Get[T any](rc *NodeClient, getUrl string) (T, error) {
+	return GetCtx[T](context.Background(), rc, getUrl)
+}
 
-	response, err := rc.client.Do(req)
-	if err != nil {
-		err = fmt.Errorf("GET %s, %w", getUrl, err)
-		return out, err
-	}
+// GetCtx makes a GET request bound to ctx to the endpoint and parses the response into the given type with JSON
+//
+// The request is retried according to the client's [RetryPolicy], if any.
+func GetCtx[T any](ctx context.Context, rc *NodeClient, getUrl string) (T, error) {
+	out, _, err := getWithHeader[T](ctx, rc, getUrl)
+	return out, err
+}
 
-	if response.StatusCode >= 400 {
-		err = NewHttpError(response)
-		return out, err
-	}
-	defer response.Body.Close()
-	blob, err := io.ReadAll(response.Body)
+// getWithHeader is [GetCtx], additionally returning the response headers
+func getWithHeader[T any](ctx context.Context, rc *NodeClient, getUrl string) (T, http.Header, error) {
+	var out T
+	response, err := rc.withRetry(ctx, func() (*rawResponse, error) {
+		return rc.doRequest(ctx, http.MethodGet, getUrl, "", "", nil, true)
+	})
 	if err != nil {
-		return out, fmt.Errorf("error getting response data, %w", err)
+		return out, nil, err
 	}
-	err = json.Unmarshal(blob, &out)
+	err = json.Unmarshal(response.body, &out)
 	if err != nil {
-		return out, err
+		return out, nil, err
 	}
-	return out, nil
+	return out, response.header, nil
 }
 
 // GetBCS makes a GET request to the endpoint and parses the response into the given type with BCS
 func (rc *NodeClient) GetBCS(getUrl string) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, getUrl, nil)
+	return rc.GetBCSCtx(context.Background(), getUrl)
+}
+
+// GetBCSCtx makes a GET request bound to ctx to the endpoint and returns the raw BCS response
+//
+// The request is retried according to the client's [RetryPolicy], if any.
+func (rc *NodeClient) GetBCSCtx(ctx context.Context, getUrl string) ([]byte, error) {
+	blob, _, err := rc.getBCSWithHeader(ctx, getUrl)
+	return blob, err
+}
+
+// getBCSWithHeader is [NodeClient.GetBCSCtx], additionally returning the response headers
+func (rc *NodeClient) getBCSWithHeader(ctx context.Context, getUrl string) ([]byte, http.Header, error) {
+	response, err := rc.withRetry(ctx, func() (*rawResponse, error) {
+		return rc.doRequest(ctx, http.MethodGet, getUrl, "", "application/x-bcs", nil, true)
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	req.Header.Set("Accept", "application/x-bcs")
-	req.Header.Set(ClientHeader, ClientHeaderValue)
+	return response.body, response.header, nil
+}
 
-	// Set all preset headers
-	for key, value := range rc.headers {
-		req.Header.Set(key, value)
-	}
+// Post makes a POST request to the endpoint with the given body and parses the response into the given type with JSON
+func Post[T any](rc *NodeClient, postUrl string, contentType string, body io.Reader) (T, error) {
+	return PostCtx[T](context.Background(), rc, postUrl, contentType, body)
+}
 
-	response, err := rc.client.Do(req)
+// PostCtx makes a POST request bound to ctx to the endpoint with the given body and parses the response into the given
+// type with JSON
+//
+// The request is never retried, as a POST may not be safe to repeat.
+func PostCtx[T any](ctx context.Context, rc *NodeClient, postUrl string, contentType string, body io.Reader) (T, error) {
+	var data T
+	var blob []byte
+	if body != nil {
+		var err error
+		blob, err = io.ReadAll(body)
+		if err != nil {
+			return data, fmt.Errorf("error reading request body, %w", err)
+		}
+	}
+	response, err := rc.doRequest(ctx, http.MethodPost, postUrl, contentType, "", blob, false)
 	if err != nil {
-		return nil, fmt.Errorf("GET %s, %w", getUrl, err)
+		return data, err
 	}
-	if response.StatusCode >= 400 {
-		return nil, NewHttpError(response)
+
+	err = json.Unmarshal(response.body, &data)
+	return data, err
+}
+
+// postIdempotent makes a POST request that is safe to repeat, such as a view function, a simulation, or the submission
+// of already signed bytes.  The request is retried according to the client's [RetryPolicy], if any.
+func postIdempotent[T any](ctx context.Context, rc *NodeClient, postUrl string, contentType string, body []byte) (T, error) {
+	data, _, err := postIdempotentWithHeader[T](ctx, rc, postUrl, contentType, body)
+	return data, err
+}
+
+// postIdempotentWithHeader is postIdempotent, additionally returning the response headers
+func postIdempotentWithHeader[T any](ctx context.Context, rc *NodeClient, postUrl string, contentType string, body []byte) (T, http.Header, error) {
+	var data T
+	response, err := rc.withRetry(ctx, func() (*rawResponse, error) {
+		return rc.doRequest(ctx, http.MethodPost, postUrl, contentType, "", body, true)
+	})
+	if err != nil {
+		return data, nil, err
 	}
-	defer response.Body.Close()
-	blob, err := io.ReadAll(response.Body)
+
+	err = json.Unmarshal(response.body, &data)
 	if err != nil {
-		return nil, fmt.Errorf("error getting response data, %w", err)
+		return data, nil, err
 	}
-	return blob, nil
+	return data, response.header, nil
 }
 
-// Post makes a POST request to the endpoint with the given body and parses the response into the given type with JSON
-func Post[T any](rc *NodeClient, postUrl string, contentType string, body io.Reader) (T, error) {
-	var data T
-	if body == nil {
-		body = http.NoBody
+// rawResponse is the body and headers of a successful HTTP response
+type rawResponse struct {
+	body   []byte
+	header http.Header
+}
+
+// doRequest makes an HTTP request and returns the response.  Any status code of 400 or above is returned as an
+// [HttpError].
+//
+// Requests to a multi-endpoint client are routed to one of its node endpoints, idempotent requests fail over to the
+// next endpoint on transient errors.
+func (rc *NodeClient) doRequest(ctx context.Context, method string, requestUrl string, contentType string, accept string, body []byte, idempotent bool) (*rawResponse, error) {
+	if rc.pool != nil {
+		if path, ok := rc.pool.relativePath(rc.baseUrl, requestUrl); ok {
+			return rc.pool.do(ctx, path, idempotent, func(endpointUrl string) (*rawResponse, error) {
+				return rc.sendRequest(ctx, method, endpointUrl, contentType, accept, body)
+			})
+		}
 	}
-	req, err := http.NewRequest(http.MethodPost, postUrl, body)
+	return rc.sendRequest(ctx, method, requestUrl, contentType, accept, body)
+}
+
+// sendRequest makes a single HTTP request to requestUrl
+func (rc *NodeClient) sendRequest(ctx context.Context, method string, requestUrl string, contentType string, accept string, body []byte) (*rawResponse, error) {
+	var bodyReader io.Reader = http.NoBody
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, bodyReader)
 	if err != nil {
-		return data, err
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
 	}
-	req.Header.Set("Content-Type", contentType)
 	req.Header.Set(ClientHeader, ClientHeaderValue)
 
 	// Set all preset headers
@@ -1353,22 +1904,17 @@ func Post[T any](rc *NodeClient, postUrl string, contentType string, body io.Rea
 
 	response, err := rc.client.Do(req)
 	if err != nil {
-		err = fmt.Errorf("POST %s, %w", postUrl, err)
-		return data, err
+		return nil, fmt.Errorf("%s %s, %w", method, requestUrl, err)
 	}
 	if response.StatusCode >= 400 {
-		err = NewHttpError(response)
-		return data, err
+		return nil, NewHttpError(response)
 	}
 	defer response.Body.Close()
 	blob, err := io.ReadAll(response.Body)
 	if err != nil {
-		err = fmt.Errorf("error getting response data, %w", err)
-		return data, err
+		return nil, fmt.Errorf("error getting response data, %w", err)
 	}
-
-	err = json.Unmarshal(blob, &data)
-	return data, err
+	return &rawResponse{body: blob, header: response.Header}, nil
 }
 
 // ConcResponse is a concurrent response wrapper as a return type for all APIs.  It is meant to specifically be used in channels.
@@ -1,6 +1,7 @@
 package aptos
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -28,6 +29,78 @@ func TestPollForTransaction(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestPollForTransactionsCtx_Cancelled(t *testing.T) {
+	t.Parallel()
+	client, err := NewClient(LocalnetConfig)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = client.PollForTransactionsCtx(ctx, []string{"alice", "bob"}, PollTimeout(10*time.Second), PollPeriod(2*time.Millisecond))
+	dt := time.Since(start)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, dt, time.Second)
+}
+
+func TestNodeClient_AccountCtx_Cancelled(t *testing.T) {
+	t.Parallel()
+	// Server that never responds before the client gives up
+	release := make(chan struct{})
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer mockServer.Close()
+	defer close(release)
+
+	client, err := NewNodeClient(mockServer.URL, 4)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = client.AccountCtx(ctx, AccountOne)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNodeClient_BuildSignAndSubmitTransactionsCtx_Cancelled(t *testing.T) {
+	t.Parallel()
+	// Server that never accepts the submission before the client gives up
+	release := make(chan struct{})
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer mockServer.Close()
+	defer close(release)
+
+	client, err := NewNodeClient(mockServer.URL, 4)
+	require.NoError(t, err)
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 1)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Orderless, so only the submission makes a request
+	payloads := make(chan TransactionBuildPayload, 1)
+	responses := make(chan TransactionSubmissionResponse, 1)
+	payloads <- TransactionBuildPayload{Type: TransactionSubmissionTypeSingle, Inner: TransactionPayload{Payload: payload}}
+	close(payloads)
+	go client.BuildSignAndSubmitTransactionsCtx(ctx, sender, payloads, responses, GasUnitPrice(100), Orderless(true))
+	response := <-responses
+	require.ErrorIs(t, response.Err, context.DeadlineExceeded)
+}
+
 func TestEventsByHandle(t *testing.T) {
 	t.Parallel()
 	createMockServer := func(t *testing.T) *httptest.Server {
@@ -334,3 +407,126 @@ func TestNodeClient_ViewWithResponse(t *testing.T) {
 	assert.Equal(t, "0xa0d9d647c5737a5aed08d2cfeb39c31cf901d44bc4aa024eaa7e5e68b804e011", out[1].Inner)
 	assert.Equal(t, "0xaef6a8c3182e076db72d64324617114cacf9a52f28325edc10b483f7f05da0e7", out[2].Inner)
 }
+
+// newSimulationServer serves the lookups of building a transaction, and simulates it with the given result
+func newSimulationServer(t *testing.T, success bool, vmStatus string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transactions/simulate":
+			assert.Equal(t, "true", r.URL.Query().Get("estimate_max_gas_amount"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{{
+				"type":           "user_transaction",
+				"version":        "0",
+				"gas_used":       "1000",
+				"max_gas_amount": "2000000",
+				"success":        success,
+				"vm_status":      vmStatus,
+			}})
+		default:
+			_, _ = w.Write([]byte(`{"sequence_number":"3","authentication_key":"0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+		}
+	}))
+}
+
+func TestNodeClient_BuildTransaction_SimulateMaxGas(t *testing.T) {
+	t.Parallel()
+	server := newSimulationServer(t, true, "Executed successfully")
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	require.NoError(t, err)
+
+	rawTxn, err := client.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload}, GasUnitPrice(100), SimulateMaxGas{Signer: sender})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1500), rawTxn.MaxGasAmount)
+	assert.Equal(t, uint64(3), rawTxn.SequenceNumber)
+
+	rawTxn, err = client.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload}, GasUnitPrice(100), SimulateMaxGas{Signer: sender, GasMultiplier: 2})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2000), rawTxn.MaxGasAmount)
+
+	// An explicit max gas amount caps the estimate
+	rawTxn, err = client.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload}, GasUnitPrice(100), MaxGasAmount(1200), SimulateMaxGas{Signer: sender})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1200), rawTxn.MaxGasAmount)
+
+	_, err = client.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload}, GasUnitPrice(100), SimulateMaxGas{})
+	require.Error(t, err)
+}
+
+func TestNodeClient_BuildTransactionMultiAgent_SimulateMaxGas(t *testing.T) {
+	t.Parallel()
+	server := newSimulationServer(t, true, "Executed successfully")
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	require.NoError(t, err)
+
+	rawTxn, err := client.BuildTransactionMultiAgent(sender.AccountAddress(), TransactionPayload{Payload: payload}, GasUnitPrice(100), FeePayer(&AccountZero), SimulateMaxGas{Signer: sender})
+	require.NoError(t, err)
+	require.Equal(t, MultiAgentWithFeePayerRawTransactionWithDataVariant, rawTxn.Variant)
+	inner, ok := rawTxn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	require.True(t, ok)
+	assert.Equal(t, uint64(1500), inner.RawTxn.MaxGasAmount)
+
+	rawTxn, err = client.BuildTransactionMultiAgent(sender.AccountAddress(), TransactionPayload{Payload: payload}, GasUnitPrice(100), AdditionalSigners{AccountTwo}, MaxGasAmount(1200), SimulateMaxGas{Signer: sender})
+	require.NoError(t, err)
+	multiAgent, ok := rawTxn.Inner.(*MultiAgentRawTransactionWithData)
+	require.True(t, ok)
+	assert.Equal(t, uint64(1200), multiAgent.RawTxn.MaxGasAmount)
+
+	_, err = client.BuildTransactionMultiAgent(sender.AccountAddress(), TransactionPayload{Payload: payload}, GasUnitPrice(100), FeePayer(&AccountZero), SimulateMaxGas{})
+	require.Error(t, err)
+}
+
+func TestNodeClient_BuildTransaction_SimulationError(t *testing.T) {
+	t.Parallel()
+	server := newSimulationServer(t, false, "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006)")
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	require.NoError(t, err)
+
+	_, err = client.BuildSignAndSubmitTransaction(sender, TransactionPayload{Payload: payload}, GasUnitPrice(100), SimulateMaxGas{})
+	var simulationErr *SimulationError
+	require.ErrorAs(t, err, &simulationErr)
+	assert.Equal(t, "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006)", simulationErr.VmStatus)
+	assert.Equal(t, uint64(1000), simulationErr.GasUsed)
+}
+
+func TestNodeClient_BuildSignAndSubmitTransactions_SimulateMaxGas(t *testing.T) {
+	t.Parallel()
+	server := newSimulationServer(t, false, "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006)")
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	require.NoError(t, err)
+
+	// The pipeline simulates as the sender, so the simulation's failure comes back rather than a missing Signer
+	payloads := make(chan TransactionBuildPayload, 1)
+	responses := make(chan TransactionSubmissionResponse, 1)
+	payloads <- TransactionBuildPayload{Id: 1, Type: TransactionSubmissionTypeSingle, Inner: TransactionPayload{Payload: payload}}
+	close(payloads)
+	go client.BuildSignAndSubmitTransactions(sender, payloads, responses, GasUnitPrice(100), SimulateMaxGas{})
+	response := <-responses
+	var simulationErr *SimulationError
+	require.ErrorAs(t, response.Err, &simulationErr)
+	assert.Equal(t, uint64(1000), simulationErr.GasUsed)
+}
@@ -0,0 +1,354 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latencySmoothing is the weight of the newest sample in an endpoint's moving average latency
+const latencySmoothing = 0.2
+
+// FailoverPolicy configures how a multi-endpoint [NodeClient] picks between its endpoints.  Pass it as an option to
+// [NewMultiNodeClient] or [NewClient].
+//
+// Requests go to the endpoint with the lowest latency among those that are healthy and caught up.  An endpoint that
+// fails with a transport error, 429 Too Many Requests, or a 5xx response is avoided for UnhealthyCooldown.  An endpoint
+// whose ledger version trails the newest one seen by more than MaxLedgerLag is only used when no caught up endpoint is
+// available.
+type FailoverPolicy struct {
+	MaxLedgerLag      uint64        // Versions an endpoint may trail the newest endpoint before it's avoided
+	UnhealthyCooldown time.Duration // How long an endpoint is avoided after a failure
+}
+
+// DefaultFailoverPolicy is a reasonable [FailoverPolicy] for public full nodes
+func DefaultFailoverPolicy() FailoverPolicy {
+	return FailoverPolicy{
+		MaxLedgerLag:      1000,
+		UnhealthyCooldown: 10 * time.Second,
+	}
+}
+
+// FailoverNodeUrls are additional node API endpoints of the same network for [NewClient] to route requests across,
+// alongside [NetworkConfig.NodeUrl]
+//
+//	client, err := NewClient(MainnetConfig, FailoverNodeUrls{"https://my-node.example.com/v1"})
+type FailoverNodeUrls []string
+
+// NodeEndpointStatus is a snapshot of the state of one endpoint of a multi-endpoint [NodeClient]
+type NodeEndpointStatus struct {
+	Url           string        // Base URL of the endpoint
+	Healthy       bool          // False while the endpoint is avoided after a failure
+	LedgerVersion uint64        // Latest ledger version reported by the endpoint, 0 if not yet known
+	Latency       time.Duration // Moving average latency of successful requests, 0 if not yet known
+	LastError     error         // Error of the last failed request, nil if the last request succeeded
+}
+
+// nodeEndpoint is the tracked state of one node endpoint, guarded by the mutex of its [nodePool]
+type nodeEndpoint struct {
+	baseUrl        string // Base URL without a trailing slash
+	unhealthyUntil time.Time
+	ledgerVersion  uint64
+	latency        time.Duration
+	lastErr        error
+}
+
+// nodePool routes requests across several node endpoints serving the same network
+type nodePool struct {
+	mutex     sync.Mutex
+	policy    FailoverPolicy
+	endpoints []*nodeEndpoint
+}
+
+// newNodePool creates a pool over the given base URLs, which must already be valid
+func newNodePool(baseUrls []*url.URL, policy FailoverPolicy) *nodePool {
+	endpoints := make([]*nodeEndpoint, len(baseUrls))
+	for i, baseUrl := range baseUrls {
+		endpoints[i] = &nodeEndpoint{baseUrl: strings.TrimSuffix(baseUrl.String(), "/")}
+	}
+	return &nodePool{policy: policy, endpoints: endpoints}
+}
+
+// relativePath strips baseUrl from requestUrl, returning false if requestUrl isn't a request to the node API
+func (pool *nodePool) relativePath(baseUrl *url.URL, requestUrl string) (string, bool) {
+	prefix := strings.TrimSuffix(baseUrl.String(), "/")
+	path, ok := strings.CutPrefix(requestUrl, prefix)
+	// The base URL must end at a path segment, so a base of .../v1 doesn't match .../v10
+	if !ok || (path != "" && !strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "?")) {
+		return "", false
+	}
+	return path, true
+}
+
+// do sends a request for path to the best endpoint.  Idempotent requests fail over to the next best endpoint for as
+// long as the failure is one that another endpoint may not have.
+func (pool *nodePool) do(ctx context.Context, path string, idempotent bool, send func(endpointUrl string) (*rawResponse, error)) (*rawResponse, error) {
+	var err error
+	for _, endpoint := range pool.candidates() {
+		start := time.Now()
+		var response *rawResponse
+		response, err = send(endpoint.baseUrl + path)
+		pool.record(ctx, endpoint, time.Since(start), response, err)
+		if err == nil {
+			return response, nil
+		}
+		if !idempotent || !isRetryableError(ctx, err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// candidates orders the endpoints, healthy and caught up first, then lagging, then unhealthy, each by latency
+func (pool *nodePool) candidates() []*nodeEndpoint {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	now := time.Now()
+	newest := uint64(0)
+	for _, endpoint := range pool.endpoints {
+		newest = max(newest, endpoint.ledgerVersion)
+	}
+	tier := func(endpoint *nodeEndpoint) int {
+		switch {
+		case now.Before(endpoint.unhealthyUntil):
+			return 2
+		case endpoint.ledgerVersion != 0 && newest-endpoint.ledgerVersion > pool.policy.MaxLedgerLag:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	ordered := slices.Clone(pool.endpoints)
+	slices.SortStableFunc(ordered, func(a, b *nodeEndpoint) int {
+		if tierA, tierB := tier(a), tier(b); tierA != tierB {
+			return tierA - tierB
+		}
+		return int(a.latency - b.latency)
+	})
+	return ordered
+}
+
+// record updates an endpoint with the outcome of a request
+func (pool *nodePool) record(ctx context.Context, endpoint *nodeEndpoint, elapsed time.Duration, response *rawResponse, err error) {
+	var header http.Header
+	if response != nil {
+		header = response.header
+	}
+	var httpErr *HttpError
+	if errors.As(err, &httpErr) {
+		header = httpErr.Header
+	}
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if version, ok := ledgerVersionFromHeader(header); ok {
+		endpoint.ledgerVersion = version
+	}
+	switch {
+	case err == nil:
+		endpoint.unhealthyUntil = time.Time{}
+		endpoint.lastErr = nil
+		if endpoint.latency == 0 {
+			endpoint.latency = elapsed
+		} else {
+			endpoint.latency += time.Duration(latencySmoothing * float64(elapsed-endpoint.latency))
+		}
+	case isRetryableError(ctx, err):
+		endpoint.unhealthyUntil = time.Now().Add(pool.policy.UnhealthyCooldown)
+		endpoint.lastErr = err
+	default:
+		// The endpoint answered, the request itself was bad e.g. a 404
+		endpoint.unhealthyUntil = time.Time{}
+		endpoint.lastErr = nil
+	}
+}
+
+// status returns a snapshot of every endpoint
+func (pool *nodePool) status() []NodeEndpointStatus {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	now := time.Now()
+	statuses := make([]NodeEndpointStatus, len(pool.endpoints))
+	for i, endpoint := range pool.endpoints {
+		statuses[i] = NodeEndpointStatus{
+			Url:           endpoint.baseUrl,
+			Healthy:       !now.Before(endpoint.unhealthyUntil),
+			LedgerVersion: endpoint.ledgerVersion,
+			Latency:       endpoint.latency,
+			LastError:     endpoint.lastErr,
+		}
+	}
+	return statuses
+}
+
+// markUnhealthy avoids an endpoint for the cooldown after a failed health check
+func (pool *nodePool) markUnhealthy(endpoint *nodeEndpoint, err error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	endpoint.unhealthyUntil = time.Now().Add(pool.policy.UnhealthyCooldown)
+	endpoint.lastErr = err
+}
+
+// ledgerVersionFromHeader parses the [LedgerVersionHeader] of a response
+func ledgerVersionFromHeader(header http.Header) (uint64, bool) {
+	if header == nil {
+		return 0, false
+	}
+	value := header.Get(LedgerVersionHeader)
+	if value == "" {
+		return 0, false
+	}
+	version, err := strconv.ParseUint(value, 10, 64)
+	return version, err == nil
+}
+
+// NewMultiNodeClient creates a new client that spreads requests across several node API endpoints of the same network
+//
+// The first URL is used as the client's base URL.  Requests are sent to the best endpoint according to the
+// [FailoverPolicy], and requests that are safe to repeat fail over to the next endpoint on transient errors.
+//
+// Accepts options:
+//   - [RetryPolicy]
+//   - [*AbiCache]
+//   - [FailoverPolicy], defaults to [DefaultFailoverPolicy]
+func NewMultiNodeClient(rpcUrls []string, chainId uint8, options ...any) (*NodeClient, error) {
+	if len(rpcUrls) == 0 {
+		return nil, errors.New("NewMultiNodeClient requires at least one url")
+	}
+	// The default client is set up the same as for a single endpoint
+	defaultClient, err := NewNodeClient(rpcUrls[0], chainId)
+	if err != nil {
+		return nil, err
+	}
+	return NewMultiNodeClientWithHttpClient(rpcUrls, chainId, defaultClient.client, options...)
+}
+
+// NewMultiNodeClientWithHttpClient creates a new client that spreads requests across several node API endpoints of
+// the same network, with a custom http.Client
+//
+// Accepts options:
+//   - [RetryPolicy]
+//   - [*AbiCache]
+//   - [FailoverPolicy], defaults to [DefaultFailoverPolicy]
+func NewMultiNodeClientWithHttpClient(rpcUrls []string, chainId uint8, client *http.Client, options ...any) (*NodeClient, error) {
+	if len(rpcUrls) == 0 {
+		return nil, errors.New("NewMultiNodeClient requires at least one url")
+	}
+	policy := DefaultFailoverPolicy()
+	var nodeOptions []any
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case FailoverPolicy:
+			policy = value
+		case RetryPolicy, *AbiCache:
+			nodeOptions = append(nodeOptions, value)
+		default:
+			return nil, fmt.Errorf("NewMultiNodeClient arg %d bad type %T", i+1, arg)
+		}
+	}
+
+	nodeClient, err := NewNodeClientWithHttpClient(rpcUrls[0], chainId, client, nodeOptions...)
+	if err != nil {
+		return nil, err
+	}
+	baseUrls := make([]*url.URL, len(rpcUrls))
+	for i, rpcUrl := range rpcUrls {
+		baseUrls[i], err = url.Parse(rpcUrl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RPC url '%s': %w", rpcUrl, err)
+		}
+	}
+	nodeClient.pool = newNodePool(baseUrls, policy)
+	return nodeClient, nil
+}
+
+// EndpointStatus returns the state of each endpoint of a client made with [NewMultiNodeClient], nil for a single
+// endpoint client
+func (rc *NodeClient) EndpointStatus() []NodeEndpointStatus {
+	if rc.pool == nil {
+		return nil
+	}
+	return rc.pool.status()
+}
+
+// CheckEndpointHealth probes every endpoint concurrently, marking those that fail the node health check or report the
+// wrong chain ID as unhealthy.  Returns the failures of all unhealthy endpoints joined together, nil if all are healthy.
+//
+// For a single endpoint client, it's the same as [NodeClient.NodeAPIHealthCheckCtx].
+func (rc *NodeClient) CheckEndpointHealth(ctx context.Context) error {
+	if rc.pool == nil {
+		_, err := rc.NodeAPIHealthCheckCtx(ctx)
+		return err
+	}
+
+	errs := make([]error, len(rc.pool.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range rc.pool.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = rc.checkEndpoint(ctx, endpoint)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// checkEndpoint runs the health check against a single endpoint, bypassing routing and retries
+func (rc *NodeClient) checkEndpoint(ctx context.Context, endpoint *nodeEndpoint) error {
+	baseUrl, err := url.Parse(endpoint.baseUrl)
+	if err != nil {
+		return err
+	}
+	probe := &NodeClient{
+		client:  rc.client,
+		baseUrl: baseUrl,
+		headers: rc.headers,
+	}
+
+	start := time.Now()
+	var info NodeInfo
+	_, err = probe.NodeAPIHealthCheckCtx(ctx)
+	if err == nil {
+		info, err = probe.InfoCtx(ctx)
+	}
+	if err == nil && rc.chainId != 0 && info.ChainId != rc.chainId {
+		err = fmt.Errorf("expected chain id %d, got %d", rc.chainId, info.ChainId)
+	}
+	if err != nil {
+		err = fmt.Errorf("endpoint %s unhealthy: %w", endpoint.baseUrl, err)
+		rc.pool.markUnhealthy(endpoint, err)
+		return err
+	}
+
+	header := http.Header{}
+	header.Set(LedgerVersionHeader, info.LedgerVersionStr)
+	rc.pool.record(ctx, endpoint, time.Since(start), &rawResponse{header: header}, nil)
+	return nil
+}
+
+// MonitorEndpointHealth runs [NodeClient.CheckEndpointHealth] every interval until ctx is done
+//
+//	go client.MonitorEndpointHealth(ctx, 30*time.Second)
+func (rc *NodeClient) MonitorEndpointHealth(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_ = rc.CheckEndpointHealth(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package aptos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newVersionedServer responds with body, reporting ledgerVersion in the response headers
+func newVersionedServer(t *testing.T, ledgerVersion string, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set(LedgerVersionHeader, ledgerVersion)
+		_, _ = w.Write([]byte(body))
+	}))
+	return server, calls
+}
+
+func TestNodePool_FailsOver(t *testing.T) {
+	t.Parallel()
+	down, downCalls := newFlakyServer(t, 10, http.StatusServiceUnavailable, `{}`)
+	defer down.Close()
+	up, upCalls := newVersionedServer(t, "100", `{"gas_estimate":100}`)
+	defer up.Close()
+
+	client, err := NewMultiNodeClient([]string{down.URL, up.URL}, 4)
+	require.NoError(t, err)
+
+	info, err := client.EstimateGasPrice()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), info.GasEstimate)
+	assert.Equal(t, int32(1), downCalls.Load())
+	assert.Equal(t, int32(1), upCalls.Load())
+
+	// The failed endpoint is avoided for the cooldown
+	_, err = client.EstimateGasPrice()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), downCalls.Load())
+	assert.Equal(t, int32(2), upCalls.Load())
+
+	status := client.EndpointStatus()
+	require.Len(t, status, 2)
+	assert.False(t, status[0].Healthy)
+	require.Error(t, status[0].LastError)
+	assert.True(t, status[1].Healthy)
+	assert.Equal(t, uint64(100), status[1].LedgerVersion)
+}
+
+func TestNodePool_AvoidsLaggingEndpoint(t *testing.T) {
+	t.Parallel()
+	lagging, laggingCalls := newVersionedServer(t, "10", `{"chain_id":4,"ledger_version":"10","gas_estimate":1}`)
+	defer lagging.Close()
+	current, currentCalls := newVersionedServer(t, "5000", `{"chain_id":4,"ledger_version":"5000","gas_estimate":2}`)
+	defer current.Close()
+
+	client, err := NewMultiNodeClient([]string{lagging.URL, current.URL}, 4, FailoverPolicy{
+		MaxLedgerLag:      100,
+		UnhealthyCooldown: time.Minute,
+	})
+	require.NoError(t, err)
+
+	// Learn the ledger versions of both endpoints
+	require.NoError(t, client.CheckEndpointHealth(context.Background()))
+	assert.Equal(t, int32(2), laggingCalls.Load())
+	assert.Equal(t, int32(2), currentCalls.Load())
+
+	info, err := client.EstimateGasPrice()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), info.GasEstimate)
+	assert.Equal(t, int32(2), laggingCalls.Load())
+}
+
+func TestNodePool_CheckEndpointHealth(t *testing.T) {
+	t.Parallel()
+	wrongChain, _ := newVersionedServer(t, "10", `{"chain_id":1,"ledger_version":"10","message":"ok"}`)
+	defer wrongChain.Close()
+	rightChain, _ := newVersionedServer(t, "10", `{"chain_id":4,"ledger_version":"10","message":"ok"}`)
+	defer rightChain.Close()
+
+	client, err := NewMultiNodeClient([]string{wrongChain.URL, rightChain.URL}, 4)
+	require.NoError(t, err)
+
+	err = client.CheckEndpointHealth(context.Background())
+	require.ErrorContains(t, err, "expected chain id 4, got 1")
+
+	status := client.EndpointStatus()
+	require.Len(t, status, 2)
+	assert.False(t, status[0].Healthy)
+	assert.True(t, status[1].Healthy)
+	assert.Equal(t, uint64(10), status[1].LedgerVersion)
+}
+
+func TestNodePool_PostNotFailedOver(t *testing.T) {
+	t.Parallel()
+	down, downCalls := newFlakyServer(t, 10, http.StatusServiceUnavailable, `{}`)
+	defer down.Close()
+	up, upCalls := newVersionedServer(t, "100", `{}`)
+	defer up.Close()
+
+	client, err := NewMultiNodeClient([]string{down.URL, up.URL}, 4)
+	require.NoError(t, err)
+
+	_, err = Post[map[string]any](client, client.baseUrl.JoinPath("transactions").String(), "application/json", strings.NewReader("{}"))
+	require.Error(t, err)
+	assert.Equal(t, int32(1), downCalls.Load())
+	assert.Equal(t, int32(0), upCalls.Load())
+}
+
+func TestNodePool_SingleEndpoint(t *testing.T) {
+	t.Parallel()
+	client, err := NewNodeClient("https://localhost", 4)
+	require.NoError(t, err)
+	assert.Nil(t, client.EndpointStatus())
+
+	_, err = NewMultiNodeClient(nil, 4)
+	require.Error(t, err)
+}
+
+func TestNodePool_RelativePath(t *testing.T) {
+	t.Parallel()
+	pool := &nodePool{}
+	baseUrl, err := url.Parse("https://node.example/v1")
+	require.NoError(t, err)
+
+	for requestUrl, expected := range map[string]string{
+		"https://node.example/v1":                  "",
+		"https://node.example/v1/accounts/0x1":     "/accounts/0x1",
+		"https://node.example/v1?ledger_version=1": "?ledger_version=1",
+	} {
+		path, ok := pool.relativePath(baseUrl, requestUrl)
+		assert.True(t, ok, requestUrl)
+		assert.Equal(t, expected, path, requestUrl)
+	}
+	for _, requestUrl := range []string{"https://node.example/v10/accounts/0x1", "https://node.example/v1x", "https://other.example/v1"} {
+		_, ok := pool.relativePath(baseUrl, requestUrl)
+		assert.False(t, ok, requestUrl)
+	}
+}
@@ -0,0 +1,138 @@
+package aptos
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+)
+
+// MaxOrderlessExpirationSeconds is how far in the future an orderless transaction may expire, the chain rejects ones
+// expiring later, as it only remembers replay nonces for that long
+const MaxOrderlessExpirationSeconds = uint64(60)
+
+// OrderlessSequenceNumber is the sequence number given to orderless transactions, which the chain ignores
+const OrderlessSequenceNumber = uint64(0xdeadbeef)
+
+// Orderless will make a transaction orderless, with a random replay nonce from [NewReplayNonce].  Orderless
+// transactions don't use the sender's sequence number, so any number of them can be in flight, in any order.
+//
+// Orderless transactions expire in [MaxOrderlessExpirationSeconds] unless [ExpirationSeconds] is given, which can't be
+// more than that.
+type Orderless bool
+
+// ReplayNonce will make a transaction orderless, with the given replay nonce.  A nonce can only be used once by a
+// sender while its transactions may be pending, see [Orderless].
+type ReplayNonce uint64
+
+// NewReplayNonce returns a cryptographically random replay nonce for an orderless transaction
+func NewReplayNonce() (uint64, error) {
+	nonceBytes := make([]byte, 8)
+	_, err := rand.Read(nonceBytes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate replay nonce: %w", err)
+	}
+	return binary.LittleEndian.Uint64(nonceBytes), nil
+}
+
+// OrderlessPayload wraps payload in a [TransactionInnerPayloadV1] with the replay nonce, making the transaction
+// orderless.  A multisig payload keeps its multisig address in the [TransactionExtraConfigV1], and a payload that's
+// already wrapped gets its nonce replaced.
+func OrderlessPayload(payload TransactionPayload, nonce uint64) (TransactionPayload, error) {
+	config := &TransactionExtraConfigV1{ReplayProtectionNonce: &nonce}
+	var executable TransactionExecutableImpl
+	switch inner := payload.Payload.(type) {
+	case *EntryFunction:
+		executable = inner
+	case *Script:
+		executable = inner
+	case *Multisig:
+		multisigAddress := inner.MultisigAddress
+		config.MultisigAddress = &multisigAddress
+		executable = &TransactionExecutableEmpty{}
+		if inner.Payload != nil {
+			entryFunction, ok := inner.Payload.Payload.(*EntryFunction)
+			if !ok {
+				return TransactionPayload{}, fmt.Errorf("unsupported multisig payload %T", inner.Payload.Payload)
+			}
+			executable = entryFunction
+		}
+	case *TransactionInnerPayload:
+		v1, ok := inner.Payload.(*TransactionInnerPayloadV1)
+		if !ok {
+			return TransactionPayload{}, fmt.Errorf("unsupported inner payload %T", inner.Payload)
+		}
+		executable = v1.Executable.Inner
+		if existing, ok := v1.ExtraConfig.Inner.(*TransactionExtraConfigV1); ok {
+			config.MultisigAddress = existing.MultisigAddress
+		}
+	default:
+		return TransactionPayload{}, fmt.Errorf("unsupported payload %T for an orderless transaction", payload.Payload)
+	}
+	return TransactionPayload{Payload: &TransactionInnerPayload{
+		Payload: &TransactionInnerPayloadV1{
+			Executable:  TransactionExecutable{Inner: executable},
+			ExtraConfig: TransactionExtraConfig{Inner: config},
+		},
+	}}, nil
+}
+
+// PayloadReplayNonce is the replay nonce of an orderless transaction's payload, or nil if it has none
+func PayloadReplayNonce(payload *TransactionPayload) *uint64 {
+	inner, ok := payload.Payload.(*TransactionInnerPayload)
+	if !ok {
+		return nil
+	}
+	v1, ok := inner.Payload.(*TransactionInnerPayloadV1)
+	if !ok {
+		return nil
+	}
+	config, ok := v1.ExtraConfig.Inner.(*TransactionExtraConfigV1)
+	if !ok {
+		return nil
+	}
+	return config.ReplayProtectionNonce
+}
+
+// orderlessPayload wraps payload for an orderless transaction if the [Orderless] or [ReplayNonce] options ask for one,
+// returning it and whether the transaction is orderless, which it also is if the payload already has a replay nonce
+func orderlessPayload(payload TransactionPayload, orderless bool, replayNonce *uint64) (TransactionPayload, bool, error) {
+	if replayNonce == nil && orderless {
+		nonce, err := NewReplayNonce()
+		if err != nil {
+			return TransactionPayload{}, false, err
+		}
+		replayNonce = &nonce
+	}
+	if replayNonce != nil {
+		payload, err := OrderlessPayload(payload, *replayNonce)
+		return payload, true, err
+	}
+	return payload, PayloadReplayNonce(&payload) != nil, nil
+}
+
+// orderlessExpirationSeconds is the expiration of an orderless transaction, [MaxOrderlessExpirationSeconds] unless
+// [ExpirationSeconds] was given
+func orderlessExpirationSeconds(expirationSeconds uint64, haveExpirationSeconds bool) (uint64, error) {
+	if !haveExpirationSeconds {
+		return MaxOrderlessExpirationSeconds, nil
+	}
+	if expirationSeconds > MaxOrderlessExpirationSeconds {
+		return 0, fmt.Errorf("orderless transactions must expire within %d seconds, not %d", MaxOrderlessExpirationSeconds, expirationSeconds)
+	}
+	return expirationSeconds, nil
+}
+
+// isOrderless is whether options include [Orderless], or a [ReplayNonce]
+func isOrderless(options []any) bool {
+	for _, option := range options {
+		switch value := option.(type) {
+		case Orderless:
+			if value {
+				return true
+			}
+		case ReplayNonce:
+			return true
+		}
+	}
+	return false
+}
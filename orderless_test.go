@@ -0,0 +1,170 @@
+package aptos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderlessPayload(t *testing.T) {
+	t.Parallel()
+	entryFunction, err := CoinTransferPayload(nil, AccountOne, 100)
+	require.NoError(t, err)
+	script := &Script{Code: []byte{1, 2, 3}, ArgTypes: []TypeTag{}, Args: []ScriptArgument{}}
+	multisigAddress := AccountTwo
+	multisig := &Multisig{
+		MultisigAddress: multisigAddress,
+		Payload: &MultisigTransactionPayload{
+			Variant: MultisigTransactionPayloadVariantEntryFunction,
+			Payload: entryFunction,
+		},
+	}
+
+	for name, test := range map[string]struct {
+		payload    TransactionPayloadImpl
+		executable TransactionExecutableImpl
+		multisig   *AccountAddress
+	}{
+		"entry function": {entryFunction, entryFunction, nil},
+		"script":         {script, script, nil},
+		"multisig":       {multisig, entryFunction, &multisigAddress},
+		"multisig vote":  {&Multisig{MultisigAddress: multisigAddress}, &TransactionExecutableEmpty{}, &multisigAddress},
+	} {
+		payload, err := OrderlessPayload(TransactionPayload{Payload: test.payload}, 42)
+		require.NoError(t, err, name)
+		assert.Equal(t, uint64(42), *PayloadReplayNonce(&payload), name)
+
+		// Survives BCS
+		payloadBytes, err := bcs.Serialize(&payload)
+		require.NoError(t, err, name)
+		decoded := TransactionPayload{}
+		require.NoError(t, bcs.Deserialize(&decoded, payloadBytes), name)
+		assert.Equal(t, payload, decoded, name)
+
+		v1 := decoded.Payload.(*TransactionInnerPayload).Payload.(*TransactionInnerPayloadV1)
+		assert.Equal(t, test.executable, v1.Executable.Inner, name)
+		assert.Equal(t, test.multisig, v1.ExtraConfig.Inner.(*TransactionExtraConfigV1).MultisigAddress, name)
+
+		// Wrapping again replaces the nonce
+		rewrapped, err := OrderlessPayload(payload, 7)
+		require.NoError(t, err, name)
+		assert.Equal(t, uint64(7), *PayloadReplayNonce(&rewrapped), name)
+		assert.Equal(t, uint64(42), *PayloadReplayNonce(&payload), name)
+	}
+
+	assert.Nil(t, PayloadReplayNonce(&TransactionPayload{Payload: entryFunction}))
+	_, err = OrderlessPayload(TransactionPayload{Payload: &ModuleBundle{}}, 1)
+	require.Error(t, err)
+
+	first, err := NewReplayNonce()
+	require.NoError(t, err)
+	second, err := NewReplayNonce()
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestNodeClient_BuildTransactionOrderless(t *testing.T) {
+	t.Parallel()
+	// Nothing is looked up on chain, given the gas unit price and chain id
+	client, err := NewNodeClient("http://localhost:1", 4)
+	require.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	require.NoError(t, err)
+
+	before := uint64(time.Now().Unix())
+	rawTxn, err := client.BuildTransaction(AccountTwo, TransactionPayload{Payload: payload}, GasUnitPrice(100), Orderless(true))
+	require.NoError(t, err)
+	assert.Equal(t, OrderlessSequenceNumber, rawTxn.SequenceNumber)
+	assert.NotNil(t, PayloadReplayNonce(&rawTxn.Payload))
+	assert.GreaterOrEqual(t, rawTxn.ExpirationTimestampSeconds, before+MaxOrderlessExpirationSeconds)
+	assert.LessOrEqual(t, rawTxn.ExpirationTimestampSeconds, uint64(time.Now().Unix())+MaxOrderlessExpirationSeconds)
+	other, err := client.BuildTransaction(AccountTwo, TransactionPayload{Payload: payload}, GasUnitPrice(100), Orderless(true))
+	require.NoError(t, err)
+	assert.NotEqual(t, *PayloadReplayNonce(&rawTxn.Payload), *PayloadReplayNonce(&other.Payload))
+
+	// A given nonce, and a shorter expiration
+	rawTxn, err = client.BuildTransaction(AccountTwo, TransactionPayload{Payload: payload}, GasUnitPrice(100), ReplayNonce(5), ExpirationSeconds(10))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), *PayloadReplayNonce(&rawTxn.Payload))
+	assert.LessOrEqual(t, rawTxn.ExpirationTimestampSeconds, uint64(time.Now().Unix())+10)
+	_, err = client.BuildTransaction(AccountTwo, TransactionPayload{Payload: payload}, GasUnitPrice(100), Orderless(true), ExpirationSeconds(61))
+	require.Error(t, err)
+
+	// An already wrapped payload is orderless too
+	wrapped, err := OrderlessPayload(TransactionPayload{Payload: payload}, 9)
+	require.NoError(t, err)
+	rawTxn, err = client.BuildTransaction(AccountTwo, wrapped, GasUnitPrice(100))
+	require.NoError(t, err)
+	assert.Equal(t, OrderlessSequenceNumber, rawTxn.SequenceNumber)
+
+	feePayer := AccountThree
+	rawTxnWithData, err := client.BuildTransactionMultiAgent(AccountTwo, TransactionPayload{Payload: payload}, GasUnitPrice(100), FeePayer(&feePayer), ReplayNonce(3))
+	require.NoError(t, err)
+	inner := rawTxnWithData.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	assert.Equal(t, OrderlessSequenceNumber, inner.RawTxn.SequenceNumber)
+	assert.Equal(t, uint64(3), *PayloadReplayNonce(&inner.RawTxn.Payload))
+}
+
+func TestNodeClient_BuildSignAndSubmitTransactionsOrderless(t *testing.T) {
+	t.Parallel()
+	// A node that only accepts submissions, as nothing else should be needed
+	mutex := sync.Mutex{}
+	nonces := map[uint64]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "/transactions", r.URL.Path) {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		signedTxn := &SignedTransaction{}
+		assert.NoError(t, bcs.Deserialize(signedTxn, body))
+		assert.NoError(t, signedTxn.Verify())
+		mutex.Lock()
+		nonces[*PayloadReplayNonce(&signedTxn.Transaction.Payload)] = true
+		mutex.Unlock()
+		hash, err := signedTxn.Hash()
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintf(w, `{"hash":%q}`, hash)
+	}))
+	defer server.Close()
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+
+	const count = 10
+	payloads := make(chan TransactionBuildPayload, count)
+	responses := make(chan TransactionSubmissionResponse, count)
+	for id := range uint64(count) {
+		payload, err := CoinTransferPayload(nil, AccountOne, id+1)
+		require.NoError(t, err)
+		payloads <- TransactionBuildPayload{Id: id, Type: TransactionSubmissionTypeSingle, Inner: TransactionPayload{Payload: payload}}
+	}
+	close(payloads)
+	go client.BuildSignAndSubmitTransactions(sender, payloads, responses, GasUnitPrice(100), Orderless(true))
+	for response := range responses {
+		require.NoError(t, response.Err)
+	}
+	assert.Len(t, nonces, count)
+
+	// A single nonce can't be used by every transaction
+	payloads = make(chan TransactionBuildPayload, 1)
+	responses = make(chan TransactionSubmissionResponse, 1)
+	payload, err := CoinTransferPayload(nil, AccountOne, 1)
+	require.NoError(t, err)
+	payloads <- TransactionBuildPayload{Type: TransactionSubmissionTypeSingle, Inner: TransactionPayload{Payload: payload}}
+	close(payloads)
+	go client.BuildSignAndSubmitTransactions(sender, payloads, responses, GasUnitPrice(100), ReplayNonce(1))
+	response := <-responses
+	require.Error(t, response.Err)
+}
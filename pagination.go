@@ -0,0 +1,297 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// iterPageSize is the number of items requested per page by the iterators that page by version or sequence number.
+// Nodes may return fewer, so a short page doesn't mean it's the last one.
+const iterPageSize = 100
+
+// paginate yields the items of each page returned by fetchPage, starting at cursor start.  fetchPage returns the cursor
+// of the next page, or "" if it was the last page.
+//
+// Iteration stops after the first error, which is yielded with the zero value of T.
+func paginate[T any](start string, fetchPage func(cursor string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		cursor := start
+		for {
+			page, next, err := fetchPage(cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if next == "" {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// TransactionsIter iterates over committed transactions in ascending version order, starting at version start.
+// Pages are fetched lazily as the iteration proceeds, and the iteration ends at the latest ledger version at the time
+// the last page was fetched, or at an empty page if the node doesn't report it.
+//
+//	for txn, err := range client.TransactionsIter(0) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(txn.Version())
+//	}
+func (rc *NodeClient) TransactionsIter(start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return rc.TransactionsIterCtx(context.Background(), start)
+}
+
+// TransactionsIterCtx is [NodeClient.TransactionsIter] with all page requests bound to ctx
+func (rc *NodeClient) TransactionsIterCtx(ctx context.Context, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return paginate(strconv.FormatUint(start, 10), func(cursor string) ([]*api.CommittedTransaction, string, error) {
+		pageStart, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		params := url.Values{}
+		params.Set("start", strconv.FormatUint(pageStart, 10))
+		params.Set("limit", strconv.Itoa(iterPageSize))
+		requestUrl := rc.baseUrl.JoinPath("transactions")
+		requestUrl.RawQuery = params.Encode()
+
+		txns, header, err := getWithHeader[[]*api.CommittedTransaction](ctx, rc, requestUrl.String())
+		if err != nil {
+			return nil, "", fmt.Errorf("get transactions api err: %w", err)
+		}
+		if len(txns) == 0 {
+			return txns, "", nil
+		}
+		last := txns[len(txns)-1].Version()
+		ledgerVersion, err := strconv.ParseUint(header.Get(LedgerVersionHeader), 10, 64)
+		if err == nil && last >= ledgerVersion {
+			return txns, "", nil
+		}
+		return txns, strconv.FormatUint(last+1, 10), nil
+	})
+}
+
+// AccountTransactionsIter iterates over the transactions sent by an account in ascending sequence number order,
+// starting at sequence number start.  Pages are fetched lazily as the iteration proceeds.
+//
+//	for txn, err := range client.AccountTransactionsIter(address, 0) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(txn.Hash())
+//	}
+func (rc *NodeClient) AccountTransactionsIter(account AccountAddress, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return rc.AccountTransactionsIterCtx(context.Background(), account, start)
+}
+
+// AccountTransactionsIterCtx is [NodeClient.AccountTransactionsIter] with all page requests bound to ctx
+func (rc *NodeClient) AccountTransactionsIterCtx(ctx context.Context, account AccountAddress, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return paginate(strconv.FormatUint(start, 10), func(cursor string) ([]*api.CommittedTransaction, string, error) {
+		pageStart, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		limit := uint64(iterPageSize)
+		txns, err := rc.accountTransactionsInner(ctx, account, &pageStart, &limit)
+		if err != nil || len(txns) == 0 {
+			return txns, "", err
+		}
+		// It will always be a UserTransaction, no other type will come from the API
+		userTxn, err := txns[len(txns)-1].UserTransaction()
+		if err != nil {
+			return nil, "", err
+		}
+		return txns, strconv.FormatUint(userTxn.SequenceNumber+1, 10), nil
+	})
+}
+
+// EventsByHandleIter iterates over the events of an event handle in ascending sequence number order, starting at
+// sequence number start.  Pages are fetched lazily as the iteration proceeds.
+//
+// Arguments are the same as [NodeClient.EventsByHandle]
+func (rc *NodeClient) EventsByHandleIter(account AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error] {
+	return rc.EventsByHandleIterCtx(context.Background(), account, eventHandle, fieldName, start)
+}
+
+// EventsByHandleIterCtx is [NodeClient.EventsByHandleIter] with all page requests bound to ctx
+func (rc *NodeClient) EventsByHandleIterCtx(ctx context.Context, account AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error] {
+	basePath := fmt.Sprintf("accounts/%s/events/%s/%s",
+		account.String(),
+		eventHandle,
+		fieldName)
+	return rc.eventsIter(ctx, rc.baseUrl.JoinPath(basePath), start)
+}
+
+// EventsByCreationNumberIter iterates over the events of an event stream in ascending sequence number order, starting
+// at sequence number start.  Pages are fetched lazily as the iteration proceeds.
+//
+// Arguments are the same as [NodeClient.EventsByCreationNumber]
+func (rc *NodeClient) EventsByCreationNumberIter(account AccountAddress, creationNumber string, start uint64) iter.Seq2[*api.Event, error] {
+	return rc.EventsByCreationNumberIterCtx(context.Background(), account, creationNumber, start)
+}
+
+// EventsByCreationNumberIterCtx is [NodeClient.EventsByCreationNumberIter] with all page requests bound to ctx
+func (rc *NodeClient) EventsByCreationNumberIterCtx(ctx context.Context, account AccountAddress, creationNumber string, start uint64) iter.Seq2[*api.Event, error] {
+	basePath := fmt.Sprintf("accounts/%s/events/%s",
+		account.String(),
+		creationNumber)
+	return rc.eventsIter(ctx, rc.baseUrl.JoinPath(basePath), start)
+}
+
+// eventsIter is a helper function for iterating over an events endpoint by sequence number
+func (rc *NodeClient) eventsIter(ctx context.Context, baseUrl *url.URL, start uint64) iter.Seq2[*api.Event, error] {
+	return paginate(strconv.FormatUint(start, 10), func(cursor string) ([]*api.Event, string, error) {
+		params := url.Values{}
+		params.Set("start", cursor)
+		params.Set("limit", strconv.Itoa(iterPageSize))
+		requestUrl := *baseUrl
+		requestUrl.RawQuery = params.Encode()
+
+		events, err := GetCtx[[]*api.Event](ctx, rc, requestUrl.String())
+		if err != nil {
+			return nil, "", fmt.Errorf("get events api err: %w", err)
+		}
+		if len(events) == 0 {
+			return events, "", nil
+		}
+		return events, strconv.FormatUint(events[len(events)-1].SequenceNumber+1, 10), nil
+	})
+}
+
+// AccountResourcesIter iterates over the resources of an account, following the node's [CursorHeader] from page to
+// page.  Optionally, a ledgerVersion can be given to get the account state at a specific ledger version, otherwise
+// every page is read at the ledger version of the first page.
+//
+//	for resource, err := range client.AccountResourcesIter(address) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(resource.Type)
+//	}
+func (rc *NodeClient) AccountResourcesIter(address AccountAddress, ledgerVersion ...uint64) iter.Seq2[AccountResourceInfo, error] {
+	return rc.AccountResourcesIterCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesIterCtx is [NodeClient.AccountResourcesIter] with all page requests bound to ctx
+func (rc *NodeClient) AccountResourcesIterCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) iter.Seq2[AccountResourceInfo, error] {
+	return cursorIter[AccountResourceInfo](ctx, rc, rc.baseUrl.JoinPath("accounts", address.String(), "resources"), "get resources api err", ledgerVersion...)
+}
+
+// AccountModulesIter iterates over the modules of an account with their ABIs, following the node's [CursorHeader] from
+// page to page.  Optionally, a ledgerVersion can be given to get the account state at a specific ledger version,
+// otherwise every page is read at the ledger version of the first page.
+func (rc *NodeClient) AccountModulesIter(address AccountAddress, ledgerVersion ...uint64) iter.Seq2[*api.MoveBytecode, error] {
+	return rc.AccountModulesIterCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountModulesIterCtx is [NodeClient.AccountModulesIter] with all page requests bound to ctx
+func (rc *NodeClient) AccountModulesIterCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) iter.Seq2[*api.MoveBytecode, error] {
+	return cursorIter[*api.MoveBytecode](ctx, rc, rc.baseUrl.JoinPath("accounts", address.String(), "modules"), "get modules api err", ledgerVersion...)
+}
+
+// cursorIter is a helper function for iterating over an endpoint paginated by [CursorHeader], the page size is left
+// to the node.
+//
+// Without a ledgerVersion, the pages after the first are pinned to the ledger version the first page was read at, so
+// the iteration sees a consistent state.
+func cursorIter[T any](ctx context.Context, rc *NodeClient, baseUrl *url.URL, errPrefix string, ledgerVersion ...uint64) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		version := ""
+		if len(ledgerVersion) > 0 {
+			version = strconv.FormatUint(ledgerVersion[0], 10)
+		}
+		pages := paginate("", func(cursor string) ([]T, string, error) {
+			params := url.Values{}
+			if cursor != "" {
+				params.Set("start", cursor)
+			}
+			if version != "" {
+				params.Set("ledger_version", version)
+			}
+			requestUrl := *baseUrl
+			requestUrl.RawQuery = params.Encode()
+
+			page, header, err := getWithHeader[[]T](ctx, rc, requestUrl.String())
+			if err != nil {
+				return nil, "", fmt.Errorf("%s: %w", errPrefix, err)
+			}
+			if version == "" {
+				version = header.Get(LedgerVersionHeader)
+			}
+			return page, header.Get(CursorHeader), nil
+		})
+		for item, err := range pages {
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
+// collect gathers all items of seq into a slice, stopping at the first error
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := make([]T, 0)
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// getAllBCS fetches every page of a BCS sequence from an endpoint paginated by [CursorHeader], pinning the pages after
+// the first to the ledger version of the first page when no ledgerVersion is given
+func getAllBCS[T any](ctx context.Context, rc *NodeClient, baseUrl *url.URL, ledgerVersion ...uint64) ([]T, error) {
+	version := ""
+	if len(ledgerVersion) > 0 {
+		version = strconv.FormatUint(ledgerVersion[0], 10)
+	}
+	items := make([]T, 0)
+	cursor := ""
+	for {
+		params := url.Values{}
+		if cursor != "" {
+			params.Set("start", cursor)
+		}
+		if version != "" {
+			params.Set("ledger_version", version)
+		}
+		requestUrl := *baseUrl
+		requestUrl.RawQuery = params.Encode()
+
+		blob, header, err := rc.getBCSWithHeader(ctx, requestUrl.String())
+		if err != nil {
+			return nil, err
+		}
+		deserializer := bcs.NewDeserializer(blob)
+		// See resource_test.go TestMoveResourceBCS
+		page := bcs.DeserializeSequence[T](deserializer)
+		if err = deserializer.Error(); err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+
+		cursor = header.Get(CursorHeader)
+		if cursor == "" {
+			return items, nil
+		}
+		if version == "" {
+			version = header.Get(LedgerVersionHeader)
+		}
+	}
+}
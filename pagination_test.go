@@ -0,0 +1,236 @@
+package aptos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTransactionsServer serves state checkpoint transactions up to, but not including, version head.  Pages hold at
+// most pageCap transactions, whatever the limit, unless it's 0.
+func newTransactionsServer(t *testing.T, head uint64, pageCap uint64) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "/transactions", r.URL.Path)
+		start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		limit, _ := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+		if pageCap > 0 {
+			limit = min(limit, pageCap)
+		}
+		w.Header().Set(LedgerVersionHeader, strconv.FormatUint(head-1, 10))
+
+		txns := make([]map[string]any, 0, limit)
+		for version := start; version < min(start+limit, head); version++ {
+			txns = append(txns, map[string]any{
+				"type":    "state_checkpoint_transaction",
+				"version": strconv.FormatUint(version, 10),
+				"success": true,
+			})
+		}
+		err := json.NewEncoder(w).Encode(txns)
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	return server, calls
+}
+
+func TestNodeClient_TransactionsIter(t *testing.T) {
+	t.Parallel()
+	server, calls := newTransactionsServer(t, 250, 0)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	expected := uint64(10)
+	for txn, err := range client.TransactionsIter(10) {
+		require.NoError(t, err)
+		assert.Equal(t, expected, txn.Version())
+		expected++
+	}
+	assert.Equal(t, uint64(250), expected)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestNodeClient_TransactionsIter_CappedPages(t *testing.T) {
+	t.Parallel()
+	// The node returns fewer transactions than asked for, which isn't the end
+	server, calls := newTransactionsServer(t, 110, 25)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	expected := uint64(0)
+	for txn, err := range client.TransactionsIter(0) {
+		require.NoError(t, err)
+		assert.Equal(t, expected, txn.Version())
+		expected++
+	}
+	assert.Equal(t, uint64(110), expected)
+	// Ends at the ledger version, without asking for an empty page
+	assert.Equal(t, int32(5), calls.Load())
+}
+
+func TestNodeClient_TransactionsIter_Break(t *testing.T) {
+	t.Parallel()
+	server, calls := newTransactionsServer(t, 1000, 0)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	count := 0
+	for _, err := range client.TransactionsIter(0) {
+		require.NoError(t, err)
+		count++
+		if count == 150 {
+			break
+		}
+	}
+	assert.Equal(t, 150, count)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestNodeClient_TransactionsIter_Error(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	count := 0
+	for txn, err := range client.TransactionsIter(0) {
+		require.Error(t, err)
+		assert.Nil(t, txn)
+		count++
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestNodeClient_EventsByCreationNumberIter(t *testing.T) {
+	t.Parallel()
+	for _, pageCap := range []uint64{0, 25} {
+		testEventsByCreationNumberIter(t, pageCap)
+	}
+}
+
+// testEventsByCreationNumberIter iterates over 120 events, served in pages of at most pageCap events, unless it's 0
+func testEventsByCreationNumberIter(t *testing.T, pageCap uint64) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/0x1/events/2", r.URL.Path)
+		start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		limit, _ := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+		if pageCap > 0 {
+			limit = min(limit, pageCap)
+		}
+
+		events := make([]map[string]any, 0, limit)
+		for seqNum := start; seqNum < min(start+limit, 120); seqNum++ {
+			events = append(events, map[string]any{
+				"type":            "0x1::coin::DepositEvent",
+				"guid":            map[string]any{"creation_number": "2", "account_address": "0x1"},
+				"sequence_number": strconv.FormatUint(seqNum, 10),
+				"data":            map[string]any{},
+			})
+		}
+		err := json.NewEncoder(w).Encode(events)
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	expected := uint64(0)
+	for event, err := range client.EventsByCreationNumberIter(AccountOne, "2", 0) {
+		require.NoError(t, err)
+		assert.Equal(t, expected, event.SequenceNumber)
+		expected++
+	}
+	assert.Equal(t, uint64(120), expected, "page cap %d", pageCap)
+}
+
+func TestNodeClient_AccountResourcesFollowsCursor(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/0x1/resources", r.URL.Path)
+		w.Header().Set(LedgerVersionHeader, "77")
+		page := 0
+		if cursor := r.URL.Query().Get("start"); cursor != "" {
+			// Later pages are pinned to the version of the first
+			assert.Equal(t, "77", r.URL.Query().Get("ledger_version"))
+			page, _ = strconv.Atoi(cursor)
+		}
+		if page < 2 {
+			w.Header().Set(CursorHeader, strconv.Itoa(page+1))
+		}
+		_, _ = fmt.Fprintf(w, `[{"type":"0x1::test::Resource%d","data":{}}]`, page)
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	resources, err := client.AccountResources(AccountOne)
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+	for i, resource := range resources {
+		assert.Equal(t, fmt.Sprintf("0x1::test::Resource%d", i), resource.Type)
+	}
+}
+
+func TestNodeClient_AccountModules(t *testing.T) {
+	t.Parallel()
+	modules := []AccountModuleRecord{
+		{Id: ModuleId{Address: AccountOne, Name: "coin"}, Bytecode: []byte{0xa1, 0x1c}},
+		{Id: ModuleId{Address: AccountOne, Name: "account"}, Bytecode: []byte{0xeb, 0x0b}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/0x1/modules", r.URL.Path)
+		page, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		if page < len(modules)-1 {
+			w.Header().Set(CursorHeader, strconv.Itoa(page+1))
+		}
+		if r.Header.Get("Accept") == "application/x-bcs" {
+			ser := &bcs.Serializer{}
+			bcs.SerializeSequence(modules[page:page+1], ser)
+			_, _ = w.Write(ser.ToBytes())
+			return
+		}
+		_, _ = fmt.Fprintf(w, `[{"bytecode":"%s","abi":{"address":"0x1","name":"%s"}}]`, BytesToHex(modules[page].Bytecode), modules[page].Id.Name)
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	jsonModules, err := client.AccountModules(AccountOne)
+	require.NoError(t, err)
+	require.Len(t, jsonModules, 2)
+	for i, module := range jsonModules {
+		assert.Equal(t, modules[i].Id.Name, module.Abi.Name)
+		assert.Equal(t, modules[i].Bytecode, []byte(module.Bytecode))
+	}
+
+	bcsModules, err := client.AccountModulesBCS(AccountOne)
+	require.NoError(t, err)
+	assert.Equal(t, modules, bcsModules)
+}
@@ -0,0 +1,73 @@
+package aptos
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Response headers in which a node reports the state of its ledger at the time it served the request
+const (
+	ChainIdHeader             = "X-Aptos-Chain-Id"              // Chain ID of the network
+	LedgerVersionHeader       = "X-Aptos-Ledger-Version"        // Latest ledger version of the node
+	LedgerOldestVersionHeader = "X-Aptos-Ledger-Oldest-Version" // Oldest ledger version not pruned on the node
+	LedgerTimestampHeader     = "X-Aptos-Ledger-TimestampUsec"  // Timestamp of the latest ledger version in microseconds
+	EpochHeader               = "X-Aptos-Epoch"                 // Current epoch of the network
+	BlockHeightHeader         = "X-Aptos-Block-Height"          // Latest block height of the node
+	OldestBlockHeightHeader   = "X-Aptos-Oldest-Block-Height"   // Oldest block height not pruned on the node
+	GasUsedHeader             = "X-Aptos-Gas-Used"              // Gas used by a view function
+	CursorHeader              = "X-Aptos-Cursor"                // Cursor of the next page of resources or modules, absent on the last page
+)
+
+// ResponseInfo is the ledger metadata a node returns in the headers of every response.  It tells which ledger state a
+// result was read from, e.g. to prove the version a balance came from or to detect a lagging node.
+//
+// Fields whose header is absent from the response are left as 0 or "".
+type ResponseInfo struct {
+	ChainId             uint8  // ChainId of the network
+	LedgerVersion       uint64 // LedgerVersion is the latest ledger version of the node
+	OldestLedgerVersion uint64 // OldestLedgerVersion is the oldest ledger version not pruned on the node
+	LedgerTimestamp     uint64 // LedgerTimestamp is the timestamp of LedgerVersion in microseconds
+	Epoch               uint64 // Epoch is the current epoch of the network
+	BlockHeight         uint64 // BlockHeight is the latest block height of the node
+	OldestBlockHeight   uint64 // OldestBlockHeight is the oldest block height not pruned on the node
+	GasUsed             uint64 // GasUsed is the gas used by a view function, 0 for other requests
+	Cursor              string // Cursor is the start of the next page of a paginated response, "" on the last page
+}
+
+// ParseResponseInfo reads the [ResponseInfo] from the headers of a node response, including the Header of an
+// [HttpError].  Only the ...WithResponseInfo reads fail on a malformed header, the plain reads ignore the headers.
+func ParseResponseInfo(header http.Header) (*ResponseInfo, error) {
+	info := &ResponseInfo{Cursor: header.Get(CursorHeader)}
+	fields := []struct {
+		header string
+		out    *uint64
+	}{
+		{LedgerVersionHeader, &info.LedgerVersion},
+		{LedgerOldestVersionHeader, &info.OldestLedgerVersion},
+		{LedgerTimestampHeader, &info.LedgerTimestamp},
+		{EpochHeader, &info.Epoch},
+		{BlockHeightHeader, &info.BlockHeight},
+		{OldestBlockHeightHeader, &info.OldestBlockHeight},
+		{GasUsedHeader, &info.GasUsed},
+	}
+	for _, field := range fields {
+		value := header.Get(field.header)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad %s header '%s': %w", field.header, value, err)
+		}
+		*field.out = parsed
+	}
+	if value := header.Get(ChainIdHeader); value != "" {
+		chainId, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("bad %s header '%s': %w", ChainIdHeader, value, err)
+		}
+		info.ChainId = uint8(chainId)
+	}
+	return info, nil
+}
@@ -0,0 +1,123 @@
+package aptos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setLedgerHeaders sets the ledger metadata headers a node returns on every response
+func setLedgerHeaders(header http.Header) {
+	header.Set(ChainIdHeader, "4")
+	header.Set(LedgerVersionHeader, "1234")
+	header.Set(LedgerOldestVersionHeader, "10")
+	header.Set(LedgerTimestampHeader, "1700000000000000")
+	header.Set(EpochHeader, "7")
+	header.Set(BlockHeightHeader, "500")
+	header.Set(OldestBlockHeightHeader, "2")
+}
+
+func TestParseResponseInfo(t *testing.T) {
+	t.Parallel()
+	header := http.Header{}
+	setLedgerHeaders(header)
+	header.Set(GasUsedHeader, "3")
+	header.Set(CursorHeader, "0x1234")
+
+	info, err := ParseResponseInfo(header)
+	require.NoError(t, err)
+	assert.Equal(t, &ResponseInfo{
+		ChainId:             4,
+		LedgerVersion:       1234,
+		OldestLedgerVersion: 10,
+		LedgerTimestamp:     1700000000000000,
+		Epoch:               7,
+		BlockHeight:         500,
+		OldestBlockHeight:   2,
+		GasUsed:             3,
+		Cursor:              "0x1234",
+	}, info)
+
+	info, err = ParseResponseInfo(http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, &ResponseInfo{}, info)
+
+	header.Set(EpochHeader, "not a number")
+	_, err = ParseResponseInfo(header)
+	require.Error(t, err)
+}
+
+func TestNodeClient_WithResponseInfo(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setLedgerHeaders(w.Header())
+		switch r.URL.Path {
+		case "/accounts/0x1":
+			_, _ = w.Write([]byte(`{"sequence_number":"5","authentication_key":"0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+		case "/view":
+			w.Header().Set(GasUsedHeader, "9")
+			_, _ = w.Write([]byte(`["100"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	account, info, err := client.AccountWithResponseInfo(AccountOne)
+	require.NoError(t, err)
+	seqNum, err := account.SequenceNumber()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), seqNum)
+	assert.Equal(t, uint64(1234), info.LedgerVersion)
+	assert.Equal(t, uint64(7), info.Epoch)
+
+	balance, info, err := client.AccountAPTBalanceWithResponseInfo(AccountOne)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), balance)
+	assert.Equal(t, uint64(1234), info.LedgerVersion)
+	assert.Equal(t, uint64(9), info.GasUsed)
+}
+
+func TestNodeClient_BadResponseInfo(t *testing.T) {
+	t.Parallel()
+	// A proxy mangling a header only fails the reads that asked for the metadata
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setLedgerHeaders(w.Header())
+		w.Header().Set(LedgerVersionHeader, "garbled")
+		switch r.URL.Path {
+		case "/accounts/0x1":
+			_, _ = w.Write([]byte(`{"sequence_number":"5","authentication_key":"0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+		case "/accounts/0x1/resource/0x1::account::Account":
+			_, _ = w.Write([]byte(`{"type":"0x1::account::Account","data":{}}`))
+		case "/view":
+			_, _ = w.Write([]byte(`["100"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	_, err = client.Account(AccountOne)
+	require.NoError(t, err)
+	_, err = client.AccountResource(AccountOne, "0x1::account::Account")
+	require.NoError(t, err)
+	balance, err := client.AccountAPTBalance(AccountOne)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), balance)
+
+	_, _, err = client.AccountWithResponseInfo(AccountOne)
+	require.ErrorContains(t, err, LedgerVersionHeader)
+	_, _, err = client.AccountResourceWithResponseInfo(AccountOne, "0x1::account::Account")
+	require.ErrorContains(t, err, LedgerVersionHeader)
+	_, _, err = client.AccountAPTBalanceWithResponseInfo(AccountOne)
+	require.ErrorContains(t, err, LedgerVersionHeader)
+}
@@ -0,0 +1,164 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how a [NodeClient] retries failed requests.  Pass it as an option to [NewClient] or
+// [NewNodeClient], or set it later with [NodeClient.SetRetryPolicy].
+//
+// Retries are only applied to requests that are safe to repeat: reads, view functions, simulations, and the submission
+// of already signed transactions, where resubmitting the same signed bytes can't apply the transaction twice.  Faucet
+// requests and [Post] are never retried.
+//
+// A request is retried on transport errors, 429 Too Many Requests, and 5xx responses.  The delay grows exponentially
+// from InitialBackoff with random jitter, and a Retry-After header from the node takes precedence when present.  Every
+// delay is capped at MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    uint32        // Total attempts including the first, values below 2 disable retries
+	InitialBackoff time.Duration // Delay before the first retry
+	MaxBackoff     time.Duration // Cap on any single delay, 0 for no cap
+	Multiplier     float64       // Growth of the delay per attempt, defaults to 2 if not above 1
+	Jitter         float64       // Fraction of the delay to randomize by in [0, 1], e.g. 0.2 for +/-20%
+}
+
+// DefaultRetryPolicy is a reasonable [RetryPolicy] for public full nodes, 4 attempts starting at 200ms apart
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// RetryError is returned when a request still failed after being retried.  Err is the error of the last attempt,
+// and can be inspected with errors.As e.g. for an [HttpError].
+type RetryError struct {
+	Attempts uint32 // Number of attempts made, including the first
+	Err      error  // Error of the last attempt
+}
+
+// Error returns a string representation of the RetryError
+//
+// Implements:
+//   - [error]
+func (re *RetryError) Error() string {
+	return fmt.Sprintf("failed after %d attempts: %s", re.Attempts, re.Err.Error())
+}
+
+// Unwrap returns the error of the last attempt
+func (re *RetryError) Unwrap() error {
+	return re.Err
+}
+
+// SetRetryPolicy sets the [RetryPolicy] for all future requests, nil disables retries.  It's safe to call while
+// requests are in flight, and the policy is copied, so changing it afterward has no effect.
+//
+//	policy := DefaultRetryPolicy()
+//	client.SetRetryPolicy(&policy)
+func (rc *NodeClient) SetRetryPolicy(policy *RetryPolicy) {
+	if policy != nil {
+		policyCopy := *policy
+		policy = &policyCopy
+	}
+	rc.retryPolicy.Store(policy)
+}
+
+// withRetry runs request, retrying it according to the client's [RetryPolicy].  Callers must only use it for requests
+// that are safe to repeat.
+func (rc *NodeClient) withRetry(ctx context.Context, request func() (*rawResponse, error)) (*rawResponse, error) {
+	policy := rc.retryPolicy.Load()
+	if policy == nil || policy.MaxAttempts < 2 {
+		return request()
+	}
+
+	for attempt := uint32(1); ; attempt++ {
+		response, err := request()
+		if err == nil {
+			return response, nil
+		}
+		if attempt >= policy.MaxAttempts || !isRetryableError(ctx, err) {
+			if attempt == 1 {
+				return nil, err
+			}
+			return nil, &RetryError{Attempts: attempt, Err: err}
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &RetryError{Attempts: attempt, Err: fmt.Errorf("%w, last error: %w", ctx.Err(), err)}
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff determines how long to wait after the given failed attempt
+func (policy *RetryPolicy) backoff(attempt uint32, err error) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier <= 1 {
+		multiplier = 2
+	}
+	delay := float64(policy.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if policy.Jitter > 0 {
+		//nolint:gosec
+		delay += delay * min(policy.Jitter, 1) * (2*rand.Float64() - 1)
+	}
+
+	if retryAfter, ok := retryAfterDelay(err); ok {
+		delay = float64(retryAfter)
+	}
+	if policy.MaxBackoff > 0 {
+		delay = min(delay, float64(policy.MaxBackoff))
+	}
+	return time.Duration(delay)
+}
+
+// isRetryableError tells if a failed request may succeed when tried again
+func isRetryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var httpErr *HttpError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+
+	// Errors from the http.Client are transport errors, except failing to parse the URL in the first place
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Op != "parse"
+	}
+	return false
+}
+
+// retryAfterDelay parses the Retry-After header of an [HttpError], in either seconds or HTTP date form
+func retryAfterDelay(err error) (time.Duration, bool) {
+	var httpErr *HttpError
+	if !errors.As(err, &httpErr) || httpErr.Header == nil {
+		return 0, false
+	}
+	value := httpErr.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, parseErr := strconv.ParseUint(value, 10, 32); parseErr == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, parseErr := http.ParseTime(value); parseErr == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,159 @@
+package aptos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+}
+
+// newFlakyServer fails the first `failures` requests with the status code, and then responds with body
+func newFlakyServer(t *testing.T, failures int32, statusCode int, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte(`{"message":"try again","error_code":"internal_error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	return server, calls
+}
+
+func TestRetryPolicy_RetriesReads(t *testing.T) {
+	t.Parallel()
+	server, calls := newFlakyServer(t, 2, http.StatusServiceUnavailable, `{"gas_estimate":100}`)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4, testRetryPolicy())
+	require.NoError(t, err)
+
+	info, err := client.EstimateGasPrice()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), info.GasEstimate)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryPolicy_SurfacesAttempts(t *testing.T) {
+	t.Parallel()
+	server, calls := newFlakyServer(t, 10, http.StatusTooManyRequests, `{}`)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4, testRetryPolicy())
+	require.NoError(t, err)
+
+	_, err = client.EstimateGasPrice()
+	require.Error(t, err)
+
+	var retryErr *RetryError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, uint32(3), retryErr.Attempts)
+	var httpErr *HttpError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryPolicy_NoRetryOnClientError(t *testing.T) {
+	t.Parallel()
+	server, calls := newFlakyServer(t, 10, http.StatusNotFound, `{}`)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4, testRetryPolicy())
+	require.NoError(t, err)
+
+	_, err = client.Account(AccountOne)
+	require.Error(t, err)
+	var retryErr *RetryError
+	assert.NotErrorAs(t, err, &retryErr)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryPolicy_PostNotRetried(t *testing.T) {
+	t.Parallel()
+	server, calls := newFlakyServer(t, 10, http.StatusServiceUnavailable, `[]`)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4, testRetryPolicy())
+	require.NoError(t, err)
+	faucetClient, err := NewFaucetClient(client, server.URL)
+	require.NoError(t, err)
+
+	err = faucetClient.Fund(AccountOne, 100)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryPolicy_RetryAfter(t *testing.T) {
+	t.Parallel()
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Second}
+	err := &HttpError{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"1"}}}
+	assert.Equal(t, time.Second, policy.backoff(1, err))
+
+	// Retry-After is capped by MaxBackoff
+	policy.MaxBackoff = 500 * time.Millisecond
+	assert.Equal(t, 500*time.Millisecond, policy.backoff(1, err))
+
+	// Without Retry-After, the backoff grows exponentially
+	policy.MaxBackoff = 0
+	assert.Equal(t, 4*time.Millisecond, policy.backoff(3, &HttpError{StatusCode: http.StatusServiceUnavailable}))
+}
+
+func TestRetryPolicy_StopsOnCancel(t *testing.T) {
+	t.Parallel()
+	server, _ := newFlakyServer(t, 10, http.StatusServiceUnavailable, `{}`)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4, RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Minute})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.EstimateGasPriceCtx(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryPolicy_SetWhileRequesting(t *testing.T) {
+	t.Parallel()
+	server, _ := newFlakyServer(t, 0, http.StatusOK, `{"gas_estimate":100}`)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	// Run with -race, changing the policy mid-flight must not race with requests
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 10 {
+			_, err := client.EstimateGasPrice()
+			assert.NoError(t, err)
+		}
+	}()
+	policy := testRetryPolicy()
+	for range 10 {
+		client.SetRetryPolicy(&policy)
+		client.SetRetryPolicy(nil)
+	}
+	<-done
+
+	// The policy is copied
+	client.SetRetryPolicy(&policy)
+	policy.MaxAttempts = 1
+	assert.Equal(t, uint32(3), client.retryPolicy.Load().MaxAttempts)
+}
@@ -0,0 +1,467 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// SnapshotClient is a view of a [NodeClient] with every read pinned to a single ledger version, so that several calls
+// see one consistent state of the chain.  Create one with [NodeClient.AtVersion] or [Client.AtVersion].
+//
+// Account, resource, module and view function reads are made at the pinned version, and event and transaction
+// listings leave out anything committed after it.  An explicit ledgerVersion argument takes precedence over the pinned
+// version.  Building, simulating and submitting transactions are not pinned, as they always apply to the latest state.
+//
+// It implements [AptosRpcClient], so it can be used e.g. with [NewFungibleAssetClient] to pin all of its reads.
+//
+//	snapshot, err := client.AtVersion(info.LedgerVersion())
+//	balance, err := snapshot.AccountAPTBalance(address)
+//	account, err := snapshot.Account(address)
+type SnapshotClient struct {
+	*NodeClient
+	version uint64
+}
+
+// AtVersion returns a [SnapshotClient] with every read pinned to ledger version
+//
+// Returns an error matching [ErrVersionPruned] if the version has already been pruned from the node, or
+// [ErrVersionNotFound] if the node hasn't reached it yet.
+func (rc *NodeClient) AtVersion(version uint64) (*SnapshotClient, error) {
+	return rc.AtVersionCtx(context.Background(), version)
+}
+
+// AtVersionCtx is [NodeClient.AtVersion] with the check of the version bound to ctx
+func (rc *NodeClient) AtVersionCtx(ctx context.Context, version uint64) (*SnapshotClient, error) {
+	info, err := rc.InfoCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if version < info.OldestLedgerVersion() {
+		return nil, fmt.Errorf("ledger version %d is older than the oldest available version %d: %w", version, info.OldestLedgerVersion(), ErrVersionPruned)
+	}
+	if version > info.LedgerVersion() {
+		return nil, fmt.Errorf("ledger version %d is newer than the latest version %d: %w", version, info.LedgerVersion(), ErrVersionNotFound)
+	}
+	return &SnapshotClient{NodeClient: rc, version: version}, nil
+}
+
+// Version is the ledger version reads are pinned to
+func (sc *SnapshotClient) Version() uint64 {
+	return sc.version
+}
+
+// pin returns ledgerVersion if given, otherwise the pinned version
+func (sc *SnapshotClient) pin(ledgerVersion []uint64) []uint64 {
+	if len(ledgerVersion) > 0 {
+		return ledgerVersion
+	}
+	return []uint64{sc.version}
+}
+
+// wrapErr explains a pruned version error, as the node may prune the pinned version while the snapshot is in use
+func (sc *SnapshotClient) wrapErr(err error) error {
+	if err != nil && errors.Is(err, ErrVersionPruned) {
+		return fmt.Errorf("snapshot ledger version %d has been pruned: %w", sc.version, err)
+	}
+	return err
+}
+
+// Account gets information about an account at the pinned version
+func (sc *SnapshotClient) Account(address AccountAddress, ledgerVersion ...uint64) (AccountInfo, error) {
+	return sc.AccountCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountCtx is [SnapshotClient.Account] with its requests bound to ctx
+func (sc *SnapshotClient) AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (AccountInfo, error) {
+	info, err := sc.NodeClient.AccountCtx(ctx, address, sc.pin(ledgerVersion)...)
+	return info, sc.wrapErr(err)
+}
+
+// AccountWithResponseInfo is [SnapshotClient.Account], additionally returning the ledger metadata of the response
+func (sc *SnapshotClient) AccountWithResponseInfo(address AccountAddress, ledgerVersion ...uint64) (AccountInfo, *ResponseInfo, error) {
+	return sc.AccountWithResponseInfoCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountWithResponseInfoCtx is [SnapshotClient.AccountWithResponseInfo] with its requests bound to ctx
+func (sc *SnapshotClient) AccountWithResponseInfoCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (AccountInfo, *ResponseInfo, error) {
+	info, responseInfo, err := sc.NodeClient.AccountWithResponseInfoCtx(ctx, address, sc.pin(ledgerVersion)...)
+	return info, responseInfo, sc.wrapErr(err)
+}
+
+// AccountResource fetches a resource for an account at the pinned version
+func (sc *SnapshotClient) AccountResource(address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, error) {
+	return sc.AccountResourceCtx(context.Background(), address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceCtx is [SnapshotClient.AccountResource] with its requests bound to ctx
+func (sc *SnapshotClient) AccountResourceCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, error) {
+	data, err := sc.NodeClient.AccountResourceCtx(ctx, address, resourceType, sc.pin(ledgerVersion)...)
+	return data, sc.wrapErr(err)
+}
+
+// AccountResourceWithResponseInfo is [SnapshotClient.AccountResource], additionally returning the ledger metadata of
+// the response
+func (sc *SnapshotClient) AccountResourceWithResponseInfo(address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, *ResponseInfo, error) {
+	return sc.AccountResourceWithResponseInfoCtx(context.Background(), address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceWithResponseInfoCtx is [SnapshotClient.AccountResourceWithResponseInfo] with its requests bound to ctx
+func (sc *SnapshotClient) AccountResourceWithResponseInfoCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, *ResponseInfo, error) {
+	data, responseInfo, err := sc.NodeClient.AccountResourceWithResponseInfoCtx(ctx, address, resourceType, sc.pin(ledgerVersion)...)
+	return data, responseInfo, sc.wrapErr(err)
+}
+
+// AccountResources fetches all resources for an account at the pinned version
+func (sc *SnapshotClient) AccountResources(address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceInfo, error) {
+	return sc.AccountResourcesCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesCtx is [SnapshotClient.AccountResources] with its requests bound to ctx
+func (sc *SnapshotClient) AccountResourcesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceInfo, error) {
+	resources, err := sc.NodeClient.AccountResourcesCtx(ctx, address, sc.pin(ledgerVersion)...)
+	return resources, sc.wrapErr(err)
+}
+
+// AccountResourcesBCS fetches all account resources as raw Move struct BCS blobs at the pinned version
+func (sc *SnapshotClient) AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceRecord, error) {
+	return sc.AccountResourcesBCSCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesBCSCtx is [SnapshotClient.AccountResourcesBCS] with its requests bound to ctx
+func (sc *SnapshotClient) AccountResourcesBCSCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountResourceRecord, error) {
+	resources, err := sc.NodeClient.AccountResourcesBCSCtx(ctx, address, sc.pin(ledgerVersion)...)
+	return resources, sc.wrapErr(err)
+}
+
+// GetTableItem fetches an item of a 0x1::table::Table by its key at the pinned version
+func (sc *SnapshotClient) GetTableItem(handle string, keyType string, valueType string, key any, ledgerVersion ...uint64) (any, error) {
+	return sc.GetTableItemCtx(context.Background(), handle, keyType, valueType, key, ledgerVersion...)
+}
+
+// GetTableItemCtx is [SnapshotClient.GetTableItem] with its requests bound to ctx
+func (sc *SnapshotClient) GetTableItemCtx(ctx context.Context, handle string, keyType string, valueType string, key any, ledgerVersion ...uint64) (any, error) {
+	data, err := sc.NodeClient.GetTableItemCtx(ctx, handle, keyType, valueType, key, sc.pin(ledgerVersion)...)
+	return data, sc.wrapErr(err)
+}
+
+// GetRawTableItem fetches an item of a 0x1::table::Table by its BCS encoded key at the pinned version
+func (sc *SnapshotClient) GetRawTableItem(handle string, key []byte, ledgerVersion ...uint64) ([]byte, error) {
+	return sc.GetRawTableItemCtx(context.Background(), handle, key, ledgerVersion...)
+}
+
+// GetRawTableItemCtx is [SnapshotClient.GetRawTableItem] with its requests bound to ctx
+func (sc *SnapshotClient) GetRawTableItemCtx(ctx context.Context, handle string, key []byte, ledgerVersion ...uint64) ([]byte, error) {
+	data, err := sc.NodeClient.GetRawTableItemCtx(ctx, handle, key, sc.pin(ledgerVersion)...)
+	return data, sc.wrapErr(err)
+}
+
+// AccountResourcesIter iterates over the resources of an account at the pinned version
+func (sc *SnapshotClient) AccountResourcesIter(address AccountAddress, ledgerVersion ...uint64) iter.Seq2[AccountResourceInfo, error] {
+	return sc.AccountResourcesIterCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesIterCtx is [SnapshotClient.AccountResourcesIter] with its requests bound to ctx
+func (sc *SnapshotClient) AccountResourcesIterCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) iter.Seq2[AccountResourceInfo, error] {
+	return wrapIterErr(sc.NodeClient.AccountResourcesIterCtx(ctx, address, sc.pin(ledgerVersion)...), sc.wrapErr)
+}
+
+// AccountModule fetches a single account module's bytecode and ABI at the pinned version
+func (sc *SnapshotClient) AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (*api.MoveBytecode, error) {
+	return sc.AccountModuleCtx(context.Background(), address, moduleName, ledgerVersion...)
+}
+
+// AccountModuleCtx is [SnapshotClient.AccountModule] with its requests bound to ctx
+func (sc *SnapshotClient) AccountModuleCtx(ctx context.Context, address AccountAddress, moduleName string, ledgerVersion ...uint64) (*api.MoveBytecode, error) {
+	module, err := sc.NodeClient.AccountModuleCtx(ctx, address, moduleName, sc.pin(ledgerVersion)...)
+	return module, sc.wrapErr(err)
+}
+
+// AccountModules fetches all modules of an account with their ABIs at the pinned version
+func (sc *SnapshotClient) AccountModules(address AccountAddress, ledgerVersion ...uint64) ([]api.MoveBytecode, error) {
+	return sc.AccountModulesCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountModulesCtx is [SnapshotClient.AccountModules] with its requests bound to ctx
+func (sc *SnapshotClient) AccountModulesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]api.MoveBytecode, error) {
+	modules, err := sc.NodeClient.AccountModulesCtx(ctx, address, sc.pin(ledgerVersion)...)
+	return modules, sc.wrapErr(err)
+}
+
+// AccountModulesBCS fetches all modules of an account as raw bytecode at the pinned version
+func (sc *SnapshotClient) AccountModulesBCS(address AccountAddress, ledgerVersion ...uint64) ([]AccountModuleRecord, error) {
+	return sc.AccountModulesBCSCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountModulesBCSCtx is [SnapshotClient.AccountModulesBCS] with its requests bound to ctx
+func (sc *SnapshotClient) AccountModulesBCSCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) ([]AccountModuleRecord, error) {
+	modules, err := sc.NodeClient.AccountModulesBCSCtx(ctx, address, sc.pin(ledgerVersion)...)
+	return modules, sc.wrapErr(err)
+}
+
+// AccountModulesIter iterates over the modules of an account at the pinned version
+func (sc *SnapshotClient) AccountModulesIter(address AccountAddress, ledgerVersion ...uint64) iter.Seq2[*api.MoveBytecode, error] {
+	return sc.AccountModulesIterCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountModulesIterCtx is [SnapshotClient.AccountModulesIter] with its requests bound to ctx
+func (sc *SnapshotClient) AccountModulesIterCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) iter.Seq2[*api.MoveBytecode, error] {
+	return wrapIterErr(sc.NodeClient.AccountModulesIterCtx(ctx, address, sc.pin(ledgerVersion)...), sc.wrapErr)
+}
+
+// EntryFunctionWithArgs generates an EntryFunction from the Module ABI at the pinned version, and converts simple
+// inputs to BCS encoded ones.
+func (sc *SnapshotClient) EntryFunctionWithArgs(moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, options ...any) (*EntryFunction, error) {
+	return sc.EntryFunctionWithArgsCtx(context.Background(), moduleAddress, moduleName, functionName, typeArgs, args, options...)
+}
+
+// EntryFunctionWithArgsCtx is [SnapshotClient.EntryFunctionWithArgs] with its requests bound to ctx
+func (sc *SnapshotClient) EntryFunctionWithArgsCtx(ctx context.Context, moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, options ...any) (*EntryFunction, error) {
+	module, err := sc.AccountModuleCtx(ctx, moduleAddress, moduleName)
+	if err != nil {
+		return nil, err
+	}
+	return EntryFunctionFromAbi(module.Abi, moduleAddress, moduleName, functionName, typeArgs, args, options...)
+}
+
+// View calls a view function at the pinned version
+func (sc *SnapshotClient) View(payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	return sc.ViewCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewCtx is [SnapshotClient.View] with its requests bound to ctx
+func (sc *SnapshotClient) ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	data, err := sc.NodeClient.ViewCtx(ctx, payload, sc.pin(ledgerVersion)...)
+	return data, sc.wrapErr(err)
+}
+
+// ViewWithResponseInfo is [SnapshotClient.View], additionally returning the ledger metadata of the response
+func (sc *SnapshotClient) ViewWithResponseInfo(payload *ViewPayload, ledgerVersion ...uint64) ([]any, *ResponseInfo, error) {
+	return sc.ViewWithResponseInfoCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewWithResponseInfoCtx is [SnapshotClient.ViewWithResponseInfo] with its requests bound to ctx
+func (sc *SnapshotClient) ViewWithResponseInfoCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, *ResponseInfo, error) {
+	data, responseInfo, err := sc.NodeClient.ViewWithResponseInfoCtx(ctx, payload, sc.pin(ledgerVersion)...)
+	return data, responseInfo, sc.wrapErr(err)
+}
+
+// ViewRaw calls a view function at the pinned version, returning each of its return values BCS encoded
+func (sc *SnapshotClient) ViewRaw(payload *ViewPayload, ledgerVersion ...uint64) ([][]byte, error) {
+	return sc.ViewRawCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewRawCtx is [SnapshotClient.ViewRaw] with its requests bound to ctx
+func (sc *SnapshotClient) ViewRawCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([][]byte, error) {
+	values, err := sc.NodeClient.ViewRawCtx(ctx, payload, sc.pin(ledgerVersion)...)
+	return values, sc.wrapErr(err)
+}
+
+// ViewTyped calls a view function at the pinned version, and decodes its return values by the module's ABI
+func (sc *SnapshotClient) ViewTyped(payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	return sc.ViewTypedCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewTypedCtx is [SnapshotClient.ViewTyped] with its requests bound to ctx
+func (sc *SnapshotClient) ViewTypedCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	values, err := sc.NodeClient.ViewTypedCtx(ctx, payload, sc.pin(ledgerVersion)...)
+	return values, sc.wrapErr(err)
+}
+
+// ViewWithArgs calls a view function at the pinned version with simple inputs converted to BCS by the module's ABI
+func (sc *SnapshotClient) ViewWithArgs(moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, ledgerVersion ...uint64) ([]any, error) {
+	return sc.ViewWithArgsCtx(context.Background(), moduleAddress, moduleName, functionName, typeArgs, args, ledgerVersion...)
+}
+
+// ViewWithArgsCtx is [SnapshotClient.ViewWithArgs] with its requests bound to ctx
+func (sc *SnapshotClient) ViewWithArgsCtx(ctx context.Context, moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, ledgerVersion ...uint64) ([]any, error) {
+	values, err := sc.NodeClient.ViewWithArgsCtx(ctx, moduleAddress, moduleName, functionName, typeArgs, args, sc.pin(ledgerVersion)...)
+	return values, sc.wrapErr(err)
+}
+
+// ViewWithResponse runs a view function at the pinned version and unmarshals the JSON result into response
+func (sc *SnapshotClient) ViewWithResponse(response any, payload *ViewPayload, ledgerVersion ...uint64) error {
+	return sc.ViewWithResponseCtx(context.Background(), response, payload, ledgerVersion...)
+}
+
+// ViewWithResponseCtx is [SnapshotClient.ViewWithResponse] with its requests bound to ctx
+func (sc *SnapshotClient) ViewWithResponseCtx(ctx context.Context, response any, payload *ViewPayload, ledgerVersion ...uint64) error {
+	return sc.wrapErr(sc.NodeClient.ViewWithResponseCtx(ctx, response, payload, sc.pin(ledgerVersion)...))
+}
+
+// AccountAPTBalance fetches the APT balance of an account at the pinned version
+func (sc *SnapshotClient) AccountAPTBalance(account AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	return sc.AccountAPTBalanceCtx(context.Background(), account, ledgerVersion...)
+}
+
+// AccountAPTBalanceCtx is [SnapshotClient.AccountAPTBalance] with its requests bound to ctx
+func (sc *SnapshotClient) AccountAPTBalanceCtx(ctx context.Context, account AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	balance, err := sc.NodeClient.AccountAPTBalanceCtx(ctx, account, sc.pin(ledgerVersion)...)
+	return balance, sc.wrapErr(err)
+}
+
+// AccountAPTBalanceWithResponseInfo is [SnapshotClient.AccountAPTBalance], additionally returning the ledger metadata
+// of the response
+func (sc *SnapshotClient) AccountAPTBalanceWithResponseInfo(account AccountAddress, ledgerVersion ...uint64) (uint64, *ResponseInfo, error) {
+	return sc.AccountAPTBalanceWithResponseInfoCtx(context.Background(), account, ledgerVersion...)
+}
+
+// AccountAPTBalanceWithResponseInfoCtx is [SnapshotClient.AccountAPTBalanceWithResponseInfo] with its requests bound
+// to ctx
+func (sc *SnapshotClient) AccountAPTBalanceWithResponseInfoCtx(ctx context.Context, account AccountAddress, ledgerVersion ...uint64) (uint64, *ResponseInfo, error) {
+	balance, responseInfo, err := sc.NodeClient.AccountAPTBalanceWithResponseInfoCtx(ctx, account, sc.pin(ledgerVersion)...)
+	return balance, responseInfo, sc.wrapErr(err)
+}
+
+// Transactions gets transactions like [NodeClient.Transactions], leaving out those after the pinned version
+func (sc *SnapshotClient) Transactions(start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	return sc.TransactionsCtx(context.Background(), start, limit)
+}
+
+// TransactionsCtx is [SnapshotClient.Transactions] with its requests bound to ctx
+func (sc *SnapshotClient) TransactionsCtx(ctx context.Context, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	txns, err := sc.NodeClient.TransactionsCtx(ctx, start, limit)
+	if err != nil {
+		return nil, err
+	}
+	return sc.filterTransactions(txns), nil
+}
+
+// AccountTransactions gets transactions like [NodeClient.AccountTransactions], leaving out those after the pinned
+// version
+func (sc *SnapshotClient) AccountTransactions(account AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	return sc.AccountTransactionsCtx(context.Background(), account, start, limit)
+}
+
+// AccountTransactionsCtx is [SnapshotClient.AccountTransactions] with its requests bound to ctx
+func (sc *SnapshotClient) AccountTransactionsCtx(ctx context.Context, account AccountAddress, start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	txns, err := sc.NodeClient.AccountTransactionsCtx(ctx, account, start, limit)
+	if err != nil {
+		return nil, err
+	}
+	return sc.filterTransactions(txns), nil
+}
+
+// TransactionsIter iterates over committed transactions from version start up to the pinned version
+func (sc *SnapshotClient) TransactionsIter(start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return sc.TransactionsIterCtx(context.Background(), start)
+}
+
+// TransactionsIterCtx is [SnapshotClient.TransactionsIter] with its requests bound to ctx
+func (sc *SnapshotClient) TransactionsIterCtx(ctx context.Context, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return untilVersion(sc.NodeClient.TransactionsIterCtx(ctx, start), sc.version, (*api.CommittedTransaction).Version)
+}
+
+// AccountTransactionsIter iterates over the transactions sent by an account up to the pinned version
+func (sc *SnapshotClient) AccountTransactionsIter(account AccountAddress, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return sc.AccountTransactionsIterCtx(context.Background(), account, start)
+}
+
+// AccountTransactionsIterCtx is [SnapshotClient.AccountTransactionsIter] with its requests bound to ctx
+func (sc *SnapshotClient) AccountTransactionsIterCtx(ctx context.Context, account AccountAddress, start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return untilVersion(sc.NodeClient.AccountTransactionsIterCtx(ctx, account, start), sc.version, (*api.CommittedTransaction).Version)
+}
+
+// EventsByHandle retrieves events like [NodeClient.EventsByHandle], leaving out those emitted after the pinned version
+func (sc *SnapshotClient) EventsByHandle(account AccountAddress, eventHandle string, fieldName string, start *uint64, limit *uint64) ([]*api.Event, error) {
+	return sc.EventsByHandleCtx(context.Background(), account, eventHandle, fieldName, start, limit)
+}
+
+// EventsByHandleCtx is [SnapshotClient.EventsByHandle] with its requests bound to ctx
+func (sc *SnapshotClient) EventsByHandleCtx(ctx context.Context, account AccountAddress, eventHandle string, fieldName string, start *uint64, limit *uint64) ([]*api.Event, error) {
+	events, err := sc.NodeClient.EventsByHandleCtx(ctx, account, eventHandle, fieldName, start, limit)
+	if err != nil {
+		return nil, err
+	}
+	return sc.filterEvents(events), nil
+}
+
+// EventsByCreationNumber retrieves events like [NodeClient.EventsByCreationNumber], leaving out those emitted after the
+// pinned version
+func (sc *SnapshotClient) EventsByCreationNumber(account AccountAddress, creationNumber string, start *uint64, limit *uint64) ([]*api.Event, error) {
+	return sc.EventsByCreationNumberCtx(context.Background(), account, creationNumber, start, limit)
+}
+
+// EventsByCreationNumberCtx is [SnapshotClient.EventsByCreationNumber] with its requests bound to ctx
+func (sc *SnapshotClient) EventsByCreationNumberCtx(ctx context.Context, account AccountAddress, creationNumber string, start *uint64, limit *uint64) ([]*api.Event, error) {
+	events, err := sc.NodeClient.EventsByCreationNumberCtx(ctx, account, creationNumber, start, limit)
+	if err != nil {
+		return nil, err
+	}
+	return sc.filterEvents(events), nil
+}
+
+// EventsByHandleIter iterates over the events of an event handle emitted up to the pinned version
+func (sc *SnapshotClient) EventsByHandleIter(account AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error] {
+	return sc.EventsByHandleIterCtx(context.Background(), account, eventHandle, fieldName, start)
+}
+
+// EventsByHandleIterCtx is [SnapshotClient.EventsByHandleIter] with its requests bound to ctx
+func (sc *SnapshotClient) EventsByHandleIterCtx(ctx context.Context, account AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error] {
+	return untilVersion(sc.NodeClient.EventsByHandleIterCtx(ctx, account, eventHandle, fieldName, start), sc.version, eventVersion)
+}
+
+// EventsByCreationNumberIter iterates over the events of an event stream emitted up to the pinned version
+func (sc *SnapshotClient) EventsByCreationNumberIter(account AccountAddress, creationNumber string, start uint64) iter.Seq2[*api.Event, error] {
+	return sc.EventsByCreationNumberIterCtx(context.Background(), account, creationNumber, start)
+}
+
+// EventsByCreationNumberIterCtx is [SnapshotClient.EventsByCreationNumberIter] with its requests bound to ctx
+func (sc *SnapshotClient) EventsByCreationNumberIterCtx(ctx context.Context, account AccountAddress, creationNumber string, start uint64) iter.Seq2[*api.Event, error] {
+	return untilVersion(sc.NodeClient.EventsByCreationNumberIterCtx(ctx, account, creationNumber, start), sc.version, eventVersion)
+}
+
+// filterTransactions leaves out transactions committed after the pinned version
+func (sc *SnapshotClient) filterTransactions(txns []*api.CommittedTransaction) []*api.CommittedTransaction {
+	filtered := make([]*api.CommittedTransaction, 0, len(txns))
+	for _, txn := range txns {
+		if txn.Version() <= sc.version {
+			filtered = append(filtered, txn)
+		}
+	}
+	return filtered
+}
+
+// filterEvents leaves out events emitted after the pinned version
+func (sc *SnapshotClient) filterEvents(events []*api.Event) []*api.Event {
+	filtered := make([]*api.Event, 0, len(events))
+	for _, event := range events {
+		if event.Version <= sc.version {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// eventVersion is the version of the transaction that emitted an event
+func eventVersion(event *api.Event) uint64 {
+	return event.Version
+}
+
+// untilVersion stops an ascending iteration at the first item after version
+func untilVersion[T any](seq iter.Seq2[T, error], version uint64, itemVersion func(T) uint64) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range seq {
+			if err == nil && itemVersion(item) > version {
+				return
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
+// wrapIterErr passes any error of an iteration through wrap
+func wrapIterErr[T any](seq iter.Seq2[T, error], wrap func(error) error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range seq {
+			if !yield(item, wrap(err)) {
+				return
+			}
+		}
+	}
+}
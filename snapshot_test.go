@@ -0,0 +1,77 @@
+package aptos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSnapshotServer reports a ledger between versions 100 and 200, and records the ledger_version of every request
+func newSnapshotServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mutex sync.Mutex
+	var versions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"chain_id":4,"ledger_version":"200","oldest_ledger_version":"100"}`))
+			return
+		case "/view":
+			_, _ = w.Write([]byte(`["42"]`))
+		default:
+			_, _ = w.Write([]byte(`{"sequence_number":"1","authentication_key":"0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		versions = append(versions, r.URL.Query().Get("ledger_version"))
+	}))
+	return server, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return versions
+	}
+}
+
+func TestSnapshotClient_PinsReads(t *testing.T) {
+	t.Parallel()
+	server, versions := newSnapshotServer(t)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	var snapshot AptosRpcClient
+	snapshot, err = client.AtVersion(150)
+	require.NoError(t, err)
+
+	_, err = snapshot.Account(AccountOne)
+	require.NoError(t, err)
+	balance, err := snapshot.AccountAPTBalance(AccountOne)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), balance)
+
+	// An explicit version takes precedence
+	_, err = snapshot.Account(AccountOne, 120)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"150", "150", "120"}, versions())
+}
+
+func TestSnapshotClient_VersionOutOfRange(t *testing.T) {
+	t.Parallel()
+	server, _ := newSnapshotServer(t)
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	_, err = client.AtVersion(99)
+	require.ErrorIs(t, err, ErrVersionPruned)
+
+	_, err = client.AtVersion(201)
+	require.ErrorIs(t, err, ErrVersionNotFound)
+}
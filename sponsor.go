@@ -0,0 +1,439 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+)
+
+var (
+	// ErrSponsorshipDenied is returned by [SponsorService] for transactions its [SponsorPolicy] doesn't allow
+	ErrSponsorshipDenied = errors.New("sponsorship denied")
+	// ErrSponsorshipRateLimited is returned by [SponsorService] for senders over the rate limit of its [SponsorPolicy]
+	ErrSponsorshipRateLimited = errors.New("sponsorship rate limited")
+)
+
+// MaxSponsorshipRequestSize is the largest body accepted by [SponsorService.ServeHTTP]
+const MaxSponsorshipRequestSize = 64 * 1024
+
+// DefaultSponsorRateLimitWindow is the [SponsorPolicy.RateLimitWindow] used when it's left 0
+const DefaultSponsorRateLimitWindow = time.Minute
+
+// SponsorPolicy is what a [SponsorService] will pay for.  Limits left 0 aren't enforced.
+type SponsorPolicy struct {
+	// AllowedFunctions are the entry functions that may be called, either a whole module e.g. "0x1::aptos_account" or a
+	// single function e.g. "0x1::aptos_account::transfer".  Nothing is sponsored without any.  Scripts and multisig
+	// payloads are never sponsored.
+	AllowedFunctions []string
+
+	MaxGasAmount    uint64        // MaxGasAmount is the largest max gas amount of a transaction
+	MaxGasUnitPrice uint64        // MaxGasUnitPrice is the highest gas unit price of a transaction
+	MaxExpiration   time.Duration // MaxExpiration is how far in the future a transaction may expire
+
+	// MaxTransactionsPerSender is how many transactions of a single sender are sponsored per RateLimitWindow
+	MaxTransactionsPerSender int
+	// RateLimitWindow is the sliding window of MaxTransactionsPerSender, [DefaultSponsorRateLimitWindow] if left 0
+	RateLimitWindow time.Duration
+}
+
+// SponsorshipRequest is a fee payer transaction already signed by its sender and secondary signers, to be signed by the
+// fee payer of a [SponsorService].  The sender may have signed with the fee payer left as [AccountZero].
+type SponsorshipRequest struct {
+	Transaction      *RawTransactionWithData       // Transaction must be a [MultiAgentWithFeePayerRawTransactionWithData]
+	Sender           *crypto.AccountAuthenticator  // Sender is the authenticator of the sender
+	SecondarySigners []crypto.AccountAuthenticator // SecondarySigners are the authenticators of the secondary signers, in order
+	Submit           bool                          // Submit the transaction, rather than returning the fee payer authenticator
+}
+
+// region SponsorshipRequest bcs.Struct
+
+func (req *SponsorshipRequest) MarshalBCS(ser *bcs.Serializer) {
+	ser.Struct(req.Transaction)
+	ser.Struct(req.Sender)
+	bcs.SerializeSequence(req.SecondarySigners, ser)
+	ser.Bool(req.Submit)
+}
+
+func (req *SponsorshipRequest) UnmarshalBCS(des *bcs.Deserializer) {
+	req.Transaction = &RawTransactionWithData{}
+	des.Struct(req.Transaction)
+	req.Sender = &crypto.AccountAuthenticator{}
+	des.Struct(req.Sender)
+	req.SecondarySigners = bcs.DeserializeSequence[crypto.AccountAuthenticator](des)
+	req.Submit = des.Bool()
+}
+
+// endregion
+
+// SponsorshipResponse is the fee payer authenticator of a sponsored transaction, and its hash if it was submitted.
+// Use [RawTransactionWithData.SetFeePayer] with FeePayer, then [RawTransactionWithData.ToFeePayerSignedTransaction]
+// to submit it yourself.
+type SponsorshipResponse struct {
+	FeePayer              AccountAddress
+	FeePayerAuthenticator *crypto.AccountAuthenticator
+	Hash                  string // Hash of the submitted transaction, empty if it wasn't submitted
+}
+
+type sponsorshipResponseJson struct {
+	FeePayer              AccountAddress `json:"fee_payer"`
+	FeePayerAuthenticator string         `json:"fee_payer_authenticator"` // BCS of the authenticator, in hex
+	Hash                  string         `json:"hash,omitempty"`
+}
+
+// MarshalJSON encodes the fee payer authenticator as hex of its BCS
+//
+// Implements:
+//   - [json.Marshaler]
+func (resp *SponsorshipResponse) MarshalJSON() ([]byte, error) {
+	authBytes, err := bcs.Serialize(resp.FeePayerAuthenticator)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&sponsorshipResponseJson{
+		FeePayer:              resp.FeePayer,
+		FeePayerAuthenticator: util.BytesToHex(authBytes),
+		Hash:                  resp.Hash,
+	})
+}
+
+// UnmarshalJSON decodes a response of [SponsorService.ServeHTTP]
+//
+// Implements:
+//   - [json.Unmarshaler]
+func (resp *SponsorshipResponse) UnmarshalJSON(b []byte) error {
+	data := &sponsorshipResponseJson{}
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return err
+	}
+	authBytes, err := util.ParseHex(data.FeePayerAuthenticator)
+	if err != nil {
+		return err
+	}
+	auth := &crypto.AccountAuthenticator{}
+	err = bcs.Deserialize(auth, authBytes)
+	if err != nil {
+		return err
+	}
+	resp.FeePayer = data.FeePayer
+	resp.FeePayerAuthenticator = auth
+	resp.Hash = data.Hash
+	return nil
+}
+
+// SponsorService signs fee payer transactions as their fee payer, paying for their gas, if they pass a [SponsorPolicy].
+// It's an [http.Handler] taking a BCS [SponsorshipRequest] and responding with a JSON [SponsorshipResponse], or it can
+// be called directly with [SponsorService.Sponsor].
+//
+//	service, err := NewSponsorService(feePayer, TestnetConfig.ChainId, SponsorPolicy{
+//		AllowedFunctions: []string{"0xcafe::game"},
+//		MaxGasAmount:     10_000,
+//		MaxGasUnitPrice:  200,
+//		MaxExpiration:    time.Minute,
+//	}, client)
+//	...
+//	http.Handle("/sponsor", service)
+type SponsorService struct {
+	feePayer  TransactionSigner
+	chainId   uint8
+	policy    SponsorPolicy
+	client    AptosRpcClient // Submits sponsored transactions, nil if submission is disabled
+	functions map[string]bool
+
+	mutex     sync.Mutex
+	sponsored map[AccountAddress][]time.Time // Times each sender was sponsored within the rate limit window
+	lastSweep time.Time
+}
+
+// NewSponsorService creates a [SponsorService] paying for transactions on chainId with feePayer
+//
+// Accepts options:
+//   - [AptosRpcClient] to submit transactions for requests with [SponsorshipRequest.Submit]
+func NewSponsorService(feePayer TransactionSigner, chainId uint8, policy SponsorPolicy, options ...any) (*SponsorService, error) {
+	service := &SponsorService{
+		feePayer:  feePayer,
+		chainId:   chainId,
+		policy:    policy,
+		functions: make(map[string]bool, len(policy.AllowedFunctions)),
+		sponsored: map[AccountAddress][]time.Time{},
+	}
+	if service.policy.RateLimitWindow == 0 {
+		service.policy.RateLimitWindow = DefaultSponsorRateLimitWindow
+	}
+	for i, option := range options {
+		switch value := option.(type) {
+		case AptosRpcClient:
+			service.client = value
+		default:
+			return nil, fmt.Errorf("NewSponsorService arg %d bad type %T", i+4, option)
+		}
+	}
+
+	for _, function := range policy.AllowedFunctions {
+		parts := strings.Split(function, "::")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("bad allowed function %s, expected address::module or address::module::function", function)
+		}
+		address := AccountAddress{}
+		err := address.ParseStringRelaxed(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("bad allowed function %s: %w", function, err)
+		}
+		parts[0] = address.String()
+		service.functions[strings.Join(parts, "::")] = true
+	}
+	return service, nil
+}
+
+// FeePayer is the address paying for sponsored transactions
+func (service *SponsorService) FeePayer() AccountAddress {
+	return service.feePayer.AccountAddress()
+}
+
+// Sponsor checks the request against the policy, and signs it as the fee payer, submitting it if asked
+func (service *SponsorService) Sponsor(request *SponsorshipRequest) (*SponsorshipResponse, error) {
+	return service.SponsorCtx(context.Background(), request)
+}
+
+// SponsorCtx is Sponsor with its submission bound to ctx
+//
+// Errors wrap [ErrInvalidInput] for malformed requests and bad signatures, [ErrSponsorshipDenied] for transactions
+// outside the policy, [ErrSponsorshipRateLimited] for senders over the rate limit, or are errors of the submission.
+func (service *SponsorService) SponsorCtx(ctx context.Context, request *SponsorshipRequest) (*SponsorshipResponse, error) {
+	if request.Transaction == nil || request.Sender == nil {
+		return nil, fmt.Errorf("%w: missing transaction or sender authenticator", ErrInvalidInput)
+	}
+	inner, ok := request.Transaction.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	if !ok || inner.RawTxn == nil || inner.FeePayer == nil {
+		return nil, fmt.Errorf("%w: not a fee payer transaction", ErrInvalidInput)
+	}
+	if request.Submit && service.client == nil {
+		return nil, fmt.Errorf("%w: submission is not enabled", ErrSponsorshipDenied)
+	}
+
+	feePayer := service.feePayer.AccountAddress()
+	if *inner.FeePayer != feePayer && *inner.FeePayer != AccountZero {
+		return nil, fmt.Errorf("%w: fee payer %s is not %s", ErrSponsorshipDenied, inner.FeePayer.String(), feePayer.String())
+	}
+	err := service.verifySignatures(request, inner)
+	if err != nil {
+		return nil, err
+	}
+	err = service.checkPolicy(inner, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	err = service.reserve(inner.RawTxn.Sender, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign a copy, to leave the request as it was
+	sponsored := &RawTransactionWithData{
+		Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+		Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+			RawTxn:           inner.RawTxn,
+			SecondarySigners: inner.SecondarySigners,
+			FeePayer:         &feePayer,
+		},
+	}
+	feePayerAuth, err := sponsored.Sign(service.feePayer)
+	if err != nil {
+		return nil, err
+	}
+	response := &SponsorshipResponse{FeePayer: feePayer, FeePayerAuthenticator: feePayerAuth}
+	if !request.Submit {
+		return response, nil
+	}
+
+	signedTxn, _ := sponsored.ToFeePayerSignedTransaction(request.Sender, feePayerAuth, request.SecondarySigners)
+	submitted, err := service.client.SubmitTransactionCtx(ctx, signedTxn)
+	if err != nil {
+		return nil, err
+	}
+	response.Hash = submitted.Hash
+	return response, nil
+}
+
+// verifySignatures checks the sender and secondary signers signed the transaction, with the fee payer as it is in the
+// request.  Authentication keys are left to the node.
+func (service *SponsorService) verifySignatures(request *SponsorshipRequest, inner *MultiAgentWithFeePayerRawTransactionWithData) error {
+	if len(request.SecondarySigners) != len(inner.SecondarySigners) {
+		return fmt.Errorf("%w: %d secondary signers but %d authenticators", ErrInvalidInput, len(inner.SecondarySigners), len(request.SecondarySigners))
+	}
+	message, err := request.Transaction.SigningMessage()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	if !request.Sender.Verify(message) {
+		return fmt.Errorf("%w: invalid sender signature", ErrInvalidInput)
+	}
+	for i := range request.SecondarySigners {
+		if !request.SecondarySigners[i].Verify(message) {
+			return fmt.Errorf("%w: invalid signature of secondary signer %d", ErrInvalidInput, i)
+		}
+	}
+	return nil
+}
+
+// checkPolicy checks the transaction against the policy at now
+func (service *SponsorService) checkPolicy(inner *MultiAgentWithFeePayerRawTransactionWithData, now time.Time) error {
+	rawTxn := inner.RawTxn
+	feePayer := service.feePayer.AccountAddress()
+
+	// The fee payer signs the same message as the signers, so it must not be one of them, or its signature could be
+	// reused to act as them
+	if rawTxn.Sender == feePayer {
+		return fmt.Errorf("%w: fee payer can't be the sender", ErrSponsorshipDenied)
+	}
+	for _, signer := range inner.SecondarySigners {
+		if signer == feePayer {
+			return fmt.Errorf("%w: fee payer can't be a secondary signer", ErrSponsorshipDenied)
+		}
+	}
+
+	if rawTxn.ChainId != service.chainId {
+		return fmt.Errorf("%w: chain id %d is not %d", ErrSponsorshipDenied, rawTxn.ChainId, service.chainId)
+	}
+	if service.policy.MaxGasAmount != 0 && rawTxn.MaxGasAmount > service.policy.MaxGasAmount {
+		return fmt.Errorf("%w: max gas amount %d over %d", ErrSponsorshipDenied, rawTxn.MaxGasAmount, service.policy.MaxGasAmount)
+	}
+	if service.policy.MaxGasUnitPrice != 0 && rawTxn.GasUnitPrice > service.policy.MaxGasUnitPrice {
+		return fmt.Errorf("%w: gas unit price %d over %d", ErrSponsorshipDenied, rawTxn.GasUnitPrice, service.policy.MaxGasUnitPrice)
+	}
+	nowSeconds := uint64(now.Unix()) //nolint:gosec // Unix time is positive
+	if rawTxn.ExpirationTimestampSeconds <= nowSeconds {
+		return fmt.Errorf("%w: expired at %d", ErrSponsorshipDenied, rawTxn.ExpirationTimestampSeconds)
+	}
+	maxExpiration := uint64(service.policy.MaxExpiration / time.Second)
+	if maxExpiration != 0 && rawTxn.ExpirationTimestampSeconds > nowSeconds+maxExpiration {
+		return fmt.Errorf("%w: expiration %d more than %s away", ErrSponsorshipDenied, rawTxn.ExpirationTimestampSeconds, service.policy.MaxExpiration)
+	}
+
+	entryFunction := sponsoredEntryFunction(&rawTxn.Payload)
+	if entryFunction == nil {
+		return fmt.Errorf("%w: only entry functions are sponsored", ErrSponsorshipDenied)
+	}
+	module := entryFunction.Module.Address.String() + "::" + entryFunction.Module.Name
+	if !service.functions[module] && !service.functions[module+"::"+entryFunction.Function] {
+		return fmt.Errorf("%w: %s::%s is not allowed", ErrSponsorshipDenied, module, entryFunction.Function)
+	}
+	return nil
+}
+
+// sponsoredEntryFunction is the entry function called by payload, or nil if it doesn't call one.  An inner payload
+// with a multisig address calls its entry function as the multisig account, so it's nil too.
+func sponsoredEntryFunction(payload *TransactionPayload) *EntryFunction {
+	switch inner := payload.Payload.(type) {
+	case *EntryFunction:
+		return inner
+	case *TransactionInnerPayload:
+		if v1, ok := inner.Payload.(*TransactionInnerPayloadV1); ok {
+			if config, ok := v1.ExtraConfig.Inner.(*TransactionExtraConfigV1); ok && config.MultisigAddress != nil {
+				return nil
+			}
+			entryFunction, _ := v1.Executable.Inner.(*EntryFunction)
+			return entryFunction
+		}
+	}
+	return nil
+}
+
+// reserve counts a sponsorship of sender at now, unless it's over the rate limit
+func (service *SponsorService) reserve(sender AccountAddress, now time.Time) error {
+	if service.policy.MaxTransactionsPerSender == 0 {
+		return nil
+	}
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	since := now.Add(-service.policy.RateLimitWindow)
+
+	// Forget senders that haven't been sponsored within the window, once per window
+	if now.Sub(service.lastSweep) > service.policy.RateLimitWindow {
+		for address, times := range service.sponsored {
+			if !times[len(times)-1].After(since) {
+				delete(service.sponsored, address)
+			}
+		}
+		service.lastSweep = now
+	}
+
+	times := service.sponsored[sender]
+	for len(times) > 0 && !times[0].After(since) {
+		times = times[1:]
+	}
+	if len(times) >= service.policy.MaxTransactionsPerSender {
+		service.sponsored[sender] = times
+		return fmt.Errorf("%w: %d transactions of %s in the last %s", ErrSponsorshipRateLimited, len(times), sender.String(), service.policy.RateLimitWindow)
+	}
+	service.sponsored[sender] = append(times, now)
+	return nil
+}
+
+// ServeHTTP sponsors a POSTed BCS [SponsorshipRequest], responding with a JSON [SponsorshipResponse], 202 Accepted if
+// it was submitted.  Errors are JSON [api.Error]s, 400 for invalid requests, 403 for denied ones, and 429 for rate
+// limited senders.  Node errors on submission are passed through.
+//
+// Implements:
+//   - [http.Handler]
+func (service *SponsorService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeSponsorError(w, http.StatusMethodNotAllowed, &api.Error{Message: "only POST is supported", ErrorCode: api.ErrorCodeWebFrameworkError})
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxSponsorshipRequestSize))
+	if err != nil {
+		writeSponsorError(w, http.StatusBadRequest, &api.Error{Message: err.Error(), ErrorCode: api.ErrorCodeInvalidInput})
+		return
+	}
+	request := &SponsorshipRequest{}
+	err = bcs.Deserialize(request, body)
+	if err != nil {
+		writeSponsorError(w, http.StatusBadRequest, &api.Error{Message: err.Error(), ErrorCode: api.ErrorCodeInvalidInput})
+		return
+	}
+
+	response, err := service.SponsorCtx(r.Context(), request)
+	var httpErr *HttpError
+	switch {
+	case err == nil:
+		status := http.StatusOK
+		if response.Hash != "" {
+			status = http.StatusAccepted
+		}
+		w.Header().Set("Content-Type", ContentTypeJson)
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(response)
+	case errors.Is(err, ErrInvalidInput):
+		writeSponsorError(w, http.StatusBadRequest, &api.Error{Message: err.Error(), ErrorCode: api.ErrorCodeInvalidInput})
+	case errors.Is(err, ErrSponsorshipDenied):
+		writeSponsorError(w, http.StatusForbidden, &api.Error{Message: err.Error(), ErrorCode: api.ErrorCodeRejectedByFilter})
+	case errors.Is(err, ErrSponsorshipRateLimited):
+		w.Header().Set("Retry-After", strconv.Itoa(int(service.policy.RateLimitWindow.Seconds())))
+		writeSponsorError(w, http.StatusTooManyRequests, &api.Error{Message: err.Error(), ErrorCode: api.ErrorCodeRejectedByFilter})
+	case errors.As(err, &httpErr) && httpErr.ApiError != nil:
+		writeSponsorError(w, httpErr.StatusCode, httpErr.ApiError)
+	default:
+		writeSponsorError(w, http.StatusInternalServerError, &api.Error{Message: err.Error(), ErrorCode: api.ErrorCodeInternalError})
+	}
+}
+
+// writeSponsorError writes apiErr as the JSON body of a response with status
+func writeSponsorError(w http.ResponseWriter, status int, apiErr *api.Error) {
+	w.Header().Set("Content-Type", ContentTypeJson)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(apiErr)
+}
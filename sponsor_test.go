@@ -0,0 +1,300 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sponsorTestPolicy = SponsorPolicy{
+	AllowedFunctions: []string{"0x1::aptos_account::transfer"},
+	MaxGasAmount:     10_000,
+	MaxGasUnitPrice:  200,
+	MaxExpiration:    time.Minute,
+}
+
+// newSponsorshipRequest is a transfer by sender, sponsored by feePayer, edited by modify before the sender signs it
+func newSponsorshipRequest(t *testing.T, sender *Account, feePayer AccountAddress, modify func(*RawTransaction)) *SponsorshipRequest {
+	t.Helper()
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	require.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     sender.Address,
+		Payload:                    TransactionPayload{Payload: payload},
+		MaxGasAmount:               1_000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: uint64(time.Now().Add(30 * time.Second).Unix()),
+		ChainId:                    4,
+	}
+	if modify != nil {
+		modify(rawTxn)
+	}
+	txn := &RawTransactionWithData{
+		Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+		Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+			RawTxn:           rawTxn,
+			SecondarySigners: []AccountAddress{},
+			FeePayer:         &feePayer,
+		},
+	}
+	senderAuth, err := txn.Sign(sender)
+	require.NoError(t, err)
+	return &SponsorshipRequest{Transaction: txn, Sender: senderAuth, SecondarySigners: []crypto.AccountAuthenticator{}}
+}
+
+// sponsoredTransaction is the request signed by the fee payer of response
+func sponsoredTransaction(t *testing.T, request *SponsorshipRequest, response *SponsorshipResponse) *SignedTransaction {
+	t.Helper()
+	require.True(t, request.Transaction.SetFeePayer(response.FeePayer))
+	signedTxn, ok := request.Transaction.ToFeePayerSignedTransaction(request.Sender, response.FeePayerAuthenticator, request.SecondarySigners)
+	require.True(t, ok)
+	return signedTxn
+}
+
+// verifyFeePayerTransaction checks every signature of a fee payer signed transaction, which unlike
+// [SignedTransaction.Verify] are over the [RawTransactionWithData].  It only asserts, so nodes can use it.
+func verifyFeePayerTransaction(t *testing.T, signedTxn *SignedTransaction) {
+	t.Helper()
+	auth, ok := signedTxn.Authenticator.Auth.(*FeePayerTransactionAuthenticator)
+	if !assert.True(t, ok) {
+		return
+	}
+	txn := &RawTransactionWithData{
+		Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+		Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+			RawTxn:           signedTxn.Transaction,
+			SecondarySigners: auth.SecondarySignerAddresses,
+			FeePayer:         auth.FeePayer,
+		},
+	}
+	message, err := txn.SigningMessage()
+	assert.NoError(t, err)
+	assert.True(t, auth.Verify(message))
+}
+
+func TestSponsorService_Sponsor(t *testing.T) {
+	t.Parallel()
+	feePayer, err := NewEd25519Account()
+	require.NoError(t, err)
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	service, err := NewSponsorService(feePayer, 4, sponsorTestPolicy)
+	require.NoError(t, err)
+	assert.Equal(t, feePayer.Address, service.FeePayer())
+
+	// Signed by the sender with the fee payer set, and left as 0x0
+	for _, signedFeePayer := range []AccountAddress{feePayer.Address, AccountZero} {
+		request := newSponsorshipRequest(t, sender, signedFeePayer, nil)
+		response, err := service.Sponsor(request)
+		require.NoError(t, err)
+		assert.Equal(t, feePayer.Address, response.FeePayer)
+		assert.Empty(t, response.Hash)
+
+		// The node checks a sender that left the fee payer as 0x0 against that
+		signedTxn := sponsoredTransaction(t, request, response)
+		if signedFeePayer == feePayer.Address {
+			verifyFeePayerTransaction(t, signedTxn)
+		} else {
+			message, err := request.Transaction.SigningMessage()
+			require.NoError(t, err)
+			assert.True(t, response.FeePayerAuthenticator.Verify(message))
+		}
+	}
+
+	// Without a client, nothing is submitted
+	request := newSponsorshipRequest(t, sender, feePayer.Address, nil)
+	request.Submit = true
+	_, err = service.Sponsor(request)
+	require.ErrorIs(t, err, ErrSponsorshipDenied)
+}
+
+func TestSponsorService_Policy(t *testing.T) {
+	t.Parallel()
+	feePayer, err := NewEd25519Account()
+	require.NoError(t, err)
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	other, err := NewEd25519Account()
+	require.NoError(t, err)
+	service, err := NewSponsorService(feePayer, 4, sponsorTestPolicy)
+	require.NoError(t, err)
+
+	denied := map[string]func(*RawTransaction){
+		"chain id":       func(rawTxn *RawTransaction) { rawTxn.ChainId = 2 },
+		"max gas":        func(rawTxn *RawTransaction) { rawTxn.MaxGasAmount = 10_001 },
+		"gas price":      func(rawTxn *RawTransaction) { rawTxn.GasUnitPrice = 201 },
+		"expired":        func(rawTxn *RawTransaction) { rawTxn.ExpirationTimestampSeconds = uint64(time.Now().Unix()) - 1 },
+		"far expiry":     func(rawTxn *RawTransaction) { rawTxn.ExpirationTimestampSeconds += 3600 },
+		"other module":   func(rawTxn *RawTransaction) { rawTxn.Payload.Payload.(*EntryFunction).Module.Name = "coin" },
+		"other function": func(rawTxn *RawTransaction) { rawTxn.Payload.Payload.(*EntryFunction).Function = "batch_transfer" },
+		"script":         func(rawTxn *RawTransaction) { rawTxn.Payload = TransactionPayload{Payload: &Script{Code: []byte{1}}} },
+		"multisig": func(rawTxn *RawTransaction) {
+			payload, err := OrderlessPayload(TransactionPayload{Payload: &Multisig{
+				MultisigAddress: AccountTwo,
+				Payload: &MultisigTransactionPayload{
+					Variant: MultisigTransactionPayloadVariantEntryFunction,
+					Payload: rawTxn.Payload.Payload.(*EntryFunction),
+				},
+			}}, 1)
+			require.NoError(t, err)
+			rawTxn.Payload = payload
+		},
+	}
+	for name, modify := range denied {
+		_, err = service.Sponsor(newSponsorshipRequest(t, sender, feePayer.Address, modify))
+		assert.ErrorIs(t, err, ErrSponsorshipDenied, name)
+	}
+
+	// The same entry function in an orderless payload is sponsored, as long as it isn't for a multisig account
+	_, err = service.Sponsor(newSponsorshipRequest(t, sender, feePayer.Address, func(rawTxn *RawTransaction) {
+		payload, err := OrderlessPayload(rawTxn.Payload, 1)
+		require.NoError(t, err)
+		rawTxn.Payload = payload
+	}))
+	require.NoError(t, err)
+
+	// The fee payer's signature could be reused to act as the sender
+	_, err = service.Sponsor(newSponsorshipRequest(t, other, feePayer.Address, func(rawTxn *RawTransaction) {
+		rawTxn.Sender = feePayer.Address
+	}))
+	require.ErrorIs(t, err, ErrSponsorshipDenied)
+
+	// Another fee payer
+	_, err = service.Sponsor(newSponsorshipRequest(t, sender, other.Address, nil))
+	require.ErrorIs(t, err, ErrSponsorshipDenied)
+
+	// Bad signatures
+	request := newSponsorshipRequest(t, sender, feePayer.Address, nil)
+	request.Transaction.Inner.(*MultiAgentWithFeePayerRawTransactionWithData).RawTxn.MaxGasAmount++
+	_, err = service.Sponsor(request)
+	require.ErrorIs(t, err, ErrInvalidInput)
+	request = newSponsorshipRequest(t, sender, feePayer.Address, nil)
+	request.Transaction.Inner.(*MultiAgentWithFeePayerRawTransactionWithData).SecondarySigners = []AccountAddress{other.Address}
+	_, err = service.Sponsor(request)
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	// A whole module can be allowed
+	service, err = NewSponsorService(feePayer, 4, SponsorPolicy{AllowedFunctions: []string{"0x01::aptos_account"}})
+	require.NoError(t, err)
+	_, err = service.Sponsor(newSponsorshipRequest(t, sender, feePayer.Address, nil))
+	require.NoError(t, err)
+
+	_, err = NewSponsorService(feePayer, 4, SponsorPolicy{AllowedFunctions: []string{"aptos_account"}})
+	require.Error(t, err)
+	_, err = NewSponsorService(feePayer, 4, sponsorTestPolicy, "not an option")
+	require.Error(t, err)
+}
+
+func TestSponsorService_RateLimit(t *testing.T) {
+	t.Parallel()
+	feePayer, err := NewEd25519Account()
+	require.NoError(t, err)
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	other, err := NewEd25519Account()
+	require.NoError(t, err)
+	policy := sponsorTestPolicy
+	policy.MaxTransactionsPerSender = 2
+	service, err := NewSponsorService(feePayer, 4, policy)
+	require.NoError(t, err)
+
+	for range 2 {
+		_, err = service.Sponsor(newSponsorshipRequest(t, sender, feePayer.Address, nil))
+		require.NoError(t, err)
+	}
+	_, err = service.Sponsor(newSponsorshipRequest(t, sender, feePayer.Address, nil))
+	require.ErrorIs(t, err, ErrSponsorshipRateLimited)
+	_, err = service.Sponsor(newSponsorshipRequest(t, other, feePayer.Address, nil))
+	require.NoError(t, err)
+
+	// The window slides
+	now := time.Now()
+	require.ErrorIs(t, service.reserve(sender.Address, now), ErrSponsorshipRateLimited)
+	require.NoError(t, service.reserve(sender.Address, now.Add(DefaultSponsorRateLimitWindow)))
+}
+
+func TestSponsorService_ServeHTTP(t *testing.T) {
+	t.Parallel()
+	feePayer, err := NewEd25519Account()
+	require.NoError(t, err)
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+
+	// A node accepting transactions that verify
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		signedTxn := &SignedTransaction{}
+		assert.NoError(t, bcs.Deserialize(signedTxn, body))
+		verifyFeePayerTransaction(t, signedTxn)
+		hash, err := signedTxn.Hash()
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintf(w, `{"hash":%q}`, hash)
+	}))
+	defer node.Close()
+	client, err := NewNodeClient(node.URL, 4)
+	require.NoError(t, err)
+	service, err := NewSponsorService(feePayer, 4, sponsorTestPolicy, client)
+	require.NoError(t, err)
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	post := func(request *SponsorshipRequest) *http.Response {
+		body, err := bcs.Serialize(request)
+		require.NoError(t, err)
+		response, err := http.Post(server.URL, "application/x-bcs", bytes.NewReader(body))
+		require.NoError(t, err)
+		return response
+	}
+
+	// Signed
+	request := newSponsorshipRequest(t, sender, feePayer.Address, nil)
+	response := post(request)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	sponsored := &SponsorshipResponse{}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(sponsored))
+	verifyFeePayerTransaction(t, sponsoredTransaction(t, request, sponsored))
+
+	// Submitted
+	request = newSponsorshipRequest(t, sender, feePayer.Address, nil)
+	request.Submit = true
+	response = post(request)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusAccepted, response.StatusCode)
+	sponsored = &SponsorshipResponse{}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(sponsored))
+	hash, err := sponsoredTransaction(t, request, sponsored).Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, sponsored.Hash)
+
+	// Denied
+	response = post(newSponsorshipRequest(t, sender, feePayer.Address, func(rawTxn *RawTransaction) { rawTxn.ChainId = 1 }))
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+	apiErr := &api.Error{}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(apiErr))
+	assert.Equal(t, api.ErrorCodeRejectedByFilter, apiErr.ErrorCode)
+
+	// Malformed
+	response, err = http.Post(server.URL, "application/x-bcs", bytes.NewReader([]byte{1, 2, 3}))
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	response, err = http.Get(server.URL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, response.StatusCode)
+}
@@ -0,0 +1,119 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// tableItemRequest is the body of a JSON table item lookup
+type tableItemRequest struct {
+	KeyType   string `json:"key_type"`
+	ValueType string `json:"value_type"`
+	Key       any    `json:"key"`
+}
+
+// rawTableItemRequest is the body of a BCS table item lookup
+type rawTableItemRequest struct {
+	Key string `json:"key"`
+}
+
+// GetTableItem fetches an item of a 0x1::table::Table by its key, decoded into JSON-like data.
+// Optionally, a ledgerVersion can be given to get the item at a specific ledger version
+//
+// The handle is the address of the table, found in the resource holding it.  The key is given in the same JSON form the
+// node would return it in, e.g. a string for an address or a u64.
+//
+//	value, err := client.GetTableItem(handle, "address", "u64", "0x1")
+func (rc *NodeClient) GetTableItem(handle string, keyType string, valueType string, key any, ledgerVersion ...uint64) (any, error) {
+	return rc.GetTableItemCtx(context.Background(), handle, keyType, valueType, key, ledgerVersion...)
+}
+
+// GetTableItemCtx is [NodeClient.GetTableItem] with its requests bound to ctx
+func (rc *NodeClient) GetTableItemCtx(ctx context.Context, handle string, keyType string, valueType string, key any, ledgerVersion ...uint64) (any, error) {
+	body, err := json.Marshal(&tableItemRequest{
+		KeyType:   keyType,
+		ValueType: valueType,
+		Key:       key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	au := rc.tableItemUrl(handle, "item", ledgerVersion...)
+	data, err := postIdempotent[any](ctx, rc, au.String(), ContentTypeJson, body)
+	if err != nil {
+		return nil, fmt.Errorf("get table item api err: %w", err)
+	}
+	return data, nil
+}
+
+// GetRawTableItem fetches an item of a 0x1::table::Table by its BCS encoded key, returning the BCS encoded value.
+// Optionally, a ledgerVersion can be given to get the item at a specific ledger version
+func (rc *NodeClient) GetRawTableItem(handle string, key []byte, ledgerVersion ...uint64) ([]byte, error) {
+	return rc.GetRawTableItemCtx(context.Background(), handle, key, ledgerVersion...)
+}
+
+// GetRawTableItemCtx is [NodeClient.GetRawTableItem] with its requests bound to ctx
+func (rc *NodeClient) GetRawTableItemCtx(ctx context.Context, handle string, key []byte, ledgerVersion ...uint64) ([]byte, error) {
+	body, err := json.Marshal(&rawTableItemRequest{Key: BytesToHex(key)})
+	if err != nil {
+		return nil, err
+	}
+	au := rc.tableItemUrl(handle, "raw_item", ledgerVersion...)
+	response, err := rc.withRetry(ctx, func() (*rawResponse, error) {
+		return rc.doRequest(ctx, http.MethodPost, au.String(), ContentTypeJson, "application/x-bcs", body, true)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get raw table item api err: %w", err)
+	}
+	return response.body, nil
+}
+
+// tableItemUrl is the URL of a table item endpoint
+func (rc *NodeClient) tableItemUrl(handle string, endpoint string, ledgerVersion ...uint64) *url.URL {
+	au := rc.baseUrl.JoinPath("tables", handle, endpoint)
+	if len(ledgerVersion) > 0 {
+		params := url.Values{}
+		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
+		au.RawQuery = params.Encode()
+	}
+	return au
+}
+
+// GetTableItemBCS fetches an item of a 0x1::table::Table by its key, and decodes the value from BCS into a T
+// Optionally, a ledgerVersion can be given to get the item at a specific ledger version
+//
+//	key := AccountOne
+//	value, err := GetTableItemBCS[AccountAddress](client, handle, &key)
+func GetTableItemBCS[T any, PT interface {
+	*T
+	bcs.Unmarshaler
+}](client AptosRpcClient, handle string, key bcs.Marshaler, ledgerVersion ...uint64) (*T, error) {
+	return GetTableItemBCSCtx[T, PT](context.Background(), client, handle, key, ledgerVersion...)
+}
+
+// GetTableItemBCSCtx is [GetTableItemBCS] with its requests bound to ctx
+func GetTableItemBCSCtx[T any, PT interface {
+	*T
+	bcs.Unmarshaler
+}](ctx context.Context, client AptosRpcClient, handle string, key bcs.Marshaler, ledgerVersion ...uint64) (*T, error) {
+	keyBytes, err := bcs.Serialize(key)
+	if err != nil {
+		return nil, err
+	}
+	valueBytes, err := client.GetRawTableItemCtx(ctx, handle, keyBytes, ledgerVersion...)
+	if err != nil {
+		return nil, err
+	}
+	value := PT(new(T))
+	err = bcs.Deserialize(value, valueBytes)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,55 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeClient_GetTableItem(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/tables/0x123/item", r.URL.Path)
+		assert.Equal(t, "5", r.URL.Query().Get("ledger_version"))
+
+		request := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, map[string]any{"key_type": "address", "value_type": "u64", "key": "0x1"}, request)
+		_, _ = w.Write([]byte(`"1000"`))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	value, err := client.GetTableItem("0x123", "address", "u64", "0x1", 5)
+	require.NoError(t, err)
+	assert.Equal(t, "1000", value)
+}
+
+func TestGetTableItemBCS(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tables/0x123/raw_item", r.URL.Path)
+		assert.Equal(t, "application/x-bcs", r.Header.Get("Accept"))
+
+		request := map[string]string{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, BytesToHex(AccountOne[:]), request["key"])
+		_, _ = w.Write(AccountTwo[:])
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	key := AccountOne
+	value, err := GetTableItemBCS[AccountAddress](client, "0x123", &key)
+	require.NoError(t, err)
+	assert.Equal(t, AccountTwo, *value)
+}
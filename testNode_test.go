@@ -0,0 +1,86 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testNode is a fake node for the followers of paged transactions.  It serves the transactions of a chain from version
+// oldest up to, but not including, head, along with the node info and any other routes given.
+type testNode struct {
+	oldest atomic.Uint64
+	head   atomic.Uint64
+	grow   uint64       // Added to head by each page of transactions served
+	fail   atomic.Int32 // Fail this many of the next requests, other than for the node info, with a 503
+	gap    atomic.Bool  // Skip the second transaction of the next page
+
+	events func(version uint64) []map[string]any // Events of the transaction at a version, or nil for state checkpoints
+	routes map[string]http.HandlerFunc           // Other routes by path, the rest are not found
+}
+
+// newTestNode starts serving node, it must be closed by the caller
+func newTestNode(t *testing.T, node *testNode) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			err := json.NewEncoder(w).Encode(map[string]any{
+				"chain_id":              4,
+				"ledger_version":        strconv.FormatUint(node.head.Load()-1, 10),
+				"oldest_ledger_version": strconv.FormatUint(node.oldest.Load(), 10),
+			})
+			assert.NoError(t, err)
+			return
+		}
+		if node.fail.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != "/transactions" {
+			if route, ok := node.routes[r.URL.Path]; ok {
+				route(w, r)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found","error_code":"account_not_found"}`))
+			return
+		}
+
+		start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		limit, _ := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+		head := node.head.Add(node.grow) - node.grow
+		switch {
+		case start < node.oldest.Load():
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"message":"pruned","error_code":"version_pruned"}`))
+			return
+		case start >= head:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found","error_code":"version_not_found"}`))
+			return
+		}
+		gap := node.gap.Swap(false)
+		txns := make([]map[string]any, 0, limit)
+		for version := start; version < min(start+limit, head); version++ {
+			if gap && version == start+1 {
+				continue
+			}
+			txn := map[string]any{
+				"type":    "state_checkpoint_transaction",
+				"version": strconv.FormatUint(version, 10),
+				"success": true,
+			}
+			if node.events != nil {
+				txn["type"] = "block_metadata_transaction"
+				txn["events"] = node.events(version)
+			}
+			txns = append(txns, txn)
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(txns))
+	}))
+}
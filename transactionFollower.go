@@ -0,0 +1,305 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// CheckpointStore persists the progress of a [TransactionFollower], so it resumes where it stopped after a restart.
+// Implementations must make each SaveCheckpoint durable before returning.
+type CheckpointStore interface {
+	// LoadCheckpoint returns the saved version, false if none was saved yet
+	LoadCheckpoint() (uint64, bool, error)
+
+	// SaveCheckpoint saves version, the next version to be processed
+	SaveCheckpoint(version uint64) error
+}
+
+// FileCheckpointStore is a [CheckpointStore] keeping the checkpoint in a file.  It's written to a temporary file and
+// renamed into place, so a crash leaves either the old or the new checkpoint.
+type FileCheckpointStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileCheckpointStore creates a [FileCheckpointStore] at path, the file is created on the first save
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// LoadCheckpoint returns the saved version, false if none was saved yet
+//
+// Implements:
+//   - [CheckpointStore]
+func (store *FileCheckpointStore) LoadCheckpoint() (uint64, bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	data, err := os.ReadFile(store.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	version, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("bad checkpoint in %s: %w", store.path, err)
+	}
+	return version, true, nil
+}
+
+// SaveCheckpoint saves version, the next version to be processed
+//
+// Implements:
+//   - [CheckpointStore]
+func (store *FileCheckpointStore) SaveCheckpoint(version uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeFileAtomic(store.path, []byte(strconv.FormatUint(version, 10)+"\n"))
+}
+
+// TransactionFollowerConfig configures a [TransactionFollower], zero values use the defaults
+type TransactionFollowerConfig struct {
+	PageSize   uint64        // Transactions fetched per request, at most 100, defaults to 100
+	PollPeriod time.Duration // How long to wait for new transactions once caught up with the ledger, defaults to 1s
+	MaxBackoff time.Duration // Cap on the wait after consecutive failed requests, defaults to 30s
+	SkipPruned bool          // Skip ahead to the oldest version the node has, instead of failing, if the next is pruned
+}
+
+// DefaultTransactionFollowerConfig is the [TransactionFollowerConfig] used by [NewTransactionFollower]
+func DefaultTransactionFollowerConfig() TransactionFollowerConfig {
+	return TransactionFollowerConfig{
+		PageSize:   100,
+		PollPeriod: time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+// TransactionFollower streams committed transactions in strict version order, without gaps, following the chain as
+// it grows.  Once caught up with the ledger it polls every PollPeriod.  Failed requests that may succeed later, see
+// [RetryPolicy], are retried with a backoff doubling up to MaxBackoff, any other error stops the follower.
+//
+// With a [CheckpointStore], the version after each processed transaction is saved, and a new follower over the same
+// store resumes from it, rather than its start version.
+//
+//	follower := NewTransactionFollower(client, 0, NewFileCheckpointStore("indexer.checkpoint"))
+//	for txn, err := range follower.Transactions(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		index(txn)
+//	}
+type TransactionFollower struct {
+	client AptosRpcClient
+	store  CheckpointStore
+	config TransactionFollowerConfig
+
+	mutex sync.Mutex // Only one stream at a time
+	next  uint64     // Next version to fetch, replaced by the checkpoint if there is one
+}
+
+// NewTransactionFollower creates a [TransactionFollower] starting at version start, or at the checkpoint in store if
+// it has one.  store may be nil to not keep checkpoints.
+func NewTransactionFollower(client AptosRpcClient, start uint64, store CheckpointStore, config ...TransactionFollowerConfig) *TransactionFollower {
+	cfg := DefaultTransactionFollowerConfig()
+	if len(config) > 0 {
+		if config[0].PageSize != 0 {
+			cfg.PageSize = min(config[0].PageSize, 100)
+		}
+		if config[0].PollPeriod != 0 {
+			cfg.PollPeriod = config[0].PollPeriod
+		}
+		if config[0].MaxBackoff != 0 {
+			cfg.MaxBackoff = config[0].MaxBackoff
+		}
+		cfg.SkipPruned = config[0].SkipPruned
+	}
+	return &TransactionFollower{
+		client: client,
+		next:   start,
+		store:  store,
+		config: cfg,
+	}
+}
+
+// Transactions iterates over committed transactions from where the follower left off, indefinitely.  The checkpoint
+// is saved once the loop body returns for a transaction, including by break, so a transaction is processed again
+// after a restart only if the process stopped while processing it.
+//
+// The iteration ends when ctx is done, or after yielding an error that stopped the follower.  Once the loop body
+// breaks, nothing more is yielded, so failing to save the checkpoint for the last transaction is not reported, and
+// that transaction is processed again after a restart.
+func (tf *TransactionFollower) Transactions(ctx context.Context) iter.Seq2[*api.CommittedTransaction, error] {
+	return func(yield func(*api.CommittedTransaction, error) bool) {
+		stopped := false
+		err := tf.follow(ctx, func(txn *api.CommittedTransaction) (bool, bool) {
+			stopped = !yield(txn, nil)
+			return true, !stopped
+		})
+		if err != nil && !stopped && ctx.Err() == nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// Run sends committed transactions to transactions from where the follower left off, until ctx is done or an error
+// stops the follower.  transactions is not closed.
+//
+// Delivery is at most once: the checkpoint is saved as soon as a transaction is received from the channel, before the
+// receiver has processed it, so a transaction in flight when the process stops is skipped after a restart.  Indexers
+// that must process every transaction should range over [TransactionFollower.Transactions] instead.
+//
+//	transactions := make(chan *api.CommittedTransaction)
+//	go func() {
+//		err := follower.Run(ctx, transactions)
+//		...
+//	}()
+func (tf *TransactionFollower) Run(ctx context.Context, transactions chan<- *api.CommittedTransaction) error {
+	err := tf.follow(ctx, func(txn *api.CommittedTransaction) (bool, bool) {
+		select {
+		case transactions <- txn:
+			return true, true
+		case <-ctx.Done():
+			return false, false
+		}
+	})
+	if err == nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// follow fetches transactions in order and hands each to process, which returns whether it handled the transaction,
+// and whether to continue.  The checkpoint is saved after each handled transaction.  Returns nil if process stops the
+// follower or ctx is done.
+func (tf *TransactionFollower) follow(ctx context.Context, process func(txn *api.CommittedTransaction) (bool, bool)) error {
+	tf.mutex.Lock()
+	defer tf.mutex.Unlock()
+
+	if tf.store != nil {
+		checkpoint, ok, err := tf.store.LoadCheckpoint()
+		if err != nil {
+			return fmt.Errorf("failed to load checkpoint: %w", err)
+		}
+		if ok {
+			tf.next = checkpoint
+		}
+	}
+
+	failures := 0
+	for ctx.Err() == nil {
+		txns, err := tf.fetch(ctx)
+		if err != nil {
+			if !isRetryableError(ctx, err) {
+				return err
+			}
+			failures++
+			if !sleepCtx(ctx, tf.backoff(failures)) {
+				return nil
+			}
+			continue
+		}
+		failures = 0
+
+		for _, txn := range txns {
+			handled, more := process(txn)
+			if handled {
+				tf.next = txn.Version() + 1
+				if tf.store != nil {
+					err = tf.store.SaveCheckpoint(tf.next)
+					if err != nil {
+						return fmt.Errorf("failed to save checkpoint: %w", err)
+					}
+				}
+			}
+			if !more {
+				return nil
+			}
+		}
+
+		// Caught up with the ledger, wait for more
+		if uint64(len(txns)) < tf.config.PageSize && !sleepCtx(ctx, tf.config.PollPeriod) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// fetch fetches the next page of transactions, dropping any before the next version, and any after a gap
+func (tf *TransactionFollower) fetch(ctx context.Context) ([]*api.CommittedTransaction, error) {
+	start := tf.next
+	limit := tf.config.PageSize
+	txns, err := tf.client.TransactionsCtx(ctx, &start, &limit)
+	switch {
+	case errors.Is(err, ErrVersionNotFound):
+		// Ahead of this node's ledger
+		return nil, nil
+	case errors.Is(err, ErrVersionPruned) && tf.config.SkipPruned:
+		info, infoErr := tf.client.InfoCtx(ctx)
+		if infoErr != nil {
+			return nil, infoErr
+		}
+		if oldest := info.OldestLedgerVersion(); oldest > tf.next {
+			tf.next = oldest
+		}
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	// Behind a load balancer, pages may come from nodes that are behind, or skip versions, so only transactions
+	// continuing the sequence are kept
+	expected := tf.next
+	for i, txn := range txns {
+		if txn.Version() < expected {
+			continue
+		}
+		if txn.Version() > expected {
+			return filterFrom(txns[:i], tf.next), nil
+		}
+		expected++
+	}
+	return filterFrom(txns, tf.next), nil
+}
+
+// filterFrom returns the transactions at version start or later
+func filterFrom(txns []*api.CommittedTransaction, start uint64) []*api.CommittedTransaction {
+	for i, txn := range txns {
+		if txn.Version() >= start {
+			return txns[i:]
+		}
+	}
+	return nil
+}
+
+// backoff is the wait after the given number of consecutive failures, doubling from the PollPeriod up to MaxBackoff
+func (tf *TransactionFollower) backoff(failures int) time.Duration {
+	delay := tf.config.PollPeriod
+	for range failures - 1 {
+		delay *= 2
+		if delay >= tf.config.MaxBackoff {
+			return tf.config.MaxBackoff
+		}
+	}
+	return min(delay, tf.config.MaxBackoff)
+}
+
+// sleepCtx waits for duration, false if ctx was done first
+func sleepCtx(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
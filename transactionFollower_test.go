@@ -0,0 +1,177 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFollowerConfig() TransactionFollowerConfig {
+	return TransactionFollowerConfig{PageSize: 10, PollPeriod: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestTransactionFollower_Transactions(t *testing.T) {
+	t.Parallel()
+	node := &testNode{grow: 7}
+	node.head.Store(25)
+	node.fail.Store(2)
+	node.gap.Store(true)
+	server := newTestNode(t, node)
+	defer server.Close()
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	// Follows the chain as it grows, through failures and gaps, in order
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint"))
+	follower := NewTransactionFollower(client, 5, store, testFollowerConfig())
+	expected := uint64(5)
+	for txn, err := range follower.Transactions(context.Background()) {
+		require.NoError(t, err)
+		require.Equal(t, expected, txn.Version())
+		expected++
+		if expected == 100 {
+			break
+		}
+	}
+	checkpoint, ok, err := store.LoadCheckpoint()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), checkpoint)
+
+	// A new follower resumes after the last processed transaction
+	follower = NewTransactionFollower(client, 0, store, testFollowerConfig())
+	for txn, err := range follower.Transactions(context.Background()) {
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), txn.Version())
+		break
+	}
+	checkpoint, _, err = store.LoadCheckpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(101), checkpoint)
+}
+
+func TestTransactionFollower_Run(t *testing.T) {
+	t.Parallel()
+	node := &testNode{}
+	node.head.Store(15)
+	server := newTestNode(t, node)
+	defer server.Close()
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	transactions := make(chan *api.CommittedTransaction)
+	done := make(chan error, 1)
+	follower := NewTransactionFollower(client, 0, nil, testFollowerConfig())
+	go func() {
+		done <- follower.Run(ctx, transactions)
+	}()
+	for expected := range uint64(15) {
+		txn := <-transactions
+		assert.Equal(t, expected, txn.Version())
+	}
+
+	// Caught up, so it polls until more transactions commit
+	node.head.Store(18)
+	assert.Equal(t, uint64(15), (<-transactions).Version())
+	assert.Equal(t, uint64(16), (<-transactions).Version())
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	// Without a checkpoint store, it resumes in memory
+	for txn, err := range follower.Transactions(context.Background()) {
+		require.NoError(t, err)
+		assert.Equal(t, uint64(17), txn.Version())
+		break
+	}
+}
+
+func TestTransactionFollower_Pruned(t *testing.T) {
+	t.Parallel()
+	node := &testNode{}
+	node.head.Store(30)
+	node.oldest.Store(20)
+	server := newTestNode(t, node)
+	defer server.Close()
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	// Fails by default
+	follower := NewTransactionFollower(client, 0, nil, testFollowerConfig())
+	for _, err := range follower.Transactions(context.Background()) {
+		require.ErrorIs(t, err, ErrVersionPruned)
+	}
+
+	// Or skips to the oldest version
+	config := testFollowerConfig()
+	config.SkipPruned = true
+	follower = NewTransactionFollower(client, 0, nil, config)
+	for txn, err := range follower.Transactions(context.Background()) {
+		require.NoError(t, err)
+		assert.Equal(t, uint64(20), txn.Version())
+		break
+	}
+}
+
+// failingCheckpointStore has no checkpoint, and fails to save any
+type failingCheckpointStore struct{}
+
+func (failingCheckpointStore) LoadCheckpoint() (uint64, bool, error) {
+	return 0, false, nil
+}
+
+func (failingCheckpointStore) SaveCheckpoint(uint64) error {
+	return errors.New("disk full")
+}
+
+func TestTransactionFollower_SaveFailure(t *testing.T) {
+	t.Parallel()
+	node := &testNode{}
+	node.head.Store(10)
+	server := newTestNode(t, node)
+	defer server.Close()
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	// The save error stops the follower after the transaction
+	follower := NewTransactionFollower(client, 0, failingCheckpointStore{}, testFollowerConfig())
+	var versions []uint64
+	for txn, err := range follower.Transactions(context.Background()) {
+		if err != nil {
+			assert.ErrorContains(t, err, "disk full")
+			continue
+		}
+		versions = append(versions, txn.Version())
+	}
+	assert.Equal(t, []uint64{0}, versions)
+
+	// Nothing is yielded after a break, not even the save error
+	follower = NewTransactionFollower(client, 0, failingCheckpointStore{}, testFollowerConfig())
+	assert.NotPanics(t, func() {
+		for _, err := range follower.Transactions(context.Background()) {
+			require.NoError(t, err)
+			break
+		}
+	})
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	t.Parallel()
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint"))
+	_, ok, err := store.LoadCheckpoint()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.SaveCheckpoint(42))
+	version, ok, err := store.LoadCheckpoint()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), version)
+}
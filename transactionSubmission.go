@@ -1,8 +1,10 @@
 package aptos
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/aptos-labs/aptos-go-sdk/api"
@@ -51,27 +53,40 @@ type WorkerPoolConfig struct {
 }
 
 // BuildTransactions start a goroutine to process [TransactionPayload] and spit out [RawTransactionImpl].
+//
+// Sequence numbers are handed out by an [AccountSequenceNumber], which can be passed in as an option to share it.
 func (client *Client) BuildTransactions(sender AccountAddress, payloads chan TransactionBuildPayload, responses chan TransactionBuildResponse, setSequenceNumber chan uint64, options ...any) {
 	client.nodeClient.BuildTransactions(sender, payloads, responses, setSequenceNumber, options...)
 }
 
+// BuildTransactionsCtx is [Client.BuildTransactions] with its requests bound to ctx
+func (client *Client) BuildTransactionsCtx(ctx context.Context, sender AccountAddress, payloads chan TransactionBuildPayload, responses chan TransactionBuildResponse, setSequenceNumber chan uint64, options ...any) {
+	client.nodeClient.BuildTransactionsCtx(ctx, sender, payloads, responses, setSequenceNumber, options...)
+}
+
 // BuildTransactions start a goroutine to process [TransactionPayload] and spit out [RawTransactionImpl].
+//
+// Sequence numbers are handed out by an [AccountSequenceNumber], which can be passed in as an option to share it.
+// With the [Orderless] option, every transaction gets its own random replay nonce instead, and sequence numbers aren't
+// used at all.  A [ReplayNonce] can't be shared by the transactions, so it fails every one of them.
 func (rc *NodeClient) BuildTransactions(sender AccountAddress, payloads chan TransactionBuildPayload, responses chan TransactionBuildResponse, setSequenceNumber chan uint64, options ...any) {
+	rc.BuildTransactionsCtx(context.Background(), sender, payloads, responses, setSequenceNumber, options...)
+}
+
+// BuildTransactionsCtx is [NodeClient.BuildTransactions] with its requests bound to ctx.  Once ctx is done, every
+// remaining payload gets the context's error as its response, without any requests being made.
+func (rc *NodeClient) BuildTransactionsCtx(ctx context.Context, sender AccountAddress, payloads chan TransactionBuildPayload, responses chan TransactionBuildResponse, setSequenceNumber chan uint64, options ...any) {
 	// Initialize state
 	defer close(responses)
-	account, err := rc.Account(sender)
-	if err != nil {
-		responses <- TransactionBuildResponse{Err: err}
-		return
+	var sequenceNumbers *AccountSequenceNumber
+	optionsLast := -1
+	if isOrderless(options) {
+		options = withoutSequenceNumbers(options)
+	} else {
+		sequenceNumbers, options = sequenceNumbersOption(rc, sender, options)
+		optionsLast = len(options)
+		options = append(options, SequenceNumber(0))
 	}
-	sequenceNumber, err := account.SequenceNumber()
-	if err != nil {
-		responses <- TransactionBuildResponse{Err: err}
-		return
-	}
-	snt := sequenceNumber
-	optionsLast := len(options)
-	options = append(options, SequenceNumber(0))
 
 	for {
 		select {
@@ -80,34 +95,85 @@ func (rc *NodeClient) BuildTransactions(sender AccountAddress, payloads chan Tra
 			if !ok {
 				return
 			}
-			switch payload.Type {
-			case TransactionSubmissionTypeSingle:
-				options[optionsLast] = SequenceNumber(snt)
-				snt++
-				txnResponse, err := rc.BuildTransaction(sender, payload.Inner, options...)
+			if payload.Type != TransactionSubmissionTypeSingle && payload.Type != TransactionSubmissionTypeMultiAgent {
+				// Skip the payload
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				responses <- TransactionBuildResponse{Err: err}
+				continue
+			}
+			if slices.ContainsFunc(options, isReplayNonce) {
+				responses <- TransactionBuildResponse{Err: errors.New("a ReplayNonce can't be shared by transactions, use Orderless")}
+				continue
+			}
+			snt := uint64(0)
+			if sequenceNumbers != nil {
+				var err error
+				snt, err = sequenceNumbers.NextCtx(ctx)
 				if err != nil {
 					responses <- TransactionBuildResponse{Err: err}
-				} else {
-					responses <- TransactionBuildResponse{Response: txnResponse}
+					continue
 				}
-			case TransactionSubmissionTypeMultiAgent:
 				options[optionsLast] = SequenceNumber(snt)
-				snt++
-				txnResponse, err := rc.BuildTransactionMultiAgent(sender, payload.Inner, options...)
-				if err != nil {
-					responses <- TransactionBuildResponse{Err: err}
-				} else {
-					responses <- TransactionBuildResponse{Response: txnResponse}
+			}
+			var txnResponse RawTransactionImpl
+			var err error
+			switch payload.Type {
+			case TransactionSubmissionTypeSingle:
+				txnResponse, err = rc.BuildTransactionCtx(ctx, sender, payload.Inner, options...)
+			case TransactionSubmissionTypeMultiAgent:
+				txnResponse, err = rc.BuildTransactionMultiAgentCtx(ctx, sender, payload.Inner, options...)
+			}
+			if err != nil {
+				if sequenceNumbers != nil {
+					sequenceNumbers.release(snt)
 				}
-			default:
-				// Skip the payload
+				responses <- TransactionBuildResponse{Err: err}
+			} else {
+				responses <- TransactionBuildResponse{Response: txnResponse}
 			}
 		case newSequenceNumber := <-setSequenceNumber:
-			// This can be used to update the sequence number at anytime
-			snt = newSequenceNumber
-			// TODO: We should periodically handle reconciliation of the sequence numbers, but this needs to know submission as well
+			// This can be used to update the sequence number at anytime, orderless transactions don't have one
+			if sequenceNumbers != nil {
+				sequenceNumbers.Set(newSequenceNumber)
+			}
+		}
+	}
+}
+
+// pipelineSequenceNumbers is the [AccountSequenceNumber] of a pipeline for sender, as [sequenceNumbersOption], or nil
+// for orderless transactions, which don't need one
+func pipelineSequenceNumbers(client AptosRpcClient, sender AccountAddress, options []any) (*AccountSequenceNumber, []any) {
+	if isOrderless(options) {
+		return nil, withoutSequenceNumbers(options)
+	}
+	sequenceNumbers, options := sequenceNumbersOption(client, sender, options)
+	return sequenceNumbers, append(options, sequenceNumbers)
+}
+
+// withoutSequenceNumbers removes any [AccountSequenceNumber] from options, without modifying options
+func withoutSequenceNumbers(options []any) []any {
+	return slices.DeleteFunc(slices.Clone(options), func(option any) bool {
+		_, ok := option.(*AccountSequenceNumber)
+		return ok
+	})
+}
+
+// isReplayNonce is whether option is a [ReplayNonce]
+func isReplayNonce(option any) bool {
+	_, ok := option.(ReplayNonce)
+	return ok
+}
+
+// sequenceNumbersOption removes an [AccountSequenceNumber] from options, creating one for sender if there is none
+func sequenceNumbersOption(client AptosRpcClient, sender AccountAddress, options []any) (*AccountSequenceNumber, []any) {
+	for i, option := range options {
+		if sequenceNumbers, ok := option.(*AccountSequenceNumber); ok {
+			return sequenceNumbers, slices.Delete(slices.Clone(options), i, i+1)
 		}
 	}
+	return NewAccountSequenceNumber(client, sender), options
 }
 
 // SubmitTransactions consumes signed transactions, submits to aptos-node, yields responses.
@@ -116,13 +182,32 @@ func (client *Client) SubmitTransactions(requests chan TransactionSubmissionRequ
 	client.nodeClient.SubmitTransactions(requests, responses)
 }
 
+// SubmitTransactionsCtx is [Client.SubmitTransactions] with its requests bound to ctx
+func (client *Client) SubmitTransactionsCtx(ctx context.Context, requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse) {
+	client.nodeClient.SubmitTransactionsCtx(ctx, requests, responses)
+}
+
 // SubmitTransactions consumes signed transactions, submits to aptos-node, yields responses.
 // closes output chan `responses` when input chan `signedTxns` is closed.
 func (rc *NodeClient) SubmitTransactions(requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse) {
+	rc.SubmitTransactionsCtx(context.Background(), requests, responses)
+}
+
+// SubmitTransactionsCtx is [NodeClient.SubmitTransactions] with its requests bound to ctx.  Once ctx is done, every
+// remaining request gets the context's error as its response.
+func (rc *NodeClient) SubmitTransactionsCtx(ctx context.Context, requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse) {
+	rc.submitTransactions(ctx, requests, responses, nil)
+}
+
+// submitTransactions is [NodeClient.SubmitTransactionsCtx], resyncing sequenceNumbers on sequence number rejections
+func (rc *NodeClient) submitTransactions(ctx context.Context, requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse, sequenceNumbers *AccountSequenceNumber) {
 	defer close(responses)
 	for request := range requests {
-		response, err := rc.SubmitTransaction(request.SignedTxn)
+		response, err := rc.SubmitTransactionCtx(ctx, request.SignedTxn)
 		if err != nil {
+			if sequenceNumbers != nil {
+				sequenceNumbers.HandleErrorCtx(ctx, err)
+			}
 			responses <- TransactionSubmissionResponse{Id: request.Id, Err: err}
 		} else {
 			responses <- TransactionSubmissionResponse{Id: request.Id, Response: response}
@@ -130,9 +215,25 @@ func (rc *NodeClient) SubmitTransactions(requests chan TransactionSubmissionRequ
 	}
 }
 
+// BatchSubmitTransactions consumes signed transactions, submits to aptos-node, yields responses.
+// closes output chan `responses` when input chan `signedTxns` is closed.
+func (client *Client) BatchSubmitTransactions(requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse) {
+	client.nodeClient.BatchSubmitTransactions(requests, responses)
+}
+
+// BatchSubmitTransactionsCtx is [Client.BatchSubmitTransactions] with its requests bound to ctx
+func (client *Client) BatchSubmitTransactionsCtx(ctx context.Context, requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse) {
+	client.nodeClient.BatchSubmitTransactionsCtx(ctx, requests, responses)
+}
+
 // BatchSubmitTransactions consumes signed transactions, submits to aptos-node, yields responses.
 // closes output chan `responses` when input chan `signedTxns` is closed.
 func (rc *NodeClient) BatchSubmitTransactions(requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse) {
+	rc.BatchSubmitTransactionsCtx(context.Background(), requests, responses)
+}
+
+// BatchSubmitTransactionsCtx is [NodeClient.BatchSubmitTransactions] with its requests bound to ctx
+func (rc *NodeClient) BatchSubmitTransactionsCtx(ctx context.Context, requests chan TransactionSubmissionRequest, responses chan TransactionSubmissionResponse) {
 	defer close(responses)
 
 	inputs := make([]*SignedTransaction, 20)
@@ -147,7 +248,7 @@ func (rc *NodeClient) BatchSubmitTransactions(requests chan TransactionSubmissio
 
 		if i >= 19 {
 			i = 0
-			response, err := rc.BatchSubmitTransaction(inputs)
+			response, err := rc.BatchSubmitTransactionCtx(ctx, inputs)
 
 			// Process the responses
 			if err != nil {
@@ -190,13 +291,38 @@ func (client *Client) BuildSignAndSubmitTransactions(
 	client.nodeClient.BuildSignAndSubmitTransactions(sender, payloads, responses, buildOptions...)
 }
 
+// BuildSignAndSubmitTransactionsCtx is [Client.BuildSignAndSubmitTransactions] with its requests bound to ctx
+func (client *Client) BuildSignAndSubmitTransactionsCtx(
+	ctx context.Context,
+	sender TransactionSigner,
+	payloads chan TransactionBuildPayload,
+	responses chan TransactionSubmissionResponse,
+	buildOptions ...any,
+) {
+	client.nodeClient.BuildSignAndSubmitTransactionsCtx(ctx, sender, payloads, responses, buildOptions...)
+}
+
 // BuildSignAndSubmitTransactions starts up a goroutine to process transactions for a single [TransactionSender]
 // Closes output chan `responses` on completion of input chan `payloads`.
+//
+// With the [Orderless] build option, transactions are submitted without any sequence number coordination, see
+// [NodeClient.BuildTransactions].  A [SimulateMaxGas] without a Signer simulates as sender.
 func (rc *NodeClient) BuildSignAndSubmitTransactions(
 	sender TransactionSigner,
 	payloads chan TransactionBuildPayload,
 	responses chan TransactionSubmissionResponse,
 	buildOptions ...any,
+) {
+	rc.BuildSignAndSubmitTransactionsCtx(context.Background(), sender, payloads, responses, buildOptions...)
+}
+
+// BuildSignAndSubmitTransactionsCtx is [NodeClient.BuildSignAndSubmitTransactions] with its requests bound to ctx
+func (rc *NodeClient) BuildSignAndSubmitTransactionsCtx(
+	ctx context.Context,
+	sender TransactionSigner,
+	payloads chan TransactionBuildPayload,
+	responses chan TransactionSubmissionResponse,
+	buildOptions ...any,
 ) {
 	singleSigner := func(rawTxn RawTransactionImpl) (*SignedTransaction, error) {
 		switch rawTxn := rawTxn.(type) {
@@ -216,7 +342,9 @@ func (rc *NodeClient) BuildSignAndSubmitTransactions(
 		}
 	}
 
-	rc.BuildSignAndSubmitTransactionsWithSignFunction(
+	buildOptions = withSimulationSigner(sender, buildOptions)
+	rc.BuildSignAndSubmitTransactionsWithSignFunctionCtx(
+		ctx,
 		sender.AccountAddress(),
 		payloads,
 		responses,
@@ -287,6 +415,26 @@ func (client *Client) BuildSignAndSubmitTransactionsWithSignFunction(
 	)
 }
 
+// BuildSignAndSubmitTransactionsWithSignFunctionCtx is [Client.BuildSignAndSubmitTransactionsWithSignFunction] with
+// its requests bound to ctx
+func (client *Client) BuildSignAndSubmitTransactionsWithSignFunctionCtx(
+	ctx context.Context,
+	sender AccountAddress,
+	payloads chan TransactionBuildPayload,
+	responses chan TransactionSubmissionResponse,
+	sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error),
+	buildOptions ...any,
+) {
+	client.nodeClient.BuildSignAndSubmitTransactionsWithSignFunctionCtx(
+		ctx,
+		sender,
+		payloads,
+		responses,
+		sign,
+		buildOptions...,
+	)
+}
+
 // BuildSignAndSubmitTransactionsWithSignFunction allows for signing with a custom function
 //
 // Closes output chan `responses` on completion of input chan `payloads`.
@@ -339,18 +487,32 @@ func (rc *NodeClient) BuildSignAndSubmitTransactionsWithSignFunction(
 	responses chan TransactionSubmissionResponse,
 	sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error),
 	buildOptions ...any,
+) {
+	rc.BuildSignAndSubmitTransactionsWithSignFunctionCtx(context.Background(), sender, payloads, responses, sign, buildOptions...)
+}
+
+// BuildSignAndSubmitTransactionsWithSignFunctionCtx is [NodeClient.BuildSignAndSubmitTransactionsWithSignFunction]
+// with its requests bound to ctx
+func (rc *NodeClient) BuildSignAndSubmitTransactionsWithSignFunctionCtx(
+	ctx context.Context,
+	sender AccountAddress,
+	payloads chan TransactionBuildPayload,
+	responses chan TransactionSubmissionResponse,
+	sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error),
+	buildOptions ...any,
 ) {
 	// TODO: Make internal buffer size configurable with an optional parameter
 
 	// Set up the channel handling building transactions
 	buildResponses := make(chan TransactionBuildResponse, 20)
 	setSequenceNumber := make(chan uint64)
-	go rc.BuildTransactions(sender, payloads, buildResponses, setSequenceNumber, buildOptions...)
+	sequenceNumbers, buildOptions := pipelineSequenceNumbers(rc, sender, buildOptions)
+	go rc.BuildTransactionsCtx(ctx, sender, payloads, buildResponses, setSequenceNumber, buildOptions...)
 
 	submissionRequests := make(chan TransactionSubmissionRequest, 20)
 	// Note that, I change this to BatchSubmitTransactions, and it caused no change in performance.  The non-batched
 	// version is more flexible and gives actual responses.  It is may be that with large payloads that batch more performant.
-	go rc.SubmitTransactions(submissionRequests, responses)
+	go rc.submitTransactions(ctx, submissionRequests, responses, sequenceNumbers)
 
 	var wg sync.WaitGroup
 
@@ -431,6 +593,36 @@ func startSigningWorkers(
 	return transactionsToSign
 }
 
+// BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool processes transactions using a fixed-size worker pool.
+// It coordinates three stages of the pipeline:
+// 1. Building transactions (BuildTransactions)
+// 2. Signing transactions (worker pool)
+// 3. Submitting transactions (SubmitTransactions)
+func (client *Client) BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool(
+	sender AccountAddress,
+	payloads chan TransactionBuildPayload,
+	responses chan TransactionSubmissionResponse,
+	sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error),
+	workerPoolConfig WorkerPoolConfig,
+	buildOptions ...any,
+) {
+	client.nodeClient.BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool(sender, payloads, responses, sign, workerPoolConfig, buildOptions...)
+}
+
+// BuildSignAndSubmitTransactionsWithSignFnAndWorkerPoolCtx is
+// [Client.BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool] with its requests bound to ctx
+func (client *Client) BuildSignAndSubmitTransactionsWithSignFnAndWorkerPoolCtx(
+	ctx context.Context,
+	sender AccountAddress,
+	payloads chan TransactionBuildPayload,
+	responses chan TransactionSubmissionResponse,
+	sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error),
+	workerPoolConfig WorkerPoolConfig,
+	buildOptions ...any,
+) {
+	client.nodeClient.BuildSignAndSubmitTransactionsWithSignFnAndWorkerPoolCtx(ctx, sender, payloads, responses, sign, workerPoolConfig, buildOptions...)
+}
+
 // BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool processes transactions using a fixed-size worker pool.
 // It coordinates three stages of the pipeline:
 // 1. Building transactions (BuildTransactions)
@@ -443,6 +635,20 @@ func (rc *NodeClient) BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool(
 	sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error),
 	workerPoolConfig WorkerPoolConfig,
 	buildOptions ...any,
+) {
+	rc.BuildSignAndSubmitTransactionsWithSignFnAndWorkerPoolCtx(context.Background(), sender, payloads, responses, sign, workerPoolConfig, buildOptions...)
+}
+
+// BuildSignAndSubmitTransactionsWithSignFnAndWorkerPoolCtx is
+// [NodeClient.BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool] with its requests bound to ctx
+func (rc *NodeClient) BuildSignAndSubmitTransactionsWithSignFnAndWorkerPoolCtx(
+	ctx context.Context,
+	sender AccountAddress,
+	payloads chan TransactionBuildPayload,
+	responses chan TransactionSubmissionResponse,
+	sign func(rawTxn RawTransactionImpl) (*SignedTransaction, error),
+	workerPoolConfig WorkerPoolConfig,
+	buildOptions ...any,
 ) {
 	buildBuffer, submissionBuffer := workerPoolConfig.getBufferSizes()
 	numWorkers := workerPoolConfig.NumWorkers
@@ -452,10 +658,11 @@ func (rc *NodeClient) BuildSignAndSubmitTransactionsWithSignFnAndWorkerPool(
 
 	buildResponses := make(chan TransactionBuildResponse, buildBuffer)
 	setSequenceNumber := make(chan uint64)
-	go rc.BuildTransactions(sender, payloads, buildResponses, setSequenceNumber, buildOptions...)
+	sequenceNumbers, buildOptions := pipelineSequenceNumbers(rc, sender, buildOptions)
+	go rc.BuildTransactionsCtx(ctx, sender, payloads, buildResponses, setSequenceNumber, buildOptions...)
 
 	submissionRequests := make(chan TransactionSubmissionRequest, submissionBuffer)
-	go rc.SubmitTransactions(submissionRequests, responses)
+	go rc.submitTransactions(ctx, submissionRequests, responses, sequenceNumbers)
 
 	var signingWg sync.WaitGroup
 	var transactionWg sync.WaitGroup
@@ -0,0 +1,371 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// TxnQueue builds, signs and submits transactions for a single sender, recording every step of each transaction in a
+// [TxnStore].  A new TxnQueue over the same store picks up where a previous process left off: signed transactions are
+// resubmitted, submitted ones are looked up by hash, and ones expired by the chain's clock are rebuilt with a new
+// sequence number.  Every hash a transaction was signed with is kept and checked, in case an earlier one commits.
+//
+// Sequence numbers come from an [AccountSequenceNumber].  After a restart, an expiration, or a rejection, the queue
+// waits for its in-flight transactions to commit or expire before resyncing it and building more.
+//
+// Only single signer transactions are supported.
+//
+//	store, _ := NewFileTxnStore("txns")
+//	queue := NewTxnQueue(client, sender, store)
+//	_ = queue.Enqueue(1, payload)
+//	err := queue.Run(ctx)
+type TxnQueue struct {
+	client          AptosRpcClient
+	sender          TransactionSigner
+	store           TxnStore
+	sequenceNumbers *AccountSequenceNumber
+	buildOptions    []any
+	pollPeriod      time.Duration
+
+	mutex       sync.Mutex // Serializes processing
+	needsResync bool       // Sequence numbers may be out of sync with the records
+}
+
+// NewTxnQueue creates a [TxnQueue] for sender over store
+//
+// Accepts options:
+//   - [PollPeriod] how often [TxnQueue.Run] checks on transactions, defaults to 1 second
+//   - [*AccountSequenceNumber] to share sequence numbers with other senders of the account
+//   - any option of [NodeClient.BuildTransaction] except [SequenceNumber], used to build every transaction
+func NewTxnQueue(client AptosRpcClient, sender TransactionSigner, store TxnStore, options ...any) *TxnQueue {
+	sequenceNumbers, options := sequenceNumbersOption(client, sender.AccountAddress(), options)
+	options = withSimulationSigner(sender, options)
+	pollPeriod := time.Second
+	buildOptions := make([]any, 0, len(options)+1)
+	for _, option := range options {
+		if period, ok := option.(PollPeriod); ok {
+			pollPeriod = time.Duration(period)
+		} else {
+			buildOptions = append(buildOptions, option)
+		}
+	}
+	return &TxnQueue{
+		client:          client,
+		sender:          sender,
+		store:           store,
+		sequenceNumbers: sequenceNumbers,
+		buildOptions:    buildOptions,
+		pollPeriod:      pollPeriod,
+		needsResync:     true,
+	}
+}
+
+// Enqueue records payload as queued under id, to be built and submitted by [TxnQueue.Process].  Ids must be unique in
+// the store, and transactions are built in order of their ids.
+func (queue *TxnQueue) Enqueue(id uint64, payload TransactionPayload) error {
+	_, err := queue.store.Get(id)
+	if err == nil {
+		return fmt.Errorf("txn record %d already exists", id)
+	} else if !errors.Is(err, ErrTxnRecordNotFound) {
+		return err
+	}
+	payloadBytes, err := bcs.Serialize(&payload)
+	if err != nil {
+		return err
+	}
+	return queue.store.Put(&TxnRecord{
+		Id:      id,
+		Status:  TxnStatusQueued,
+		Payload: payloadBytes,
+	})
+}
+
+// Records returns the records of all transactions in the queue, ordered by id
+func (queue *TxnQueue) Records() ([]*TxnRecord, error) {
+	return queue.store.List()
+}
+
+// Run processes the queue until every transaction is committed or failed, checking on them every [PollPeriod]
+func (queue *TxnQueue) Run(ctx context.Context) error {
+	for {
+		outstanding, err := queue.Process(ctx)
+		if err != nil {
+			return err
+		}
+		if outstanding == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(queue.pollPeriod):
+		}
+	}
+}
+
+// Process makes a single pass over the queue.  It checks on signed and submitted transactions, then builds, signs and
+// submits queued ones.  It returns the number of transactions not yet committed or failed.
+//
+// A node or store error stops the pass, leaving the transactions to be picked up by the next one.
+func (queue *TxnQueue) Process(ctx context.Context) (int, error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	records, err := queue.store.List()
+	if err != nil {
+		return 0, err
+	}
+
+	// Check on everything in flight first, to learn whether sequence numbers are still valid.  Expiration is judged by
+	// the chain's clock, looked up before the transactions so none can commit in between.
+	var ledgerTimestampSeconds uint64
+	if slices.ContainsFunc(records, needsReconcile) {
+		info, err := queue.client.InfoCtx(ctx)
+		if err != nil {
+			return 0, err
+		}
+		ledgerTimestampSeconds = info.LedgerTimestamp() / 1_000_000
+	}
+	inFlight := 0
+	for _, record := range records {
+		if !needsReconcile(record) {
+			continue
+		}
+		err = queue.reconcile(ctx, record, ledgerTimestampSeconds)
+		if err != nil {
+			return 0, err
+		}
+		if record.Status == TxnStatusSigned || record.Status == TxnStatusSubmitted {
+			inFlight++
+		}
+	}
+
+	outstanding := 0
+	for _, record := range records {
+		if !record.Status.Done() {
+			outstanding++
+		}
+	}
+
+	if queue.needsResync {
+		if inFlight > 0 {
+			// Building now could reuse the sequence number of a transaction in flight
+			return outstanding, nil
+		}
+		err = queue.sequenceNumbers.ResyncCtx(ctx)
+		if err != nil {
+			return 0, err
+		}
+		queue.needsResync = false
+	}
+
+	for _, record := range records {
+		if record.Status != TxnStatusQueued && record.Status != TxnStatusExpired {
+			continue
+		}
+		err = queue.build(ctx, record)
+		if errors.Is(err, ErrSequenceNumbersInFlight) {
+			// The rest have to wait for transactions in flight to commit or expire
+			break
+		} else if err != nil {
+			return 0, err
+		}
+		if record.Status == TxnStatusSigned {
+			err = queue.submit(ctx, record)
+			if err != nil {
+				return 0, err
+			}
+		}
+		if record.Status.Done() {
+			outstanding--
+		}
+		if queue.needsResync {
+			// The rest have to wait for the sequence numbers to resync
+			break
+		}
+	}
+	return outstanding, nil
+}
+
+// needsReconcile is true for records that may be in flight on the chain, or expired ones that have been signed
+func needsReconcile(record *TxnRecord) bool {
+	switch record.Status {
+	case TxnStatusSigned, TxnStatusSubmitted:
+		return true
+	case TxnStatusExpired:
+		return record.Hash != ""
+	default:
+		return false
+	}
+}
+
+// reconcile looks up every hash a transaction has been signed with, resubmitting it if the node doesn't know any and
+// it hasn't expired by ledgerTimestampSeconds.  Expired transactions are only marked as committed or in flight.
+func (queue *TxnQueue) reconcile(ctx context.Context, record *TxnRecord, ledgerTimestampSeconds uint64) error {
+	txn, err := queue.lookup(ctx, record)
+	if err != nil {
+		return err
+	}
+	if txn != nil {
+		return queue.update(record, txn)
+	}
+	if record.Status == TxnStatusExpired {
+		// Rebuilt once the sequence numbers resync
+		return nil
+	}
+	if ledgerTimestampSeconds >= record.ExpirationTimestampSeconds {
+		queue.needsResync = true
+		record.Status = TxnStatusExpired
+		return queue.store.Put(record)
+	}
+	// Never reached the node, or dropped from its mempool
+	return queue.submit(ctx, record)
+}
+
+// lookup looks up the current and previous hashes of a transaction, returning the committed one if there is one, then
+// any pending one, or nil if the node doesn't know any of them
+func (queue *TxnQueue) lookup(ctx context.Context, record *TxnRecord) (*api.Transaction, error) {
+	var pending *api.Transaction
+	for _, hash := range append([]string{record.Hash}, record.PreviousHashes...) {
+		txn, err := queue.client.TransactionByHashCtx(ctx, hash)
+		if errors.Is(err, ErrTransactionNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if txn.Type != api.TransactionVariantPending {
+			return txn, nil
+		}
+		if pending == nil {
+			pending = txn
+		}
+	}
+	return pending, nil
+}
+
+// update records the status of a transaction the node knows
+func (queue *TxnQueue) update(record *TxnRecord, txn *api.Transaction) error {
+	switch txn.Type {
+	case api.TransactionVariantPending:
+		if record.Status == TxnStatusSubmitted {
+			return nil
+		}
+		record.Status = TxnStatusSubmitted
+	case api.TransactionVariantUser:
+		userTxn, err := txn.UserTransaction()
+		if err != nil {
+			return err
+		}
+		record.Hash = userTxn.Hash
+		record.Version = userTxn.Version
+		record.VmStatus = userTxn.VmStatus
+		if userTxn.Success {
+			record.Status = TxnStatusCommitted
+		} else {
+			record.Status = TxnStatusFailed
+		}
+	default:
+		return fmt.Errorf("txn record %d hash %s is a %s", record.Id, record.Hash, txn.Type)
+	}
+	return queue.store.Put(record)
+}
+
+// build builds and signs a queued or expired transaction with the next sequence number
+func (queue *TxnQueue) build(ctx context.Context, record *TxnRecord) error {
+	payload := &TransactionPayload{}
+	err := bcs.Deserialize(payload, record.Payload)
+	if err != nil {
+		return fmt.Errorf("txn record %d payload: %w", record.Id, err)
+	}
+
+	sequenceNumber, err := queue.sequenceNumbers.NextCtx(ctx)
+	if err != nil {
+		return err
+	}
+	options := append(queue.buildOptions[:len(queue.buildOptions):len(queue.buildOptions)], SequenceNumber(sequenceNumber))
+	rawTxn, err := queue.client.BuildTransactionCtx(ctx, queue.sender.AccountAddress(), *payload, options...)
+	if err != nil {
+		queue.sequenceNumbers.release(sequenceNumber)
+		var simulationErr *SimulationError
+		if errors.As(err, &simulationErr) {
+			// The payload can't succeed, so it won't be built again
+			record.Status = TxnStatusFailed
+			record.VmStatus = simulationErr.VmStatus
+			record.Error = err.Error()
+			return queue.store.Put(record)
+		}
+		return err
+	}
+	signedTxn, err := rawTxn.SignedTransaction(queue.sender)
+	if err != nil {
+		queue.sequenceNumbers.release(sequenceNumber)
+		return err
+	}
+	hash, err := signedTxn.Hash()
+	if err != nil {
+		queue.sequenceNumbers.release(sequenceNumber)
+		return err
+	}
+	signedTxnBytes, err := bcs.Serialize(signedTxn)
+	if err != nil {
+		queue.sequenceNumbers.release(sequenceNumber)
+		return err
+	}
+
+	if record.Hash != "" {
+		// The earlier transaction is kept, in case it commits after all
+		record.PreviousHashes = append(record.PreviousHashes, record.Hash)
+	}
+	record.Status = TxnStatusSigned
+	record.SignedTxn = signedTxnBytes
+	record.Hash = hash
+	record.SequenceNumber = rawTxn.SequenceNumber
+	record.ExpirationTimestampSeconds = rawTxn.ExpirationTimestampSeconds
+	record.Error = ""
+	return queue.store.Put(record)
+}
+
+// submit submits a signed transaction.  Rejections for its sequence number or expiration mark it to be rebuilt, and
+// other rejections fail it.
+func (queue *TxnQueue) submit(ctx context.Context, record *TxnRecord) error {
+	signedTxn := &SignedTransaction{}
+	err := bcs.Deserialize(signedTxn, record.SignedTxn)
+	if err != nil {
+		return fmt.Errorf("txn record %d signed transaction: %w", record.Id, err)
+	}
+
+	_, err = queue.client.SubmitTransactionCtx(ctx, signedTxn)
+	if err == nil {
+		record.Status = TxnStatusSubmitted
+		return queue.store.Put(record)
+	}
+
+	var httpErr *HttpError
+	if !errors.As(err, &httpErr) || httpErr.ApiError == nil {
+		// Not a rejection e.g. the node is unreachable, try again later
+		return err
+	}
+	if errors.Is(err, ErrSequenceNumberTooOld) {
+		// It may have been committed already by an earlier submission
+		txn, lookupErr := queue.lookup(ctx, record)
+		if lookupErr == nil && txn != nil && txn.Type == api.TransactionVariantUser {
+			return queue.update(record, txn)
+		}
+	}
+	// A rejected transaction doesn't use its sequence number
+	queue.needsResync = true
+	record.Error = err.Error()
+	if errors.Is(err, ErrSequenceNumberTooOld) || errors.Is(err, ErrSequenceNumberTooNew) || errors.Is(err, ErrTransactionExpired) {
+		record.Status = TxnStatusExpired
+	} else {
+		record.Status = TxnStatusFailed
+	}
+	return queue.store.Put(record)
+}
@@ -0,0 +1,229 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTxnNode accepts submitted transactions into a mempool, and commits them when told to
+type fakeTxnNode struct {
+	mutex          sync.Mutex
+	sequenceNumber uint64
+	pending        map[string]uint64 // Hash to sequence number
+	committed      map[string]bool
+	submissions    int
+	clockSkew      time.Duration // How far the ledger's clock is ahead of the local one
+}
+
+func newFakeTxnNode(t *testing.T) (*fakeTxnNode, *httptest.Server) {
+	t.Helper()
+	node := &fakeTxnNode{pending: map[string]uint64{}, committed: map[string]bool{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node.mutex.Lock()
+		defer node.mutex.Unlock()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/transactions":
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			signedTxn := &SignedTransaction{}
+			require.NoError(t, bcs.Deserialize(signedTxn, body))
+			hash, err := signedTxn.Hash()
+			require.NoError(t, err)
+			node.submissions++
+			node.pending[hash] = signedTxn.Transaction.SequenceNumber
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprintf(w, `{"hash":%q}`, hash)
+		case strings.HasPrefix(r.URL.Path, "/transactions/by_hash/"):
+			hash := strings.TrimPrefix(r.URL.Path, "/transactions/by_hash/")
+			if node.committed[hash] {
+				_, _ = fmt.Fprintf(w, `{"type":"user_transaction","version":"7","hash":%q,"success":true,"vm_status":"Executed successfully"}`, hash)
+			} else if _, ok := node.pending[hash]; ok {
+				_, _ = fmt.Fprintf(w, `{"type":"pending_transaction","hash":%q}`, hash)
+			} else {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]any{"message": "not found", "error_code": "transaction_not_found"})
+			}
+		case r.URL.Path == "/":
+			_, _ = fmt.Fprintf(w, `{"chain_id":4,"ledger_timestamp":"%d"}`, time.Now().Add(node.clockSkew).UnixMicro())
+		default:
+			_, _ = fmt.Fprintf(w, `{"sequence_number":"%d","authentication_key":"0x0000000000000000000000000000000000000000000000000000000000000001"}`, node.sequenceNumber)
+		}
+	}))
+	return node, server
+}
+
+// commitAll commits every pending transaction
+func (node *fakeTxnNode) commitAll() {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	for hash := range node.pending {
+		node.committed[hash] = true
+		node.sequenceNumber++
+	}
+	node.pending = map[string]uint64{}
+}
+
+// dropAll forgets every pending transaction, as if they expired
+func (node *fakeTxnNode) dropAll() {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	node.pending = map[string]uint64{}
+}
+
+// setClockSkew sets how far the ledger's clock is ahead of the local one
+func (node *fakeTxnNode) setClockSkew(skew time.Duration) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	node.clockSkew = skew
+}
+
+func newTestTxnQueue(t *testing.T, serverUrl string, sender *Account, store TxnStore, options ...any) *TxnQueue {
+	t.Helper()
+	client, err := NewNodeClient(serverUrl, 4)
+	require.NoError(t, err)
+	return NewTxnQueue(client, sender, store, append([]any{GasUnitPrice(100), PollPeriod(time.Millisecond)}, options...)...)
+}
+
+func enqueueTransfers(t *testing.T, queue *TxnQueue, count uint64) {
+	t.Helper()
+	for id := range count {
+		payload, err := CoinTransferPayload(nil, AccountOne, id+1)
+		require.NoError(t, err)
+		require.NoError(t, queue.Enqueue(id, TransactionPayload{Payload: payload}))
+	}
+}
+
+func TestTxnQueue_Restart(t *testing.T) {
+	t.Parallel()
+	node, server := newFakeTxnNode(t)
+	defer server.Close()
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	store, err := NewFileTxnStore(t.TempDir())
+	require.NoError(t, err)
+
+	queue := newTestTxnQueue(t, server.URL, sender, store)
+	enqueueTransfers(t, queue, 3)
+	outstanding, err := queue.Process(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, outstanding)
+
+	records, err := queue.Records()
+	require.NoError(t, err)
+	for i, record := range records {
+		assert.Equal(t, TxnStatusSubmitted, record.Status)
+		assert.Equal(t, uint64(i), record.SequenceNumber)
+	}
+
+	// A new queue over the same store finds them committed
+	node.commitAll()
+	queue = newTestTxnQueue(t, server.URL, sender, store)
+	require.NoError(t, queue.Run(context.Background()))
+	records, err = queue.Records()
+	require.NoError(t, err)
+	for _, record := range records {
+		assert.Equal(t, TxnStatusCommitted, record.Status)
+		assert.Equal(t, uint64(7), record.Version)
+	}
+	assert.Equal(t, 3, node.submissions)
+	require.Error(t, queue.Enqueue(1, TransactionPayload{}))
+}
+
+func TestTxnQueue_RebuildsExpired(t *testing.T) {
+	t.Parallel()
+	node, server := newFakeTxnNode(t)
+	defer server.Close()
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	store, err := NewFileTxnStore(t.TempDir())
+	require.NoError(t, err)
+
+	queue := newTestTxnQueue(t, server.URL, sender, store, ExpirationSeconds(0))
+	enqueueTransfers(t, queue, 2)
+	_, err = queue.Process(context.Background())
+	require.NoError(t, err)
+	records, err := queue.Records()
+	require.NoError(t, err)
+	expiredHashes := []string{records[0].Hash, records[1].Hash}
+
+	// The transactions expire without committing, and are rebuilt from the on-chain sequence number
+	node.dropAll()
+	time.Sleep(1100 * time.Millisecond)
+	_, err = queue.Process(context.Background())
+	require.NoError(t, err)
+
+	records, err = queue.Records()
+	require.NoError(t, err)
+	for i, record := range records {
+		assert.Equal(t, TxnStatusSubmitted, record.Status)
+		assert.Equal(t, uint64(i), record.SequenceNumber)
+		assert.NotEqual(t, expiredHashes[i], record.Hash)
+	}
+	assert.Equal(t, 4, node.submissions)
+}
+
+func TestTxnQueue_LedgerClock(t *testing.T) {
+	t.Parallel()
+	node, server := newFakeTxnNode(t)
+	defer server.Close()
+	sender, err := NewEd25519Account()
+	require.NoError(t, err)
+	store, err := NewFileTxnStore(t.TempDir())
+	require.NoError(t, err)
+
+	queue := newTestTxnQueue(t, server.URL, sender, store, ExpirationSeconds(0))
+	enqueueTransfers(t, queue, 1)
+	_, err = queue.Process(context.Background())
+	require.NoError(t, err)
+	record, err := store.Get(0)
+	require.NoError(t, err)
+	firstHash := record.Hash
+
+	// The local clock is past the expiration, but the ledger's isn't, so it's resubmitted rather than rebuilt
+	node.dropAll()
+	node.setClockSkew(-time.Hour)
+	time.Sleep(1100 * time.Millisecond)
+	_, err = queue.Process(context.Background())
+	require.NoError(t, err)
+	record, err = store.Get(0)
+	require.NoError(t, err)
+	assert.Equal(t, TxnStatusSubmitted, record.Status)
+	assert.Equal(t, firstHash, record.Hash)
+	assert.Equal(t, 2, node.submissions)
+
+	// The ledger's clock is past the expiration, so it's rebuilt, keeping the first hash
+	node.dropAll()
+	node.setClockSkew(time.Hour)
+	_, err = queue.Process(context.Background())
+	require.NoError(t, err)
+	record, err = store.Get(0)
+	require.NoError(t, err)
+	assert.Equal(t, TxnStatusSubmitted, record.Status)
+	assert.NotEqual(t, firstHash, record.Hash)
+	assert.Equal(t, []string{firstHash}, record.PreviousHashes)
+	assert.Equal(t, 3, node.submissions)
+
+	// The first transaction commits after all, so it's the one recorded
+	node.mutex.Lock()
+	node.pending = map[string]uint64{}
+	node.committed[firstHash] = true
+	node.mutex.Unlock()
+	_, err = queue.Process(context.Background())
+	require.NoError(t, err)
+	record, err = store.Get(0)
+	require.NoError(t, err)
+	assert.Equal(t, TxnStatusCommitted, record.Status)
+	assert.Equal(t, firstHash, record.Hash)
+}
@@ -0,0 +1,229 @@
+package aptos
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TxnStatus is the lifecycle state of a transaction in a [TxnQueue]
+type TxnStatus uint8
+
+const (
+	TxnStatusQueued    TxnStatus = iota // TxnStatusQueued is a payload waiting to be built and signed
+	TxnStatusSigned                     // TxnStatusSigned is a signed transaction that may not have reached the node
+	TxnStatusSubmitted                  // TxnStatusSubmitted is a transaction accepted by the node, but not yet committed
+	TxnStatusCommitted                  // TxnStatusCommitted is a transaction committed successfully
+	TxnStatusFailed                     // TxnStatusFailed is a transaction committed with a failure, or rejected by the node
+	TxnStatusExpired                    // TxnStatusExpired is a transaction that expired before committing, and will be rebuilt
+)
+
+// String returns the name of the status e.g. "submitted"
+func (status TxnStatus) String() string {
+	switch status {
+	case TxnStatusQueued:
+		return "queued"
+	case TxnStatusSigned:
+		return "signed"
+	case TxnStatusSubmitted:
+		return "submitted"
+	case TxnStatusCommitted:
+		return "committed"
+	case TxnStatusFailed:
+		return "failed"
+	case TxnStatusExpired:
+		return "expired"
+	default:
+		return "unknown(" + strconv.Itoa(int(status)) + ")"
+	}
+}
+
+// Done is true if the status is final, and the transaction won't be processed again
+func (status TxnStatus) Done() bool {
+	return status == TxnStatusCommitted || status == TxnStatusFailed
+}
+
+// TxnRecord is the persisted state of a single transaction in a [TxnQueue]
+type TxnRecord struct {
+	Id                         uint64    `json:"id"`                                     // Id given when the payload was enqueued
+	Status                     TxnStatus `json:"status"`                                 // Status in the lifecycle
+	Payload                    []byte    `json:"payload"`                                // Payload is the BCS encoded [TransactionPayload]
+	SignedTxn                  []byte    `json:"signed_txn,omitempty"`                   // SignedTxn is the BCS encoded [SignedTransaction], once signed
+	Hash                       string    `json:"hash,omitempty"`                         // Hash of SignedTxn
+	PreviousHashes             []string  `json:"previous_hashes,omitempty"`              // PreviousHashes of earlier SignedTxn that expired or were rejected
+	SequenceNumber             uint64    `json:"sequence_number,omitempty"`              // SequenceNumber of SignedTxn
+	ExpirationTimestampSeconds uint64    `json:"expiration_timestamp_seconds,omitempty"` // ExpirationTimestampSeconds of SignedTxn
+	Version                    uint64    `json:"version,omitempty"`                      // Version the transaction committed at
+	VmStatus                   string    `json:"vm_status,omitempty"`                    // VmStatus of the committed transaction
+	Error                      string    `json:"error,omitempty"`                        // Error that failed the transaction, if it was rejected
+}
+
+// TxnStore persists the [TxnRecord] of a [TxnQueue], so it can continue after a restart.  Implementations must make
+// each Put durable before returning, and be safe for concurrent use.
+type TxnStore interface {
+	// Put creates or replaces the record with the same Id
+	Put(record *TxnRecord) error
+
+	// Get returns the record with id, or an error wrapping [ErrTxnRecordNotFound]
+	Get(id uint64) (*TxnRecord, error)
+
+	// List returns all records ordered by Id
+	List() ([]*TxnRecord, error)
+
+	// Delete removes the record with id, if there is one
+	Delete(id uint64) error
+}
+
+// ErrTxnRecordNotFound is returned by a [TxnStore] for a missing record
+var ErrTxnRecordNotFound = errors.New("txn record not found")
+
+// FileTxnStore is a [TxnStore] keeping each record as a JSON file in a directory.  Records are written to a temporary
+// file and renamed into place, so a crash leaves either the old or the new record.
+type FileTxnStore struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+// NewFileTxnStore creates a [FileTxnStore] in dir, creating the directory if needed
+func NewFileTxnStore(dir string) (*FileTxnStore, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+	return &FileTxnStore{dir: dir}, nil
+}
+
+// Put creates or replaces the record with the same Id
+//
+// Implements:
+//   - [TxnStore]
+func (store *FileTxnStore) Put(record *TxnRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeFileAtomic(store.path(record.Id), data)
+}
+
+// writeFileAtomic replaces the file at path with data, a crash leaves either the old or the new file but never a
+// partial one.  The directory is synced too, so the new file is durable once it returns.
+func writeFileAtomic(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Only left behind on failure
+		_ = os.Remove(file.Name())
+	}()
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	err = dir.Sync()
+	closeErr = dir.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// Get returns the record with id, or an error wrapping [ErrTxnRecordNotFound]
+//
+// Implements:
+//   - [TxnStore]
+func (store *FileTxnStore) Get(id uint64) (*TxnRecord, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.read(store.path(id))
+}
+
+// List returns all records ordered by Id
+//
+// Implements:
+//   - [TxnStore]
+func (store *FileTxnStore) List() ([]*TxnRecord, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entries, err := os.ReadDir(store.dir)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*TxnRecord, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		record, err := store.read(filepath.Join(store.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	slices.SortFunc(records, func(a, b *TxnRecord) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	return records, nil
+}
+
+// Delete removes the record with id, if there is one
+//
+// Implements:
+//   - [TxnStore]
+func (store *FileTxnStore) Delete(id uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	err := os.Remove(store.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path is the file of the record with id
+func (store *FileTxnStore) path(id uint64) string {
+	return filepath.Join(store.dir, strconv.FormatUint(id, 10)+".json")
+}
+
+// read reads the record in file
+func (store *FileTxnStore) read(file string) (*TxnRecord, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrTxnRecordNotFound, filepath.Base(file))
+	} else if err != nil {
+		return nil, err
+	}
+	record := &TxnRecord{}
+	err = json.Unmarshal(data, record)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt txn record %s: %w", filepath.Base(file), err)
+	}
+	return record, nil
+}
@@ -0,0 +1,43 @@
+package aptos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTxnStore(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "txns")
+	store, err := NewFileTxnStore(dir)
+	require.NoError(t, err)
+
+	_, err = store.Get(1)
+	require.ErrorIs(t, err, ErrTxnRecordNotFound)
+
+	require.NoError(t, store.Put(&TxnRecord{Id: 10, Status: TxnStatusQueued, Payload: []byte{1, 2}}))
+	require.NoError(t, store.Put(&TxnRecord{Id: 2, Status: TxnStatusQueued, Payload: []byte{3}}))
+	require.NoError(t, store.Put(&TxnRecord{Id: 10, Status: TxnStatusSubmitted, Payload: []byte{1, 2}, Hash: "0x1234"}))
+
+	// A new store over the same directory sees the same records
+	store, err = NewFileTxnStore(dir)
+	require.NoError(t, err)
+	record, err := store.Get(10)
+	require.NoError(t, err)
+	assert.Equal(t, &TxnRecord{Id: 10, Status: TxnStatusSubmitted, Payload: []byte{1, 2}, Hash: "0x1234"}, record)
+
+	records, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, uint64(2), records[0].Id)
+	assert.Equal(t, uint64(10), records[1].Id)
+
+	require.NoError(t, store.Delete(2))
+	require.NoError(t, store.Delete(2))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -106,6 +106,81 @@ func EntryFunctionFromAbi(abi any, moduleAddress AccountAddress, moduleName stri
 	}, nil
 }
 
+// ViewFunctionFromAbi generates a [ViewPayload] for a view function from its module or function ABI, converting simple
+// inputs to BCS encoded ones with [ConvertArg]
+//
+//	payload, err := ViewFunctionFromAbi(moduleAbi, AccountOne, "coin", "balance", []any{"0x1::aptos_coin::AptosCoin"}, []any{"0x1"})
+func ViewFunctionFromAbi(abi any, moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, options ...any) (*ViewPayload, error) {
+	var function *api.MoveFunction
+	switch abi := abi.(type) {
+	case *api.MoveModule:
+		function = findFunction(abi, functionName)
+	case *api.MoveFunction:
+		function = abi
+	default:
+		return nil, fmt.Errorf("unknown abi type: %T", abi)
+	}
+
+	if function == nil {
+		return nil, fmt.Errorf("view function %s not found in module %s", functionName, moduleName)
+	}
+	if !function.IsView {
+		return nil, fmt.Errorf("function %s is not a view function in module %s", functionName, moduleName)
+	}
+
+	// Check type args length matches
+	if len(typeArgs) != len(function.GenericTypeParams) {
+		return nil, fmt.Errorf("view function %s expects %d type arguments, got %d", functionName, len(function.GenericTypeParams), len(typeArgs))
+	}
+
+	convertedTypeArgs := make([]TypeTag, len(typeArgs))
+	for i, typeArg := range typeArgs {
+		tag, err := ConvertTypeTag(typeArg)
+		if err != nil {
+			return nil, err
+		}
+		convertedTypeArgs[i] = *tag
+	}
+
+	// Check args length matches, view functions can't take a signer
+	if len(args) != len(function.Params) {
+		return nil, fmt.Errorf("view function %s expects %d arguments, got %d", functionName, len(function.Params), len(args))
+	}
+
+	convertedArgs := make([][]byte, len(args))
+	for i, arg := range args {
+		argType, err := ParseTypeTag(function.Params[i])
+		if err != nil {
+			return nil, err
+		}
+		b, err := ConvertArg(*argType, arg, convertedTypeArgs, options...)
+		if err != nil {
+			return nil, fmt.Errorf("view function %s argument %d: %w", functionName, i, err)
+		}
+		convertedArgs[i] = b
+	}
+
+	return &ViewPayload{
+		Module: ModuleId{
+			Address: moduleAddress,
+			Name:    moduleName,
+		},
+		Function: functionName,
+		ArgTypes: convertedTypeArgs,
+		Args:     convertedArgs,
+	}, nil
+}
+
+// findFunction finds an exposed function by name in a module ABI, returning nil if there is none
+func findFunction(abi *api.MoveModule, functionName string) *api.MoveFunction {
+	for _, fun := range abi.ExposedFunctions {
+		if fun.Name == functionName {
+			return fun
+		}
+	}
+	return nil
+}
+
 func ConvertTypeTag(typeArg any) (*TypeTag, error) {
 	switch typeArg := typeArg.(type) {
 	case TypeTag:
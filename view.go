@@ -27,3 +27,12 @@ func (vp *ViewPayload) MarshalBCS(ser *bcs.Serializer) {
 		ser.WriteBytes(a)
 	}
 }
+
+func (vp *ViewPayload) UnmarshalBCS(des *bcs.Deserializer) {
+	vp.Module.UnmarshalBCS(des)
+	vp.Function = des.ReadString()
+	vp.ArgTypes = bcs.DeserializeSequence[TypeTag](des)
+	vp.Args = bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, out *[]byte) {
+		*out = des.ReadBytes()
+	})
+}
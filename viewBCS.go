@@ -0,0 +1,374 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// ViewRaw calls a view function on the blockchain, returning each of its return values BCS encoded
+// Optionally, a ledgerVersion can be given to call the function at a specific ledger version
+//
+// To decode the values, see [ViewBCS] for a single return value, or [NodeClient.ViewTyped] to decode them by the ABI.
+func (rc *NodeClient) ViewRaw(payload *ViewPayload, ledgerVersion ...uint64) ([][]byte, error) {
+	return rc.ViewRawCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewRawCtx is [NodeClient.ViewRaw] with its requests bound to ctx
+func (rc *NodeClient) ViewRawCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([][]byte, error) {
+	sblob, err := bcs.Serialize(payload)
+	if err != nil {
+		return nil, err
+	}
+	au := rc.baseUrl.JoinPath("view")
+	if len(ledgerVersion) > 0 {
+		params := url.Values{}
+		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
+		au.RawQuery = params.Encode()
+	}
+
+	response, err := rc.withRetry(ctx, func() (*rawResponse, error) {
+		return rc.doRequest(ctx, http.MethodPost, au.String(), ContentTypeAptosViewFunctionBcs, "application/x-bcs", sblob, true)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("view function api err: %w", err)
+	}
+
+	// The values are a BCS sequence of each BCS encoded return value
+	des := bcs.NewDeserializer(response.body)
+	length := des.Uleb128()
+	values := make([][]byte, 0, length)
+	for range length {
+		values = append(values, des.ReadBytes())
+	}
+	if des.Error() != nil {
+		return nil, fmt.Errorf("view function api err: %w", des.Error())
+	}
+	return values, nil
+}
+
+// ViewBCS calls a view function with a single return value, and decodes it from BCS into a T
+// Optionally, a ledgerVersion can be given to call the function at a specific ledger version
+//
+//	objectCore, _ := ParseTypeTag("0x1::object::ObjectCore")
+//	owner, err := ViewBCS[AccountAddress](client, &ViewPayload{
+//		Module:   ModuleId{Address: AccountOne, Name: "object"},
+//		Function: "owner",
+//		ArgTypes: []TypeTag{*objectCore},
+//		Args:     [][]byte{objectAddress[:]},
+//	})
+func ViewBCS[T any, PT interface {
+	*T
+	bcs.Unmarshaler
+}](client AptosRpcClient, payload *ViewPayload, ledgerVersion ...uint64) (*T, error) {
+	return ViewBCSCtx[T, PT](context.Background(), client, payload, ledgerVersion...)
+}
+
+// ViewBCSCtx is [ViewBCS] with its requests bound to ctx
+func ViewBCSCtx[T any, PT interface {
+	*T
+	bcs.Unmarshaler
+}](ctx context.Context, client AptosRpcClient, payload *ViewPayload, ledgerVersion ...uint64) (*T, error) {
+	values, err := client.ViewRawCtx(ctx, payload, ledgerVersion...)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 1 {
+		return nil, fmt.Errorf("view function %s returned %d values, expected 1", payload.Function, len(values))
+	}
+	value := PT(new(T))
+	err = bcs.Deserialize(value, values[0])
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// ViewTyped calls a view function, and decodes its return values from BCS into Go types by the return types in the
+// module's ABI.  See [DecodeMoveValue] for the Go type of each Move type.
+// Optionally, a ledgerVersion can be given to call the function at a specific ledger version
+//
+//	values, err := client.ViewTyped(&ViewPayload{
+//		Module:   ModuleId{Address: AccountOne, Name: "coin"},
+//		Function: "balance",
+//		ArgTypes: []TypeTag{AptosCoinTypeTag},
+//		Args:     [][]byte{address[:]},
+//	})
+//	balance := values[0].(uint64)
+func (rc *NodeClient) ViewTyped(payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	return rc.ViewTypedCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewTypedCtx is [NodeClient.ViewTyped] with its requests bound to ctx
+func (rc *NodeClient) ViewTypedCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	abis := rc.moduleAbis(ctx, ledgerVersion...)
+	abi, err := abis(payload.Module)
+	if err != nil {
+		return nil, err
+	}
+	function := findFunction(abi, payload.Function)
+	if function == nil {
+		return nil, fmt.Errorf("view function %s not found in module %s", payload.Function, payload.Module.Name)
+	}
+	return rc.viewTyped(ctx, payload, function, abis, ledgerVersion...)
+}
+
+// ViewWithArgs calls a view function with simple inputs converted to BCS by the module's ABI, see
+// [ViewFunctionFromAbi], and decodes its return values like [NodeClient.ViewTyped]
+// Optionally, a ledgerVersion can be given to call the function at a specific ledger version
+//
+//	values, err := client.ViewWithArgs(AccountOne, "coin", "balance", []any{"0x1::aptos_coin::AptosCoin"}, []any{"0x1"})
+//	balance := values[0].(uint64)
+func (rc *NodeClient) ViewWithArgs(moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, ledgerVersion ...uint64) ([]any, error) {
+	return rc.ViewWithArgsCtx(context.Background(), moduleAddress, moduleName, functionName, typeArgs, args, ledgerVersion...)
+}
+
+// ViewWithArgsCtx is [NodeClient.ViewWithArgs] with its requests bound to ctx
+func (rc *NodeClient) ViewWithArgsCtx(ctx context.Context, moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any, ledgerVersion ...uint64) ([]any, error) {
+	abis := rc.moduleAbis(ctx, ledgerVersion...)
+	abi, err := abis(ModuleId{Address: moduleAddress, Name: moduleName})
+	if err != nil {
+		return nil, err
+	}
+	payload, err := ViewFunctionFromAbi(abi, moduleAddress, moduleName, functionName, typeArgs, args)
+	if err != nil {
+		return nil, err
+	}
+	return rc.viewTyped(ctx, payload, findFunction(abi, functionName), abis, ledgerVersion...)
+}
+
+// moduleAbis looks up module ABIs at ledgerVersion, each module is only fetched once.  At the latest ledger version,
+// they come from the client's [AbiCache] if it has one, revalidated with each package registry fetched only once.
+func (rc *NodeClient) moduleAbis(ctx context.Context, ledgerVersion ...uint64) func(moduleId ModuleId) (*api.MoveModule, error) {
+	modules := map[ModuleId]*api.MoveModule{}
+	cache := rc.abiCache.Load()
+	registries := map[AccountAddress]*packageRegistry{}
+	return func(moduleId ModuleId) (*api.MoveModule, error) {
+		if abi, ok := modules[moduleId]; ok {
+			return abi, nil
+		}
+		if cache != nil && len(ledgerVersion) == 0 {
+			abi, err := cache.moduleAbi(ctx, rc, moduleId, registries)
+			if err != nil {
+				return nil, err
+			}
+			modules[moduleId] = abi
+			return abi, nil
+		}
+		module, err := rc.AccountModuleCtx(ctx, moduleId.Address, moduleId.Name, ledgerVersion...)
+		if err != nil {
+			return nil, err
+		}
+		if module.Abi == nil {
+			return nil, fmt.Errorf("module %s::%s has no ABI", moduleId.Address.String(), moduleId.Name)
+		}
+		modules[moduleId] = module.Abi
+		return module.Abi, nil
+	}
+}
+
+// viewTyped calls a view function, and decodes its return values by the function ABI, with structs from abis
+func (rc *NodeClient) viewTyped(ctx context.Context, payload *ViewPayload, function *api.MoveFunction, abis func(moduleId ModuleId) (*api.MoveModule, error), ledgerVersion ...uint64) ([]any, error) {
+	values, err := rc.ViewRawCtx(ctx, payload, ledgerVersion...)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(function.Return) {
+		return nil, fmt.Errorf("view function %s returned %d values, expected %d", payload.Function, len(values), len(function.Return))
+	}
+
+	structs := func(tag *StructTag) (*api.MoveStruct, error) {
+		abi, err := abis(ModuleId{Address: tag.Address, Name: tag.Module})
+		if err != nil {
+			return nil, err
+		}
+		for _, moveStruct := range abi.Structs {
+			if moveStruct.Name == tag.Name {
+				return moveStruct, nil
+			}
+		}
+		return nil, fmt.Errorf("struct %s not found", tag.String())
+	}
+
+	out := make([]any, len(values))
+	for i, returnType := range function.Return {
+		typeTag, err := ParseTypeTag(returnType)
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := substituteGenerics(*typeTag, payload.ArgTypes)
+		if err != nil {
+			return nil, err
+		}
+		out[i], err = DecodeMoveValue(resolved, values[i], structs)
+		if err != nil {
+			return nil, fmt.Errorf("view function %s return value %d: %w", payload.Function, i, err)
+		}
+	}
+	return out, nil
+}
+
+// MoveStructResolver looks up the layout of a struct for [DecodeMoveValue]
+type MoveStructResolver func(tag *StructTag) (*api.MoveStruct, error)
+
+// DecodeMoveValue decodes a BCS encoded Move value of type typeTag into Go types:
+//   - bool, u8, u16, u32, u64 as bool, uint8, uint16, uint32, uint64
+//   - u128 and u256 as *big.Int
+//   - address and signer as [AccountAddress]
+//   - vector<u8> as []byte, and other vectors as []any
+//   - 0x1::string::String as string
+//   - 0x1::object::Object<T> as [AccountAddress]
+//   - 0x1::option::Option<T> as nil or the value
+//   - other structs as map[string]any of their fields, with the layout from structs
+//
+// structs may be nil if the value contains no other structs.
+func DecodeMoveValue(typeTag TypeTag, data []byte, structs MoveStructResolver) (any, error) {
+	des := bcs.NewDeserializer(data)
+	value := decodeMoveValue(des, typeTag, structs)
+	if des.Error() != nil {
+		return nil, des.Error()
+	}
+	if des.Remaining() != 0 {
+		return nil, fmt.Errorf("%d bytes left after decoding %s", des.Remaining(), typeTag.String())
+	}
+	return value, nil
+}
+
+// decodeMoveValue decodes a value of type typeTag, setting the error on des if it can't
+func decodeMoveValue(des *bcs.Deserializer, typeTag TypeTag, structs MoveStructResolver) any {
+	switch inner := typeTag.Value.(type) {
+	case *BoolTag:
+		return des.Bool()
+	case *U8Tag:
+		return des.U8()
+	case *U16Tag:
+		return des.U16()
+	case *U32Tag:
+		return des.U32()
+	case *U64Tag:
+		return des.U64()
+	case *U128Tag:
+		num := des.U128()
+		return &num
+	case *U256Tag:
+		num := des.U256()
+		return &num
+	case *AddressTag, *SignerTag:
+		address := AccountAddress{}
+		address.UnmarshalBCS(des)
+		return address
+	case *VectorTag:
+		if _, ok := inner.TypeParam.Value.(*U8Tag); ok {
+			return des.ReadBytes()
+		}
+		length := des.Uleb128()
+		values := make([]any, 0, length)
+		for range length {
+			values = append(values, decodeMoveValue(des, inner.TypeParam, structs))
+			if des.Error() != nil {
+				return nil
+			}
+		}
+		return values
+	case *StructTag:
+		return decodeMoveStruct(des, inner, structs)
+	default:
+		des.SetError(fmt.Errorf("cannot decode type %s", typeTag.String()))
+		return nil
+	}
+}
+
+// decodeMoveStruct decodes a struct value, with the well known framework structs decoded to their natural Go types
+func decodeMoveStruct(des *bcs.Deserializer, tag *StructTag, structs MoveStructResolver) any {
+	if tag.Address == AccountOne {
+		switch {
+		case tag.Module == "string" && tag.Name == "String":
+			return des.ReadString()
+		case tag.Module == "object" && tag.Name == "Object":
+			address := AccountAddress{}
+			address.UnmarshalBCS(des)
+			return address
+		case tag.Module == "option" && tag.Name == "Option" && len(tag.TypeParams) == 1:
+			// Option is a vector of at most one element
+			switch des.Uleb128() {
+			case 0:
+				return nil
+			case 1:
+				return decodeMoveValue(des, tag.TypeParams[0], structs)
+			default:
+				des.SetError(errors.New("invalid option length"))
+				return nil
+			}
+		}
+	}
+
+	if structs == nil {
+		des.SetError(fmt.Errorf("cannot decode struct %s without its layout", tag.String()))
+		return nil
+	}
+	layout, err := structs(tag)
+	if err != nil {
+		des.SetError(err)
+		return nil
+	}
+	fields := make(map[string]any, len(layout.Fields))
+	for _, field := range layout.Fields {
+		fieldType, err := ParseTypeTag(field.Type)
+		if err != nil {
+			des.SetError(err)
+			return nil
+		}
+		resolved, err := substituteGenerics(*fieldType, tag.TypeParams)
+		if err != nil {
+			des.SetError(err)
+			return nil
+		}
+		fields[field.Name] = decodeMoveValue(des, resolved, structs)
+		if des.Error() != nil {
+			return nil
+		}
+	}
+	return fields
+}
+
+// substituteGenerics replaces the generics T0, T1, ... in typeTag with the given types
+func substituteGenerics(typeTag TypeTag, generics []TypeTag) (TypeTag, error) {
+	switch inner := typeTag.Value.(type) {
+	case *GenericTag:
+		if inner.Num >= uint64(len(generics)) {
+			return TypeTag{}, fmt.Errorf("generic %s out of bounds, %d types given", inner.String(), len(generics))
+		}
+		return generics[inner.Num], nil
+	case *ReferenceTag:
+		return substituteGenerics(inner.TypeParam, generics)
+	case *VectorTag:
+		param, err := substituteGenerics(inner.TypeParam, generics)
+		if err != nil {
+			return TypeTag{}, err
+		}
+		return TypeTag{Value: &VectorTag{TypeParam: param}}, nil
+	case *StructTag:
+		params := make([]TypeTag, len(inner.TypeParams))
+		for i, typeParam := range inner.TypeParams {
+			param, err := substituteGenerics(typeParam, generics)
+			if err != nil {
+				return TypeTag{}, err
+			}
+			params[i] = param
+		}
+		return TypeTag{Value: &StructTag{
+			Address:    inner.Address,
+			Module:     inner.Module,
+			Name:       inner.Name,
+			TypeParams: params,
+		}}, nil
+	default:
+		return typeTag, nil
+	}
+}
@@ -0,0 +1,193 @@
+package aptos
+
+import (
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serializeViewValues encodes return values the way the node does for a BCS view response
+func serializeViewValues(t *testing.T, values ...[]byte) []byte {
+	t.Helper()
+	ser := &bcs.Serializer{}
+	ser.Uleb128(uint32(len(values)))
+	for _, value := range values {
+		ser.WriteBytes(value)
+	}
+	require.NoError(t, ser.Error())
+	return ser.ToBytes()
+}
+
+func TestDecodeMoveValue(t *testing.T) {
+	t.Parallel()
+	mustSerialize := func(f func(ser *bcs.Serializer)) []byte {
+		ser := &bcs.Serializer{}
+		f(ser)
+		require.NoError(t, ser.Error())
+		return ser.ToBytes()
+	}
+	tag := func(typeStr string) TypeTag {
+		typeTag, err := ParseTypeTag(typeStr)
+		require.NoError(t, err)
+		return *typeTag
+	}
+
+	tests := []struct {
+		typeTag  TypeTag
+		data     []byte
+		expected any
+	}{
+		{tag("bool"), []byte{1}, true},
+		{tag("u16"), mustSerialize(func(ser *bcs.Serializer) { ser.U16(513) }), uint16(513)},
+		{tag("u64"), mustSerialize(func(ser *bcs.Serializer) { ser.U64(1_000_000) }), uint64(1_000_000)},
+		{tag("u128"), mustSerialize(func(ser *bcs.Serializer) { ser.U128(*big.NewInt(12345)) }), big.NewInt(12345)},
+		{tag("address"), AccountTwo[:], AccountTwo},
+		{tag("vector<u8>"), []byte{2, 0xab, 0xcd}, []byte{0xab, 0xcd}},
+		{tag("vector<u32>"), mustSerialize(func(ser *bcs.Serializer) { ser.Uleb128(2); ser.U32(1); ser.U32(2) }), []any{uint32(1), uint32(2)}},
+		{tag("0x1::string::String"), mustSerialize(func(ser *bcs.Serializer) { ser.WriteString("hello") }), "hello"},
+		{tag("0x1::object::Object<0x1::object::ObjectCore>"), AccountOne[:], AccountOne},
+		{tag("0x1::option::Option<u8>"), []byte{0}, nil},
+		{tag("0x1::option::Option<u8>"), []byte{1, 7}, uint8(7)},
+	}
+	for _, test := range tests {
+		value, err := DecodeMoveValue(test.typeTag, test.data, nil)
+		require.NoError(t, err, test.typeTag.String())
+		assert.Equal(t, test.expected, value, test.typeTag.String())
+	}
+
+	// Extra bytes are an error
+	_, err := DecodeMoveValue(tag("u8"), []byte{1, 2}, nil)
+	require.Error(t, err)
+
+	// Structs need a layout, and their generics are filled in
+	structs := func(structTag *StructTag) (*api.MoveStruct, error) {
+		assert.Equal(t, "0x42::pair::Pair<u8,bool>", structTag.String())
+		return &api.MoveStruct{Name: "Pair", Fields: []*api.MoveStructField{
+			{Name: "first", Type: "T0"},
+			{Name: "second", Type: "vector<T1>"},
+		}}, nil
+	}
+	value, err := DecodeMoveValue(tag("0x42::pair::Pair<u8,bool>"), []byte{5, 2, 1, 0}, structs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"first": uint8(5), "second": []any{true, false}}, value)
+	_, err = DecodeMoveValue(tag("0x42::pair::Pair<u8,bool>"), []byte{5, 2, 1, 0}, nil)
+	require.Error(t, err)
+}
+
+func TestNodeClient_ViewTyped(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/0x1/module/coin":
+			_, _ = w.Write([]byte(`{"bytecode":"0x00","abi":{"address":"0x1","name":"coin","exposed_functions":[
+				{"name":"balance","is_view":true,"generic_type_params":[{"constraints":[]}],"params":["address"],"return":["u64","0x1::option::Option<T0>"]}
+			],"structs":[]}}`))
+		case "/view":
+			assert.Equal(t, "application/x-bcs", r.Header.Get("Accept"))
+			balance, err := bcs.SerializeU64(500)
+			require.NoError(t, err)
+			_, _ = w.Write(serializeViewValues(t, balance, append([]byte{1}, AccountTwo[:]...)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+	payload := &ViewPayload{
+		Module:   ModuleId{Address: AccountOne, Name: "coin"},
+		Function: "balance",
+		ArgTypes: []TypeTag{{Value: &AddressTag{}}},
+		Args:     [][]byte{AccountOne[:]},
+	}
+
+	values, err := client.ViewTyped(payload)
+	require.NoError(t, err)
+	assert.Equal(t, []any{uint64(500), AccountTwo}, values)
+
+	raw, err := client.ViewRaw(payload)
+	require.NoError(t, err)
+	require.Len(t, raw, 2)
+
+	_, err = ViewBCS[AccountAddress](client, payload)
+	require.Error(t, err)
+}
+
+func TestViewBCS(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/view", r.URL.Path)
+		_, _ = w.Write(serializeViewValues(t, AccountTwo[:]))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	owner, err := ViewBCS[AccountAddress](client, &ViewPayload{
+		Module:   ModuleId{Address: AccountOne, Name: "object"},
+		Function: "owner",
+		Args:     [][]byte{AccountOne[:]},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, AccountTwo, *owner)
+}
+
+func TestNodeClient_ViewWithArgs(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/0x1/module/coin":
+			_, _ = w.Write([]byte(`{"bytecode":"0x00","abi":{"address":"0x1","name":"coin","exposed_functions":[
+				{"name":"balance","is_view":true,"generic_type_params":[{"constraints":[]}],"params":["address"],"return":["u64"]},
+				{"name":"transfer","is_view":false,"generic_type_params":[],"params":["&signer","address","u64"],"return":[]}
+			],"structs":[]}}`))
+		case "/view":
+			coinType, err := ParseTypeTag("0x1::aptos_coin::AptosCoin")
+			require.NoError(t, err)
+			expected, err := bcs.Serialize(&ViewPayload{
+				Module:   ModuleId{Address: AccountOne, Name: "coin"},
+				Function: "balance",
+				ArgTypes: []TypeTag{*coinType},
+				Args:     [][]byte{AccountTwo[:]},
+			})
+			require.NoError(t, err)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, expected, body)
+			balance, err := bcs.SerializeU64(500)
+			require.NoError(t, err)
+			_, _ = w.Write(serializeViewValues(t, balance))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL, 4)
+	require.NoError(t, err)
+
+	values, err := client.ViewWithArgs(AccountOne, "coin", "balance", []any{"0x1::aptos_coin::AptosCoin"}, []any{"0x2"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{uint64(500)}, values)
+
+	// Wrong number of type arguments and arguments
+	_, err = client.ViewWithArgs(AccountOne, "coin", "balance", []any{}, []any{"0x2"})
+	require.Error(t, err)
+	_, err = client.ViewWithArgs(AccountOne, "coin", "balance", []any{"0x1::aptos_coin::AptosCoin"}, []any{})
+	require.Error(t, err)
+
+	// Not a view function
+	_, err = client.ViewWithArgs(AccountOne, "coin", "transfer", []any{}, []any{"0x2", uint64(1)})
+	require.Error(t, err)
+	_, err = client.ViewWithArgs(AccountOne, "coin", "missing", []any{}, []any{})
+	require.Error(t, err)
+}